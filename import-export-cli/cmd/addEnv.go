@@ -33,6 +33,8 @@ var flagRegistrationEndpoint string // registration endpoint of the environment
 var flagApiManagerEndpoint string   // api manager endpoint of the environment to be added
 var flagAdminEndpoint string        // admin endpoint of the environment to be added
 var flagMiManagementEndpoint string // mi management endpoint of the environment to be added
+var flagProtectedEnv bool           // whether the destructive vcs deployments to the environment need an approval
+var flagMaxChanges int              // maximum number of projects deployed to a protected environment without an approval
 
 // AddEnv command related Info
 const AddEnvCmdLiteral = "env [environment]"
@@ -62,10 +64,16 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 --registration https://idp.com:9443 \
 --token https://gw.com:8243/token
 
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` production \
+--apim  https://apim.com:9443 \
+--protected --max-changes 10
+
 You can either provide only the flag --apim , or all the other 4 flags (--registration --publisher --devportal --admin) without providing --apim flag.
 If you are omitting any of --registration --publisher --devportal --admin flags, you need to specify --apim flag with the API Manager endpoint. In both of the
 cases --token flag is optional and use it to specify the gateway token endpoint. This will be used for "apictl get-keys" operation.
-To add a micro integrator instance to an environment you can use the --mi flag.`
+To add a micro integrator instance to an environment you can use the --mi flag.
+Use --protected to require an approval for the 'vcs deploy' commands which delete projects from the environment, 
+downgrade the lifecycle status of APIs or deploy more projects than --max-changes at once.`
 
 // addEnvCmd represents the addEnv command
 var addEnvCmd = &cobra.Command{
@@ -92,6 +100,8 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints.AdminEndpoint = flagAdminEndpoint
 	envEndpoints.TokenEndpoint = flagTokenEndpoint
 	envEndpoints.MiManagementEndpoint = flagMiManagementEndpoint
+	envEndpoints.Protected = flagProtectedEnv
+	envEndpoints.MaxChanges = flagMaxChanges
 	err := impl.AddEnv(envToBeAdded, envEndpoints, mainConfigFilePath, AddEnvCmdLiteral)
 	if err != nil {
 		utils.HandleErrorAndExit("Error adding environment", err)
@@ -110,5 +120,9 @@ func init() {
 		"Registration endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAdminEndpoint, "admin", "", "Admin endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagMiManagementEndpoint, "mi", "", "Micro Integrator Management endpoint for the environment")
+	addEnvCmd.Flags().BoolVar(&flagProtectedEnv, "protected", false,
+		"Require an approval for the destructive vcs deployments to the environment")
+	addEnvCmd.Flags().IntVar(&flagMaxChanges, "max-changes", 0,
+		"Maximum number of projects deployed to the protected environment at once without an approval")
 	_ = addEnvCmd.MarkFlagRequired("environment")
 }
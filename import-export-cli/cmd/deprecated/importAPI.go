@@ -19,6 +19,8 @@
 package deprecated
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -66,8 +68,8 @@ var ImportAPICmdDeprecated = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
-		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup, false, false)
+		err = impl.ImportAPIToEnv(os.Stdout, accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile,
+			importAPIUpdate, importAPICmdPreserveProvider, importAPISkipCleanup, false, false)
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
@@ -30,12 +30,15 @@ const exportCmdShortDesc = "Export an API/API Product/Application in an environm
 const exportCmdLongDesc = `Export an API available in the environment specified by flag (--environment, -e)
 Export APIs available in the environment specified by flag (--environment, -e)
 Export an API Product available in the environment specified by flag (--environment, -e)
-Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)`
+Export API Products available in the environment specified by flag (--environment, -e)
+Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)
+Export Applications available in the environment specified by flag (--environment, -e)`
 
 const exportCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIProductCmdLiteral + ` -n LeasingAPIProduct -e dev
-` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev`
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppCmdLiteral + ` -n SampleApp -o admin -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e dev --with-keys`
 
 // ExportCmd represents the export command
 var ExportCmd = &cobra.Command{
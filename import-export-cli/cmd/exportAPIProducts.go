@@ -0,0 +1,91 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const ExportAPIProductsCmdLiteral = "api-products"
+const exportAPIProductsCmdShortDesc = "Export API Products for migration"
+
+const exportAPIProductsCmdLongDesc = "Export all the API Products of a tenant from one environment, to be imported " +
+	"into another environment using 'import api-products'"
+const exportAPIProductsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIProductsCmdLiteral + ` -e production --force
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIProductsCmdLiteral + ` -e production
+NOTE: The flag (--environment (-e)) is mandatory`
+
+var exportAPIProductsFormat string
+
+var ExportAPIProductsCmd = &cobra.Command{
+	Use: ExportAPIProductsCmdLiteral + " (--environment " +
+		"<environment-from-which-artifacts-should-be-exported> --format <export-format> --force)",
+	Short:   exportAPIProductsCmdShortDesc,
+	Long:    exportAPIProductsCmdLongDesc,
+	Example: exportAPIProductsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportAPIProductsCmdLiteral + " called")
+		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
+
+		cred, err := GetCredentials(CmdExportEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeExportAPIProductsCmd(cred, artifactExportDirectory)
+	},
+}
+
+// Do operations to export API Products for the migration into the directory passed as exportDirectory
+// exportDirectory = <export_directory>/migration/
+func executeExportAPIProductsCmd(credential credentials.Credential, exportDirectory string) {
+	apiProductExportDir := impl.CreateExportAPIProductsDirStructure(exportDirectory, CmdResourceTenantDomain,
+		CmdExportEnvironment, CmdForceStartFromBegin)
+	exportRelatedFilesPath := filepath.Join(exportDirectory, CmdExportEnvironment,
+		utils.GetMigrationExportTenantDirName(CmdResourceTenantDomain))
+
+	fmt.Println("\nExporting API Products for the migration...")
+	if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiProductFileName)) &&
+		!CmdForceStartFromBegin {
+		impl.PrepareAPIProductsExportResumption(credential, exportRelatedFilesPath, CmdResourceTenantDomain,
+			CmdUsername, CmdExportEnvironment)
+	} else {
+		impl.PrepareAPIProductsExportStartFromBeginning(credential, exportRelatedFilesPath, CmdResourceTenantDomain,
+			CmdUsername, CmdExportEnvironment)
+	}
+
+	impl.ExportAPIProducts(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain,
+		exportAPIProductsFormat, CmdUsername, apiProductExportDir)
+}
+
+func init() {
+	ExportCmd.AddCommand(ExportAPIProductsCmd)
+	ExportAPIProductsCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment from which the API Products should be exported")
+	ExportAPIProductsCmd.PersistentFlags().BoolVarP(&CmdForceStartFromBegin, "force", "", false,
+		"Clean all the previously exported API Products of the given target tenant, in the given environment if "+
+			"any, and to export API Products from beginning")
+	ExportAPIProductsCmd.Flags().StringVarP(&exportAPIProductsFormat, "format", "", utils.DefaultExportFormat,
+		"File format of exported archives(json or yaml)")
+	_ = ExportAPIProductsCmd.MarkFlagRequired("environment")
+}
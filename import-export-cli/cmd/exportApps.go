@@ -0,0 +1,94 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const ExportAppsCmdLiteral = "apps"
+const exportAppsCmdShortDesc = "Export Applications for migration"
+
+const exportAppsCmdLongDesc = "Export all the Applications of a tenant from one environment, to be imported " +
+	"into another environment using 'import apps'"
+const exportAppsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e production --force
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e production --with-keys
+NOTE: The flag (--environment (-e)) is mandatory`
+
+var exportAppsFormat string
+var exportAppsWithKeys bool
+
+var ExportAppsCmd = &cobra.Command{
+	Use: ExportAppsCmdLiteral + " (--environment " +
+		"<environment-from-which-artifacts-should-be-exported> --format <export-format> --with-keys --force)",
+	Short:   exportAppsCmdShortDesc,
+	Long:    exportAppsCmdLongDesc,
+	Example: exportAppsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportAppsCmdLiteral + " called")
+		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
+
+		cred, err := GetCredentials(CmdExportEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeExportAppsCmd(cred, artifactExportDirectory)
+	},
+}
+
+// Do operations to export Applications for the migration into the directory passed as exportDirectory
+// exportDirectory = <export_directory>/migration/
+func executeExportAppsCmd(credential credentials.Credential, exportDirectory string) {
+	appExportDir := impl.CreateExportAppsDirStructure(exportDirectory, CmdResourceTenantDomain, CmdExportEnvironment,
+		CmdForceStartFromBegin)
+	exportRelatedFilesPath := filepath.Join(exportDirectory, CmdExportEnvironment,
+		utils.GetMigrationExportTenantDirName(CmdResourceTenantDomain))
+
+	fmt.Println("\nExporting Applications for the migration...")
+	if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededAppFileName)) &&
+		!CmdForceStartFromBegin {
+		impl.PrepareAppsExportResumption(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername,
+			CmdExportEnvironment)
+	} else {
+		impl.PrepareAppsExportStartFromBeginning(credential, exportRelatedFilesPath, CmdResourceTenantDomain,
+			CmdUsername, CmdExportEnvironment)
+	}
+
+	impl.ExportApps(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain,
+		exportAppsFormat, CmdUsername, appExportDir, exportAppsWithKeys)
+}
+
+func init() {
+	ExportCmd.AddCommand(ExportAppsCmd)
+	ExportAppsCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment from which the Applications should be exported")
+	ExportAppsCmd.PersistentFlags().BoolVarP(&CmdForceStartFromBegin, "force", "", false,
+		"Clean all the previously exported Applications of the given target tenant, in the given environment if "+
+			"any, and to export Applications from beginning")
+	ExportAppsCmd.Flags().BoolVarP(&exportAppsWithKeys, "with-keys", "", false,
+		"Export keys of the Applications")
+	ExportAppsCmd.Flags().StringVarP(&exportAppsFormat, "format", "", utils.DefaultExportFormat,
+		"File format of exported archives(json or yaml)")
+	_ = ExportAppsCmd.MarkFlagRequired("environment")
+}
@@ -25,12 +25,14 @@ import (
 
 // Get command related usage Info
 const GenCmdLiteral = "gen"
-const GenCmdShortDesc = "Generate deployment directory for VM and K8S operator"
+const GenCmdShortDesc = "Generate deployment directory for VM and K8S operator, or an OpenAPI definition of an API project"
 
 const GenCmdLongDesc = `Generate sample directory with all the contents to use as the deployment directory` +
-	`  when performing CI/CD pipeline tasks `
+	`  when performing CI/CD pipeline tasks, or an OpenAPI definition annotated with the x-wso2 extensions from` +
+	` an API project`
 
-const GenCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenDeploymentDirCmdLiteral
+const GenCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenDeploymentDirCmdLiteral + `
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + genOASCmdLiteral + ` -f PizzaShackAPI-1.0.0`
 
 // ListCmd represents the list command
 var GenCmd = &cobra.Command{
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var genOASFile string
+var genOASOutput string
+
+// gen oas command related usage Info
+const genOASCmdLiteral = "oas"
+const genOASCmdShortDesc = "Generate an OpenAPI definition with the x-wso2 extensions from an API project"
+const genOASCmdLongDesc = `Merge the details of the API in the api.yaml of an API project (the context, whether the API is the
+default version, the endpoints, the CORS configuration, the transports, the authorization header, the security
+schemes, the throttling policies, the scopes and the auth type of each operation) into the Swagger/OpenAPI definition
+of the project as x-wso2 extensions. The definition can be used to initialize the project again with init --oas. The
+definition of the project is updated unless an output file is given.`
+const genOASCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + genOASCmdLiteral + ` -f PizzaShackAPI-1.0.0
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + genOASCmdLiteral + ` -f PizzaShackAPI-1.0.0 -o pizzashack.yaml`
+
+// genOASCmd represents the gen oas command
+var genOASCmd = &cobra.Command{
+	Use:     genOASCmdLiteral + " (--file <path-to-the-api-project>)",
+	Short:   genOASCmdShortDesc,
+	Long:    genOASCmdLongDesc,
+	Example: genOASCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GenCmdLiteral + " " + genOASCmdLiteral + " called")
+		outputPath, err := impl.GenerateOASFromProject(genOASFile, genOASOutput)
+		if err != nil {
+			utils.HandleErrorAndExit("Error generating the OpenAPI definition of the project "+genOASFile, err)
+		}
+		fmt.Println("OpenAPI definition with the x-wso2 extensions written to " + outputPath)
+	},
+}
+
+func init() {
+	GenCmd.AddCommand(genOASCmd)
+	genOASCmd.Flags().StringVarP(&genOASFile, "file", "f", "", "Path of the API project directory")
+	genOASCmd.Flags().StringVarP(&genOASOutput, "output", "o", "", "Path of the file to write the definition "+
+		"to, instead of updating the definition of the project")
+	_ = genOASCmd.MarkFlagRequired("file")
+}
@@ -19,7 +19,13 @@
 package cmd
 
 import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -29,11 +35,14 @@ const importCmdShortDesc = "Import an API/API Product/Application to an environm
 
 const importCmdLongDesc = `Import an API to the environment specified by flag (--environment, -e)
 Import an API Product to the environment specified by flag (--environment, -e)
-Import an Application to the environment specified by flag (--environment, -e)`
+Import an Application to the environment specified by flag (--environment, -e)
+Import all the APIs, API Products or Applications in a directory to the environment specified by flag (--environment, -e)`
 
 const importCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f qa/LeasingAPIProduct.zip -e dev
-` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev`
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source qa/apis -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source qa/apps -e dev`
 
 // ImportCmd represents the import command
 var ImportCmd = &cobra.Command{
@@ -47,6 +56,57 @@ var ImportCmd = &cobra.Command{
 	},
 }
 
+// Do operations to import the archives in the source directory for the migration, keeping the state of the import in
+// <export_directory>/migration-import/<environment>, so that the import can be resumed
+func executeMigrationImportCmd(credential credentials.Credential, migrationImport *impl.MigrationImport, source,
+	importEnvironment string, startFromBeginning bool) {
+	failedCount := runMigrationImport(credential, migrationImport, source, importEnvironment, startFromBeginning)
+	if failedCount > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(failedCount)+" "+migrationImport.ArtifactType+"(s) failed to "+
+			"import. Fix those and run the command with --force, or import those one by one", nil)
+	}
+	fmt.Println("\nCommand: import " + migrationImport.ArtifactType + "s execution completed !")
+}
+
+// Import the archives in the source directory for the migration and return the number of archives failed to import
+func runMigrationImport(credential credentials.Credential, migrationImport *impl.MigrationImport, source,
+	importEnvironment string, startFromBeginning bool) int {
+	source, err := filepath.Abs(source)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while resolving the source directory "+source, err)
+	}
+	if isDir, _ := utils.IsDirExists(source); !isDir {
+		utils.HandleErrorAndExit("The source directory "+source+" does not exist", nil)
+	}
+	importDirectory := filepath.Join(utils.ExportDirectory, utils.ImportedMigrationArtifactsDirName)
+	//e.g. /home/samithac/.wso2apictl/exported/migration-import/production
+	importRelatedFilesPath := filepath.Join(importDirectory, importEnvironment)
+	if err := utils.CreateDirIfNotExist(importDirectory); err != nil {
+		utils.HandleErrorAndExit("Error in creating directory structure for the import for migration .", err)
+	}
+	if err := utils.CreateDirIfNotExist(importRelatedFilesPath); err != nil {
+		utils.HandleErrorAndExit("Error in creating directory structure for the import for migration .", err)
+	}
+
+	fmt.Println("\nImporting " + migrationImport.ArtifactType + "s for the migration...")
+	importMetadata, startingIndex := migrationImport.Prepare(importRelatedFilesPath, source, importEnvironment,
+		credential.Username, startFromBeginning)
+	return migrationImport.Import(credential, importRelatedFilesPath, importEnvironment, importMetadata,
+		startingIndex)
+}
+
+// Returns the absolute path of the params directory of a migration import, or empty if not given
+func getMigrationImportParamsDir(paramsDir string) string {
+	if paramsDir == "" {
+		return ""
+	}
+	absParamsDir, err := filepath.Abs(paramsDir)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while resolving the params directory "+paramsDir, err)
+	}
+	return absParamsDir
+}
+
 // init using Cobra
 func init() {
 	RootCmd.AddCommand(ImportCmd)
@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -69,7 +71,7 @@ var ImportAPIProductCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API Product", err)
 		}
-		err = impl.ImportAPIProductToEnv(accessOAuthToken, importAPIProductEnvironment, importAPIProductFile, importAPIProductParamsFile,
+		err = impl.ImportAPIProductToEnv(os.Stdout, accessOAuthToken, importAPIProductEnvironment, importAPIProductFile, importAPIProductParamsFile,
 			importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductCmdPreserveProvider, importAPIProductSkipCleanup,
 			importAPIProductRotateRevision, importAPIProductSkipDeployments)
 		if err != nil {
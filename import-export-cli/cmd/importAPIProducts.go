@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAPIProductsSource             string
+	importAPIProductsEnvironment        string
+	importAPIProductsParamsDir          string
+	importAPIProductsPreserveProvider   bool
+	importAPIProductsImportAPIs         bool
+	importAPIProductsUpdate             bool
+	importAPIProductsUpdateAPIs         bool
+	importAPIProductsSkipCleanup        bool
+	importAPIProductsRotateRevision     bool
+	importAPIProductsSkipDeployments    bool
+	importAPIProductsStartFromBeginning bool
+)
+
+const (
+	// ImportAPIProducts command related usage info
+	ImportAPIProductsCmdLiteral   = "api-products"
+	importAPIProductsCmdShortDesc = "Import API Products for migration"
+	importAPIProductsCmdLongDesc  = `Import all the API Product archives (zip files) in a directory, such as the API Products
+exported using 'export api-products', into an environment. An API Product failed to import does not stop the import of
+the rest of the API Products, and a summary of the imported and failed API Products is shown at the end.
+If the import is halted, running the same command again retries the API Products failed earlier and resumes the import
+after the API Product imported last. Use --force to import all the API Products from beginning.
+The params of each API Product can be given in the --params directory, either as a directory generated using
+'gen deployment-dir' or as a params file, named after the archive (e.g. LeasingAPIProduct_1.0.0 or
+LeasingAPIProduct_1.0.0.yaml for LeasingAPIProduct_1.0.0.zip).`
+)
+
+const importAPIProductsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIProductsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/production/tenant-default/api-products -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIProductsCmdLiteral + ` --source ~/api-products -e dev --params ~/api-products-params --update-api-product
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIProductsCmdLiteral + ` --source ~/api-products -e dev --force
+NOTE: Both the flags (--source and --environment (-e)) are mandatory`
+
+// ImportAPIProductsCmd represents the import api-products command
+var ImportAPIProductsCmd = &cobra.Command{
+	Use: ImportAPIProductsCmdLiteral + " --source <path-to-api-product-archives-directory> --environment " +
+		"<environment>",
+	Short:   importAPIProductsCmdShortDesc,
+	Long:    importAPIProductsCmdLongDesc,
+	Example: importAPIProductsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAPIProductsCmdLiteral + " called")
+		cred, err := GetCredentials(importAPIProductsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		migrationImport := impl.NewAPIProductsMigrationImport(getMigrationImportParamsDir(importAPIProductsParamsDir),
+			func(accessToken, archivePath, paramsPath string, _ bool) error {
+				return impl.ImportAPIProductToEnv(os.Stdout, accessToken, importAPIProductsEnvironment, archivePath, paramsPath,
+					importAPIProductsImportAPIs, importAPIProductsUpdateAPIs, importAPIProductsUpdate,
+					importAPIProductsPreserveProvider, importAPIProductsSkipCleanup, importAPIProductsRotateRevision,
+					importAPIProductsSkipDeployments)
+			})
+		executeMigrationImportCmd(cred, migrationImport, importAPIProductsSource, importAPIProductsEnvironment,
+			importAPIProductsStartFromBeginning)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAPIProductsCmd)
+	ImportAPIProductsCmd.Flags().StringVarP(&importAPIProductsSource, "source", "", "",
+		"Path of the directory having the API Product archives to be imported")
+	ImportAPIProductsCmd.Flags().StringVarP(&importAPIProductsEnvironment, "environment", "e",
+		"", "Environment to which the API Products should be imported")
+	ImportAPIProductsCmd.Flags().StringVarP(&importAPIProductsParamsDir, "params", "", "", "Path of the directory "+
+		"having the params file or the directory generated using \"gen deployment-dir\" command of each API Product")
+	ImportAPIProductsCmd.Flags().BoolVar(&importAPIProductsRotateRevision, "rotate-revision", false,
+		"If the maximum revision limit is reached, undeploy and delete the earliest revision")
+	ImportAPIProductsCmd.Flags().BoolVar(&importAPIProductsPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of the API Products after importing")
+	ImportAPIProductsCmd.Flags().BoolVarP(&importAPIProductsImportAPIs, "import-apis", "", false, "Import "+
+		"dependent APIs associated with the API Products")
+	ImportAPIProductsCmd.Flags().BoolVarP(&importAPIProductsUpdate, "update-api-product", "", false, "Update the "+
+		"existing API Products or create new API Products")
+	ImportAPIProductsCmd.Flags().BoolVarP(&importAPIProductsUpdateAPIs, "update-apis", "", false, "Update existing "+
+		"dependent APIs associated with the API Products")
+	ImportAPIProductsCmd.Flags().BoolVarP(&importAPIProductsSkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAPIProductsCmd.Flags().BoolVar(&importAPIProductsSkipDeployments, "skip-deployments", false, "Update only "+
+		"the working copy and skip deployment steps in import")
+	ImportAPIProductsCmd.Flags().BoolVarP(&importAPIProductsStartFromBeginning, "force", "", false,
+		"Discard the state of the previous import of the source directory to the environment if any, "+
+			"and import the API Products from beginning")
+	// Mark required flags
+	_ = ImportAPIProductsCmd.MarkFlagRequired("environment")
+	_ = ImportAPIProductsCmd.MarkFlagRequired("source")
+}
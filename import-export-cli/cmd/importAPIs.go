@@ -0,0 +1,113 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAPIsSource             string
+	importAPIsEnvironment        string
+	importAPIsParamsDir          string
+	importAPIsPreserveProvider   bool
+	importAPIsOverwrite          bool
+	importAPIsSkipCleanup        bool
+	importAPIsRotateRevision     bool
+	importAPIsSkipDeployments    bool
+	importAPIsStartFromBeginning bool
+)
+
+const (
+	// ImportAPIs command related usage info
+	ImportAPIsCmdLiteral   = "apis"
+	importAPIsCmdShortDesc = "Import APIs for migration"
+	importAPIsCmdLongDesc  = `Import all the API archives (zip files) in a directory, such as the APIs exported using
+'export apis', into an environment. An API failed to import does not stop the import of the rest of the APIs, and a
+summary of the imported and failed APIs is shown at the end. The revisions of an API (e.g.
+PizzaShackAPI_1.0.0_Revision-1.zip) are imported in the order of the revision numbers, each as an update of the API
+creating a new revision, and the working copy of the API (e.g. PizzaShackAPI_1.0.0.zip) is imported last. Use
+--rotate-revision if the API has more revisions than the maximum number of revisions allowed in the environment.
+If the import is halted, running the same command again retries the APIs failed earlier and resumes the import after
+the API imported last. Use --force to import all the APIs from beginning.
+The params of each API can be given in the --params directory, either as a directory generated using
+'gen deployment-dir' or as a params file, named after the archive with or without the revision suffix
+(e.g. PizzaShackAPI_1.0.0_Revision-1, PizzaShackAPI_1.0.0_Revision-1.yaml, PizzaShackAPI_1.0.0 or
+PizzaShackAPI_1.0.0.yaml for PizzaShackAPI_1.0.0_Revision-1.zip).`
+)
+
+const importAPIsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/production/tenant-default/apis -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/apis -e dev --params ~/apis-params --update
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/apis -e dev --force
+NOTE: Both the flags (--source and --environment (-e)) are mandatory`
+
+// ImportAPIsCmd represents the import apis command
+var ImportAPIsCmd = &cobra.Command{
+	Use: ImportAPIsCmdLiteral + " --source <path-to-api-archives-directory> --environment " +
+		"<environment>",
+	Short:   importAPIsCmdShortDesc,
+	Long:    importAPIsCmdLongDesc,
+	Example: importAPIsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAPIsCmdLiteral + " called")
+		cred, err := GetCredentials(importAPIsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		migrationImport := impl.NewAPIsMigrationImport(getMigrationImportParamsDir(importAPIsParamsDir),
+			func(accessToken, archivePath, paramsPath string, update bool) error {
+				return impl.ImportAPIToEnv(os.Stdout, accessToken, importAPIsEnvironment, archivePath, paramsPath,
+					importAPIsOverwrite || update, importAPIsPreserveProvider,
+					importAPIsSkipCleanup, importAPIsRotateRevision, importAPIsSkipDeployments)
+			})
+		executeMigrationImportCmd(cred, migrationImport, importAPIsSource, importAPIsEnvironment,
+			importAPIsStartFromBeginning)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAPIsCmd)
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsSource, "source", "", "",
+		"Path of the directory having the API archives to be imported")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsEnvironment, "environment", "e",
+		"", "Environment to which the APIs should be imported")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsParamsDir, "params", "", "", "Path of the directory having "+
+		"the params file or the directory generated using \"gen deployment-dir\" command of each API")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of the APIs after importing")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsOverwrite, "update", false, "Update the "+
+		"existing APIs or create new APIs")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsRotateRevision, "rotate-revision", false, "Rotate the "+
+		"revisions with each update")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsSkipDeployments, "skip-deployments", false, "Update only "+
+		"the working copy and skip deployment steps in import")
+	ImportAPIsCmd.Flags().BoolVarP(&importAPIsSkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAPIsCmd.Flags().BoolVarP(&importAPIsStartFromBeginning, "force", "", false,
+		"Discard the state of the previous import of the source directory to the environment if any, "+
+			"and import the APIs from beginning")
+	// Mark required flags
+	_ = ImportAPIsCmd.MarkFlagRequired("environment")
+	_ = ImportAPIsCmd.MarkFlagRequired("source")
+}
@@ -19,6 +19,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -67,7 +69,7 @@ func executeImportAppCmd(credential credentials.Credential) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
-	_, err = impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
+	_, err = impl.ImportApplicationToEnv(os.Stdout, accessToken, importAppEnvironment, importAppFile, importAppOwner,
 		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup)
 	if err != nil {
 		utils.HandleErrorAndExit("Error importing Application", err)
@@ -0,0 +1,103 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAppsSource             string
+	importAppsEnvironment        string
+	importAppsOwner              string
+	importAppsPreserveOwner      bool
+	importAppsSkipSubscriptions  bool
+	importAppsSkipKeys           bool
+	importAppsUpdate             bool
+	importAppsSkipCleanup        bool
+	importAppsStartFromBeginning bool
+)
+
+const (
+	// ImportApps command related usage info
+	ImportAppsCmdLiteral   = "apps"
+	importAppsCmdShortDesc = "Import Applications for migration"
+	importAppsCmdLongDesc  = `Import all the Application archives (zip files) in a directory, such as the Applications
+exported using 'export apps', into an environment. An Application failed to import does not stop the import of the
+rest of the Applications, and a summary of the imported and failed Applications is shown at the end.
+If the import is halted, running the same command again retries the Applications failed earlier and resumes the
+import after the Application imported last. Use --force to import all the Applications from beginning.`
+)
+
+const importAppsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/production/tenant-default/apps -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source ~/apps -e dev --skip-subscriptions --update
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source ~/apps -e dev --force
+NOTE: Both the flags (--source and --environment (-e)) are mandatory`
+
+// ImportAppsCmd represents the import apps command
+var ImportAppsCmd = &cobra.Command{
+	Use: ImportAppsCmdLiteral + " --source <path-to-app-archives-directory> --environment " +
+		"<environment>",
+	Short:   importAppsCmdShortDesc,
+	Long:    importAppsCmdLongDesc,
+	Example: importAppsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAppsCmdLiteral + " called")
+		cred, err := GetCredentials(importAppsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		migrationImport := impl.NewAppsMigrationImport(func(accessToken, archivePath, paramsPath string, _ bool) error {
+			_, err := impl.ImportApplicationToEnv(os.Stdout, accessToken, importAppsEnvironment, archivePath, importAppsOwner,
+				importAppsUpdate, importAppsPreserveOwner, importAppsSkipSubscriptions, importAppsSkipKeys,
+				importAppsSkipCleanup)
+			return err
+		})
+		executeMigrationImportCmd(cred, migrationImport, importAppsSource, importAppsEnvironment,
+			importAppsStartFromBeginning)
+	},
+}
+
+func init() {
+	ImportCmd.AddCommand(ImportAppsCmd)
+	ImportAppsCmd.Flags().StringVarP(&importAppsSource, "source", "", "",
+		"Path of the directory having the Application archives to be imported")
+	ImportAppsCmd.Flags().StringVarP(&importAppsOwner, "owner", "o", "",
+		"Name of the target owner of the Applications as desired by the Importer")
+	ImportAppsCmd.Flags().StringVarP(&importAppsEnvironment, "environment", "e",
+		"", "Environment to which the Applications should be imported")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsPreserveOwner, "preserve-owner", "", true,
+		"Preserves the owners of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsSkipSubscriptions, "skip-subscriptions", "s", false,
+		"Skip subscriptions of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsSkipKeys, "skip-keys", "", false,
+		"Skip importing keys of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsUpdate, "update", "", false,
+		"Update the Applications if those are already imported")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsSkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsStartFromBeginning, "force", "", false,
+		"Discard the state of the previous import of the source directory to the environment if any, "+
+			"and import the Applications from beginning")
+	_ = ImportAppsCmd.MarkFlagRequired("source")
+	_ = ImportAppsCmd.MarkFlagRequired("environment")
+}
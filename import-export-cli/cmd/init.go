@@ -33,6 +33,11 @@ import (
 var (
 	initCmdOutputDir         string
 	initCmdSwaggerPath       string
+	initCmdGraphQLSchemaPath string
+	initCmdAsyncAPIPath      string
+	initCmdWSDLPath          string
+	initCmdPostmanPath       string
+	initCmdHARPath           string
 	initCmdApiDefinitionPath string
 	initCmdInitialState      string
 	initCmdForced            bool
@@ -41,12 +46,22 @@ var (
 const initCmdExample = `apictl init myapi --oas petstore.yaml
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json --initial-state=PUBLISHED
-apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml`
+apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml
+apictl init Petstore --oas https://petstore3.swagger.io/api/v3/openapi.json
+apictl init StarWarsAPI --graphql schema.graphql
+apictl init StreetlightsAPI --asyncapi asyncapi.yaml
+apictl init PhoneVerify --wsdl http://ws.cdyne.com/phoneverify/phoneverify.asmx?wsdl
+apictl init OrdersAPI --from-postman orders.postman_collection.json
+apictl init OrdersAPI --from-har orders.har`
+
+const initCmdLongDesc = `Initialize a new project in given path. If a OpenAPI specification, a GraphQL schema, an AsyncAPI
+definition or a WSDL is provided API will be populated with details from it. If a Postman collection or a HAR file
+is provided an OpenAPI specification will be inferred from the recorded requests and used to populate the API`
 
 var InitCommand = &cobra.Command{
 	Use:     "init [project path]",
 	Short:   "Initialize a new project in given path",
-	Long:    "Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it",
+	Long:    initCmdLongDesc,
 	Example: initCmdExample,
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -84,7 +99,9 @@ var InitCommand = &cobra.Command{
 			}
 		}
 
-		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath, false)
+		definitionType, definitionPath := getInitDefinition()
+		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, definitionType, definitionPath,
+			initCmdApiDefinitionPath, false)
 		if err != nil {
 			utils.HandleErrorAndContinue("Error initializing project", err)
 			// Remove the already created project with its content since it is partially created and wrong
@@ -101,12 +118,46 @@ var InitCommand = &cobra.Command{
 	},
 }
 
+// Returns the type and the path of the definition given to initialize the project, if any. Only one of the
+// definitions can be given.
+func getInitDefinition() (string, string) {
+	definitionType, definitionPath := impl.DefinitionTypeOAS, ""
+	for _, definition := range []struct{ definitionType, path string }{
+		{impl.DefinitionTypeOAS, initCmdSwaggerPath},
+		{impl.DefinitionTypeGraphQL, initCmdGraphQLSchemaPath},
+		{impl.DefinitionTypeAsyncAPI, initCmdAsyncAPIPath},
+		{impl.DefinitionTypeWSDL, initCmdWSDLPath},
+		{impl.DefinitionTypePostman, initCmdPostmanPath},
+		{impl.DefinitionTypeHAR, initCmdHARPath},
+	} {
+		if definition.path == "" {
+			continue
+		}
+		if definitionPath != "" {
+			utils.HandleErrorAndExit("Only one of --oas, --graphql, --asyncapi, --wsdl, "+
+				"--from-postman and --from-har can be given", nil)
+		}
+		definitionType, definitionPath = definition.definitionType, definition.path
+	}
+	return definitionType, definitionPath
+}
+
 func init() {
 	RootCmd.AddCommand(InitCommand)
 	InitCommand.Flags().StringVarP(&initCmdApiDefinitionPath, "definition", "d", "", "Provide a "+
 		"YAML definition of API")
 	InitCommand.Flags().StringVarP(&initCmdSwaggerPath, "oas", "", "", "Provide an OpenAPI "+
-		"specification file for the API")
+		"specification file (Swagger 2.0 or OpenAPI 3.x) for the API")
+	InitCommand.Flags().StringVarP(&initCmdGraphQLSchemaPath, "graphql", "", "", "Provide a GraphQL schema "+
+		"file for a GraphQL API")
+	InitCommand.Flags().StringVarP(&initCmdAsyncAPIPath, "asyncapi", "", "", "Provide an AsyncAPI "+
+		"definition file for a streaming (WebSocket, WebSub or SSE) API")
+	InitCommand.Flags().StringVarP(&initCmdWSDLPath, "wsdl", "", "", "Provide a WSDL file or URL for a "+
+		"SOAP API")
+	InitCommand.Flags().StringVarP(&initCmdPostmanPath, "from-postman", "", "", "Provide a Postman "+
+		"collection (v2.0 or v2.1) to infer the OpenAPI specification of the API from")
+	InitCommand.Flags().StringVarP(&initCmdHARPath, "from-har", "", "", "Provide a HAR (HTTP Archive) "+
+		"file to infer the OpenAPI specification of the API from")
 	InitCommand.Flags().StringVar(&initCmdInitialState, "initial-state", "", fmt.Sprintf("Provide the initial state "+
 		"of the API; Valid states: %v", utils.ValidInitialStates))
 	InitCommand.Flags().BoolVarP(&initCmdForced, "force", "f", false, "Force create project")
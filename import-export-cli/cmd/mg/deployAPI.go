@@ -19,6 +19,8 @@
 package mg
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/impl/mg"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -53,8 +55,11 @@ var DeployAPICmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		tempMap := make(map[string]string)
 
-		impl.DeployAPI(deployAPIEnv, deployAPIDir, tempMap,
+		err := impl.DeployAPI(os.Stdout, deployAPIEnv, deployAPIDir, tempMap,
 			deployAPISkipCleanup, deployAPIOverride)
+		if err != nil {
+			utils.HandleErrorAndExit("Error deploying API to microgateway", err)
+		}
 	},
 }
 
@@ -0,0 +1,246 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var migrateTenantFrom string
+var migrateTenantTo string
+var migrateTenantDomain string
+var migrateTenantFormat string
+var migrateTenantWithKeys bool
+var migrateTenantRotateRevision bool
+var migrateTenantStartFromBeginning bool
+
+// migrate tenant command related usage Info
+const migrateTenantCmdLiteral = "tenant"
+const migrateTenantCmdShortDesc = "Migrate all the artifacts of a tenant to another environment"
+const migrateTenantCmdLongDesc = `Migrate all the APIs (with all the revisions), API Products and Applications of a
+tenant from the environment specified by --from to the environment specified by --to.
+The artifacts are exported and imported in the below steps, using the same directories and resume files as the
+'export apis', 'export api-products', 'export apps', 'import apis', 'import api-products' and 'import apps' commands.
+  1. export-apis, export-api-products and export-apps
+  2. import-apis: imports the revisions of each API in the order of the revision numbers, creating a revision from
+     each, followed by the working copy of the API
+  3. deploy-revisions: deploys the revisions created in import-apis to the gateway environments those were deployed
+     in the source environment
+  4. import-api-products
+  5. import-apps: imports the Applications with their subscriptions
+Use --rotate-revision to delete the earliest undeployed revision of an API when the API has reached the maximum number
+of revisions allowed in the target environment.
+The progress is kept in <export_directory>/migration-tenant/<from>/<tenant>, so that an interrupted migration is
+resumed from the step it was halted at by running the same command again. Use --force to migrate from beginning.
+An artifact failed to import does not stop the migration. The number of artifacts failed in each step is shown at the
+end, and those can be imported using the import commands once fixed. The revisions failed to deploy are deployed
+again when the command is run again.`
+
+const migrateTenantCmdExamples = utils.ProjectName + ` ` + migrateCmdLiteral + ` ` + migrateTenantCmdLiteral + ` --from production --to production-new --tenant wso2.com
+` + utils.ProjectName + ` ` + migrateCmdLiteral + ` ` + migrateTenantCmdLiteral + ` --from production --to production-new --with-keys
+` + utils.ProjectName + ` ` + migrateCmdLiteral + ` ` + migrateTenantCmdLiteral + ` --from production --to production-new --tenant wso2.com --force
+NOTE: Both the flags (--from and --to) are mandatory. The tenant of the logged in user is migrated if --tenant is not given.`
+
+// migrateTenantCmd represents the migrate tenant command
+var migrateTenantCmd = &cobra.Command{
+	Use:     migrateTenantCmdLiteral + " --from <source-environment> --to <target-environment> [--tenant <tenant-domain>]",
+	Short:   migrateTenantCmdShortDesc,
+	Long:    migrateTenantCmdLongDesc,
+	Example: migrateTenantCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + migrateCmdLiteral + " " + migrateTenantCmdLiteral + " called")
+		if migrateTenantFrom == migrateTenantTo {
+			utils.HandleErrorAndExit("The source and the target environments should be different", nil)
+		}
+		fromCredential, err := GetCredentials(migrateTenantFrom)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials of "+migrateTenantFrom, err)
+		}
+		toCredential, err := GetCredentials(migrateTenantTo)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials of "+migrateTenantTo, err)
+		}
+		executeMigrateTenantCmd(fromCredential, toCredential)
+	},
+}
+
+// Run the steps of the migration which are not completed yet, keeping the progress in
+// <export_directory>/migration-tenant/<from>/<tenant>
+func executeMigrateTenantCmd(fromCredential, toCredential credentials.Credential) {
+	tenantDirName := utils.GetMigrationExportTenantDirName(migrateTenantDomain)
+	migrationRelatedFilesPath := filepath.Join(utils.ExportDirectory, utils.MigratedTenantsDirName, migrateTenantFrom,
+		tenantDirName)
+	if err := utils.CreateDirIfNotExist(migrationRelatedFilesPath); err != nil {
+		utils.HandleErrorAndExit("Error in creating directory structure for the tenant migration .", err)
+	}
+	migrationMetadata := impl.PrepareTenantMigration(migrationRelatedFilesPath, migrateTenantFrom, migrateTenantTo,
+		migrateTenantDomain, fromCredential.Username, migrateTenantStartFromBeginning)
+
+	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
+	//e.g. /home/samithac/.wso2apictl/exported/migration/production/wso2-dot-com
+	exportRelatedFilesPath := filepath.Join(exportDirectory, migrateTenantFrom, tenantDirName)
+	for _, step := range impl.TenantMigrationSteps {
+		if impl.IsTenantMigrationStepCompleted(migrationMetadata, step) {
+			fmt.Println("\nSkipping the completed step " + step)
+			continue
+		}
+		fmt.Println("\nRunning the step " + step + " of the migration...")
+		startFromBeginning := impl.StartTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, step)
+		failedCount := runTenantMigrationStep(step, fromCredential, toCredential, migrationRelatedFilesPath,
+			migrationMetadata, exportDirectory, exportRelatedFilesPath, startFromBeginning)
+		impl.CompleteTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, step, failedCount)
+	}
+
+	if len(migrationMetadata.FailedImports) > 0 {
+		var failedSteps []string
+		for step := range migrationMetadata.FailedImports {
+			failedSteps = append(failedSteps, step)
+		}
+		sort.Strings(failedSteps)
+		for _, step := range failedSteps {
+			fmt.Println(step + ": " + strconv.Itoa(migrationMetadata.FailedImports[step]) + " failed")
+		}
+		if _, failed := migrationMetadata.FailedImports[impl.TenantMigrationStepDeployRevisions]; failed {
+			fmt.Println("Run the command again to deploy the revisions failed to deploy")
+		}
+		utils.HandleErrorAndExit("The migration completed with failures. Fix those and import using the import "+
+			"commands with the --source directories in "+exportRelatedFilesPath, nil)
+	}
+	fmt.Println("\nCommand: migrate tenant execution completed !")
+}
+
+// Run a step of the migration
+// Returns int, the number of artifacts failed to import or deploy in the step
+func runTenantMigrationStep(step string, fromCredential, toCredential credentials.Credential,
+	migrationRelatedFilesPath string, migrationMetadata *utils.MigrationTenantMetadata, exportDirectory,
+	exportRelatedFilesPath string, startFromBeginning bool) int {
+	switch step {
+	case impl.TenantMigrationStepExportAPIs:
+		apiExportDir := impl.CreateExportAPIsDirStructure(exportDirectory, migrateTenantDomain, migrateTenantFrom,
+			startFromBeginning)
+		if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName)) &&
+			!startFromBeginning {
+			impl.PrepareResumption(fromCredential, exportRelatedFilesPath, migrateTenantDomain,
+				fromCredential.Username, migrateTenantFrom)
+		} else {
+			impl.PrepareStartFromBeginning(fromCredential, exportRelatedFilesPath, migrateTenantDomain,
+				fromCredential.Username, migrateTenantFrom)
+		}
+		impl.ExportAPIs(fromCredential, exportRelatedFilesPath, migrateTenantFrom, migrateTenantDomain,
+			migrateTenantFormat, fromCredential.Username, apiExportDir, true, false, true)
+	case impl.TenantMigrationStepExportAPIProducts:
+		apiProductExportDir := impl.CreateExportAPIProductsDirStructure(exportDirectory, migrateTenantDomain,
+			migrateTenantFrom, startFromBeginning)
+		if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiProductFileName)) &&
+			!startFromBeginning {
+			impl.PrepareAPIProductsExportResumption(fromCredential, exportRelatedFilesPath, migrateTenantDomain,
+				fromCredential.Username, migrateTenantFrom)
+		} else {
+			impl.PrepareAPIProductsExportStartFromBeginning(fromCredential, exportRelatedFilesPath,
+				migrateTenantDomain, fromCredential.Username, migrateTenantFrom)
+		}
+		impl.ExportAPIProducts(fromCredential, exportRelatedFilesPath, migrateTenantFrom, migrateTenantDomain,
+			migrateTenantFormat, fromCredential.Username, apiProductExportDir)
+	case impl.TenantMigrationStepExportApps:
+		appExportDir := impl.CreateExportAppsDirStructure(exportDirectory, migrateTenantDomain, migrateTenantFrom,
+			startFromBeginning)
+		if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededAppFileName)) &&
+			!startFromBeginning {
+			impl.PrepareAppsExportResumption(fromCredential, exportRelatedFilesPath, migrateTenantDomain,
+				fromCredential.Username, migrateTenantFrom)
+		} else {
+			impl.PrepareAppsExportStartFromBeginning(fromCredential, exportRelatedFilesPath, migrateTenantDomain,
+				fromCredential.Username, migrateTenantFrom)
+		}
+		impl.ExportApps(fromCredential, exportRelatedFilesPath, migrateTenantFrom, migrateTenantDomain,
+			migrateTenantFormat, fromCredential.Username, appExportDir, migrateTenantWithKeys)
+	case impl.TenantMigrationStepImportAPIs:
+		if startFromBeginning {
+			migrationMetadata.APIRevisions = nil
+		}
+		// the APIs are imported without the deployments, and the revisions are deployed in the deploy-revisions step
+		migrationImport := impl.NewAPIsMigrationImport("", func(accessToken, archivePath, paramsPath string,
+			update bool) error {
+			err := impl.ImportAPIToEnv(os.Stdout, accessToken, migrateTenantTo, archivePath, paramsPath, update, true, false,
+				migrateTenantRotateRevision, true)
+			if err != nil || !impl.IsRevisionArchive(archivePath) {
+				return err
+			}
+			revision, rotatedRevisionId, err := impl.CreateMigratedAPIRevision(accessToken, migrateTenantTo,
+				archivePath, migrateTenantRotateRevision)
+			if err != nil {
+				return err
+			}
+			impl.RecordMigratedAPIRevision(migrationRelatedFilesPath, migrationMetadata, revision, rotatedRevisionId)
+			return nil
+		})
+		return runMigrationImport(toCredential, migrationImport, filepath.Join(exportRelatedFilesPath,
+			utils.ExportedApisDirName), migrateTenantTo, startFromBeginning)
+	case impl.TenantMigrationStepDeployRevisions:
+		return impl.DeployMigratedAPIRevisions(toCredential, migrationRelatedFilesPath, migrateTenantTo,
+			migrationMetadata)
+	case impl.TenantMigrationStepImportAPIProducts:
+		// the APIs of the API Products are already imported in the import-apis step
+		migrationImport := impl.NewAPIProductsMigrationImport("", func(accessToken, archivePath,
+			paramsPath string, _ bool) error {
+			return impl.ImportAPIProductToEnv(os.Stdout, accessToken, migrateTenantTo, archivePath, paramsPath, false, false,
+				true, true, false, false, false)
+		})
+		return runMigrationImport(toCredential, migrationImport, filepath.Join(exportRelatedFilesPath,
+			utils.ExportedApiProductsDirName), migrateTenantTo, startFromBeginning)
+	case impl.TenantMigrationStepImportApps:
+		migrationImport := impl.NewAppsMigrationImport(func(accessToken, archivePath, paramsPath string,
+			_ bool) error {
+			_, err := impl.ImportApplicationToEnv(os.Stdout, accessToken, migrateTenantTo, archivePath, "", true, true, false,
+				!migrateTenantWithKeys, false)
+			return err
+		})
+		return runMigrationImport(toCredential, migrationImport, filepath.Join(exportRelatedFilesPath,
+			utils.ExportedAppsDirName), migrateTenantTo, startFromBeginning)
+	}
+	return 0
+}
+
+func init() {
+	MigrateCmd.AddCommand(migrateTenantCmd)
+	migrateTenantCmd.Flags().StringVarP(&migrateTenantFrom, "from", "", "",
+		"Environment from which the artifacts should be migrated")
+	migrateTenantCmd.Flags().StringVarP(&migrateTenantTo, "to", "", "",
+		"Environment to which the artifacts should be migrated")
+	migrateTenantCmd.Flags().StringVarP(&migrateTenantDomain, "tenant", "", "",
+		"Domain of the tenant to be migrated")
+	migrateTenantCmd.Flags().BoolVarP(&migrateTenantWithKeys, "with-keys", "", false,
+		"Migrate the keys of the Applications")
+	migrateTenantCmd.Flags().BoolVarP(&migrateTenantRotateRevision, "rotate-revision", "", false,
+		"Delete the earliest undeployed revision of an API when the API has reached the maximum number of revisions")
+	migrateTenantCmd.Flags().StringVarP(&migrateTenantFormat, "format", "", utils.DefaultExportFormat,
+		"File format of exported archives(json or yaml)")
+	migrateTenantCmd.Flags().BoolVarP(&migrateTenantStartFromBeginning, "force", "", false,
+		"Discard the progress of the previous migration of the tenant if any, and migrate from beginning")
+	_ = migrateTenantCmd.MarkFlagRequired("from")
+	_ = migrateTenantCmd.MarkFlagRequired("to")
+}
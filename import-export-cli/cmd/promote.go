@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var promoteFromEnvironment string
+var promoteToEnvironment string
+
+// promote command related usage Info
+const promoteCmdLiteral = "promote"
+const promoteCmdShortDesc = "Promote an API/API Product/Application from an environment to another"
+const promoteCmdLongDesc = `Promote an API, an API Product or an Application from the environment specified by --from to
+the environment specified by --to, without writing the exported archive to the export directory`
+const promoteCmdExamples = utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from dev --to staging
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPIProductCmdLiteral + ` -n LeasingAPIProduct --from dev --to staging
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAppCmdLiteral + ` -n SampleApp -o admin --from dev --to staging`
+
+// PromoteCmd represents the promote command
+var PromoteCmd = &cobra.Command{
+	Use:     promoteCmdLiteral,
+	Short:   promoteCmdShortDesc,
+	Long:    promoteCmdLongDesc,
+	Example: promoteCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + promoteCmdLiteral + " called")
+	},
+}
+
+// Returns the access tokens of the environments to promote from and to
+func getPromoteAccessTokens() (string, string) {
+	if promoteFromEnvironment == promoteToEnvironment {
+		utils.HandleErrorAndExit("The source and the target environments should be different", nil)
+	}
+	var accessTokens []string
+	for _, environment := range []string{promoteFromEnvironment, promoteToEnvironment} {
+		cred, err := GetCredentials(environment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials of "+environment, err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth Tokens of "+environment, err)
+		}
+		accessTokens = append(accessTokens, accessToken)
+	}
+	return accessTokens[0], accessTokens[1]
+}
+
+// Adds the flags of the environments to promote from and to
+func addPromoteEnvironmentFlags(cmd *cobra.Command, artifactType string) {
+	cmd.Flags().StringVarP(&promoteFromEnvironment, "from", "", "",
+		"Environment from which the "+artifactType+" should be promoted")
+	cmd.Flags().StringVarP(&promoteToEnvironment, "to", "", "",
+		"Environment to which the "+artifactType+" should be promoted")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+}
+
+func init() {
+	RootCmd.AddCommand(PromoteCmd)
+}
@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var promoteAPIName string
+var promoteAPIVersion string
+var promoteAPIProvider string
+var promoteAPIRevisionNum string
+var promoteAPIParamsFile string
+var promoteAPIUpdate bool
+var promoteAPIPreserveProvider bool
+var promoteAPIRotateRevision bool
+var promoteAPISkipDeployments bool
+var promoteAPISkipCleanup bool
+
+// promote api command related usage Info
+const promoteAPICmdLiteral = "api"
+const promoteAPICmdShortDesc = "Promote an API from an environment to another"
+const promoteAPICmdLongDesc = `Export an API from the environment specified by --from and import it to the environment
+specified by --to, applying the params given by --params for the target environment. The working copy of the API is
+promoted unless a revision is given by --rev. The exported archive is kept only in a temporary workspace.`
+
+const promoteAPICmdExamples = utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from dev --to staging
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin --rev 2 --from dev --to staging --params staging/api_params.yaml --update
+NOTE: All the 4 flags (--name (-n), --version (-v), --from and --to) are mandatory.`
+
+// promoteAPICmd represents the promote api command
+var promoteAPICmd = &cobra.Command{
+	Use: promoteAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --from " +
+		"<source-environment> --to <target-environment>)",
+	Short:   promoteAPICmdShortDesc,
+	Long:    promoteAPICmdLongDesc,
+	Example: promoteAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + promoteCmdLiteral + " " + promoteAPICmdLiteral + " called")
+		fromAccessToken, toAccessToken := getPromoteAccessTokens()
+		err := impl.PromoteAPI(fromAccessToken, toAccessToken, promoteAPIName, promoteAPIVersion, promoteAPIProvider,
+			promoteAPIRevisionNum, promoteFromEnvironment, promoteToEnvironment, promoteAPIParamsFile,
+			promoteAPIUpdate, promoteAPIPreserveProvider, promoteAPISkipCleanup, promoteAPIRotateRevision,
+			promoteAPISkipDeployments)
+		if err != nil {
+			utils.HandleErrorAndExit("Error promoting API", err)
+		}
+		fmt.Println("Successfully promoted API " + promoteAPIName + " " + promoteAPIVersion + " from " +
+			promoteFromEnvironment + " to " + promoteToEnvironment)
+	},
+}
+
+func init() {
+	PromoteCmd.AddCommand(promoteAPICmd)
+	promoteAPICmd.Flags().StringVarP(&promoteAPIName, "name", "n", "",
+		"Name of the API to be promoted")
+	promoteAPICmd.Flags().StringVarP(&promoteAPIVersion, "version", "v", "",
+		"Version of the API to be promoted")
+	promoteAPICmd.Flags().StringVarP(&promoteAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	promoteAPICmd.Flags().StringVarP(&promoteAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to be promoted")
+	addPromoteEnvironmentFlags(promoteAPICmd, "API")
+	promoteAPICmd.Flags().StringVarP(&promoteAPIParamsFile, "params", "", "",
+		"Provide an API Manager params file or a directory generated using \"gen deployment-dir\" command")
+	promoteAPICmd.Flags().BoolVar(&promoteAPIUpdate, "update", false,
+		"Update an existing API or create a new API")
+	promoteAPICmd.Flags().BoolVar(&promoteAPIPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of API after importing")
+	promoteAPICmd.Flags().BoolVar(&promoteAPIRotateRevision, "rotate-revision", false,
+		"If the maximum revision limit is reached, undeploy and delete the earliest revision")
+	promoteAPICmd.Flags().BoolVar(&promoteAPISkipDeployments, "skip-deployments", false,
+		"Update only the working copy and skip deployment steps in import")
+	promoteAPICmd.Flags().BoolVarP(&promoteAPISkipCleanup, "skip-cleanup", "", false,
+		"Leave all temporary files created during the promotion")
+	_ = promoteAPICmd.MarkFlagRequired("name")
+	_ = promoteAPICmd.MarkFlagRequired("version")
+}
@@ -0,0 +1,102 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var promoteAPIProductName string
+var promoteAPIProductProvider string
+var promoteAPIProductRevisionNum string
+var promoteAPIProductParamsFile string
+var promoteAPIProductImportAPIs bool
+var promoteAPIProductUpdateAPIs bool
+var promoteAPIProductUpdate bool
+var promoteAPIProductPreserveProvider bool
+var promoteAPIProductRotateRevision bool
+var promoteAPIProductSkipDeployments bool
+var promoteAPIProductSkipCleanup bool
+
+// promote api-product command related usage Info
+const promoteAPIProductCmdLiteral = "api-product"
+const promoteAPIProductCmdShortDesc = "Promote an API Product from an environment to another"
+const promoteAPIProductCmdLongDesc = `Export an API Product from the environment specified by --from and import it to the
+environment specified by --to, applying the params given by --params for the target environment. The dependent APIs of
+the API Product are imported along with it, unless --import-apis=false is given. The working copy of the API Product is
+promoted unless a revision is given by --rev. The exported archive is kept only in a temporary workspace.`
+
+const promoteAPIProductCmdExamples = utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPIProductCmdLiteral + ` -n LeasingAPIProduct --from dev --to staging
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPIProductCmdLiteral + ` -n LeasingAPIProduct -r admin --from dev --to staging --update-api-product --update-apis
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAPIProductCmdLiteral + ` -n LeasingAPIProduct --from dev --to staging --import-apis=false --params staging/api_product_params.yaml
+NOTE: All the 3 flags (--name (-n), --from and --to) are mandatory.`
+
+// promoteAPIProductCmd represents the promote api-product command
+var promoteAPIProductCmd = &cobra.Command{
+	Use: promoteAPIProductCmdLiteral + " (--name <name-of-the-api-product> --from <source-environment> --to " +
+		"<target-environment>)",
+	Short:   promoteAPIProductCmdShortDesc,
+	Long:    promoteAPIProductCmdLongDesc,
+	Example: promoteAPIProductCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + promoteCmdLiteral + " " + promoteAPIProductCmdLiteral + " called")
+		fromAccessToken, toAccessToken := getPromoteAccessTokens()
+		err := impl.PromoteAPIProduct(fromAccessToken, toAccessToken, promoteAPIProductName,
+			promoteAPIProductProvider, promoteAPIProductRevisionNum, promoteFromEnvironment, promoteToEnvironment,
+			promoteAPIProductParamsFile, promoteAPIProductImportAPIs, promoteAPIProductUpdateAPIs,
+			promoteAPIProductUpdate, promoteAPIProductPreserveProvider, promoteAPIProductSkipCleanup,
+			promoteAPIProductRotateRevision, promoteAPIProductSkipDeployments)
+		if err != nil {
+			utils.HandleErrorAndExit("Error promoting API Product", err)
+		}
+		fmt.Println("Successfully promoted API Product " + promoteAPIProductName + " from " +
+			promoteFromEnvironment + " to " + promoteToEnvironment)
+	},
+}
+
+func init() {
+	PromoteCmd.AddCommand(promoteAPIProductCmd)
+	promoteAPIProductCmd.Flags().StringVarP(&promoteAPIProductName, "name", "n", "",
+		"Name of the API Product to be promoted")
+	promoteAPIProductCmd.Flags().StringVarP(&promoteAPIProductProvider, "provider", "r", "",
+		"Provider of the API Product")
+	promoteAPIProductCmd.Flags().StringVarP(&promoteAPIProductRevisionNum, "rev", "", "",
+		"Revision number of the API Product to be promoted")
+	addPromoteEnvironmentFlags(promoteAPIProductCmd, "API Product")
+	promoteAPIProductCmd.Flags().StringVarP(&promoteAPIProductParamsFile, "params", "", "",
+		"Provide an API Manager params file or a directory generated using \"gen deployment-dir\" command")
+	promoteAPIProductCmd.Flags().BoolVarP(&promoteAPIProductImportAPIs, "import-apis", "", true,
+		"Import the dependent APIs associated with the API Product")
+	promoteAPIProductCmd.Flags().BoolVarP(&promoteAPIProductUpdateAPIs, "update-apis", "", false,
+		"Update the existing dependent APIs associated with the API Product")
+	promoteAPIProductCmd.Flags().BoolVarP(&promoteAPIProductUpdate, "update-api-product", "", false,
+		"Update an existing API Product or create a new API Product")
+	promoteAPIProductCmd.Flags().BoolVar(&promoteAPIProductPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of API Product after importing")
+	promoteAPIProductCmd.Flags().BoolVar(&promoteAPIProductRotateRevision, "rotate-revision", false,
+		"If the maximum revision limit is reached, undeploy and delete the earliest revision")
+	promoteAPIProductCmd.Flags().BoolVar(&promoteAPIProductSkipDeployments, "skip-deployments", false,
+		"Update only the working copy and skip deployment steps in import")
+	promoteAPIProductCmd.Flags().BoolVarP(&promoteAPIProductSkipCleanup, "skip-cleanup", "", false,
+		"Leave all temporary files created during the promotion")
+	_ = promoteAPIProductCmd.MarkFlagRequired("name")
+}
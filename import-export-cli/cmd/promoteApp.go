@@ -0,0 +1,90 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var promoteAppName string
+var promoteAppOwner string
+var promoteAppTargetOwner string
+var promoteAppWithKeys bool
+var promoteAppUpdate bool
+var promoteAppPreserveOwner bool
+var promoteAppSkipSubscriptions bool
+var promoteAppSkipCleanup bool
+
+// promote app command related usage Info
+const promoteAppCmdLiteral = "app"
+const promoteAppCmdShortDesc = "Promote an Application from an environment to another"
+const promoteAppCmdLongDesc = `Export an Application from the environment specified by --from and import it to the
+environment specified by --to, along with its subscriptions unless --skip-subscriptions is given. The exported archive
+is kept only in a temporary workspace.`
+
+const promoteAppCmdExamples = utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAppCmdLiteral + ` -n SampleApp -o admin --from dev --to staging
+` + utils.ProjectName + ` ` + promoteCmdLiteral + ` ` + promoteAppCmdLiteral + ` -n SampleApp -o admin --from dev --to staging --with-keys --preserve-owner --update
+NOTE: All the 4 flags (--name (-n), --owner (-o), --from and --to) are mandatory.`
+
+// promoteAppCmd represents the promote app command
+var promoteAppCmd = &cobra.Command{
+	Use: promoteAppCmdLiteral + " (--name <name-of-the-application> --owner <owner-of-the-application> --from " +
+		"<source-environment> --to <target-environment>)",
+	Short:   promoteAppCmdShortDesc,
+	Long:    promoteAppCmdLongDesc,
+	Example: promoteAppCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + promoteCmdLiteral + " " + promoteAppCmdLiteral + " called")
+		fromAccessToken, toAccessToken := getPromoteAccessTokens()
+		err := impl.PromoteApp(fromAccessToken, toAccessToken, promoteAppName, promoteAppOwner, promoteAppTargetOwner,
+			promoteFromEnvironment, promoteToEnvironment, promoteAppWithKeys, promoteAppUpdate, promoteAppPreserveOwner,
+			promoteAppSkipSubscriptions, promoteAppSkipCleanup)
+		if err != nil {
+			utils.HandleErrorAndExit("Error promoting Application", err)
+		}
+		fmt.Println("Successfully promoted Application " + promoteAppName + " from " + promoteFromEnvironment +
+			" to " + promoteToEnvironment)
+	},
+}
+
+func init() {
+	PromoteCmd.AddCommand(promoteAppCmd)
+	promoteAppCmd.Flags().StringVarP(&promoteAppName, "name", "n", "",
+		"Name of the Application to be promoted")
+	promoteAppCmd.Flags().StringVarP(&promoteAppOwner, "owner", "o", "",
+		"Owner of the Application to be promoted")
+	promoteAppCmd.Flags().StringVarP(&promoteAppTargetOwner, "target-owner", "", "",
+		"Name of the target owner of the Application as desired by the Importer")
+	addPromoteEnvironmentFlags(promoteAppCmd, "Application")
+	promoteAppCmd.Flags().BoolVarP(&promoteAppWithKeys, "with-keys", "", false,
+		"Promote the keys of the Application")
+	promoteAppCmd.Flags().BoolVarP(&promoteAppUpdate, "update", "", false,
+		"Update the Application if it is already imported")
+	promoteAppCmd.Flags().BoolVarP(&promoteAppPreserveOwner, "preserve-owner", "", false,
+		"Preserves app owner")
+	promoteAppCmd.Flags().BoolVarP(&promoteAppSkipSubscriptions, "skip-subscriptions", "s", false,
+		"Skip subscriptions of the Application")
+	promoteAppCmd.Flags().BoolVarP(&promoteAppSkipCleanup, "skip-cleanup", "", false,
+		"Leave all temporary files created during the promotion")
+	_ = promoteAppCmd.MarkFlagRequired("name")
+	_ = promoteAppCmd.MarkFlagRequired("owner")
+}
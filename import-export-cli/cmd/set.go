@@ -21,6 +21,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -36,10 +37,16 @@ var flagVCSDeletionEnabled bool
 var flagVCSConfigPath string
 var flagVCSSourceRepoPath string
 var flagVCSDeploymentRepoPath string
+var flagVCSStateBackend string
+var flagVCSStateRemote string
+var flagVCSLockExpiry int
 
 const flagVCSConfigPathName = "vcs-config-path"
 const flagVCSSourceRepoPathName = "vcs-source-repo-path"
 const flagVCSDeploymentRepoPathName = "vcs-deployment-repo-path"
+const flagVCSStateBackendName = "vcs-state-backend"
+const flagVCSStateRemoteName = "vcs-state-remote"
+const flagVCSLockExpiryName = "vcs-lock-expiry"
 
 // Set command related Info
 const setCmdLiteral = "set"
@@ -52,7 +59,10 @@ const setCmdLongDesc = `Set configuration parameters. You can use one of the fol
 * --vcs-deletion-enabled <enable-or-disable-project-deletion-via-vcs>
 * --vcs-config-path <path-to-custom-vcs-config-file>
 * --vcs-deployment-repo-path <path-to-deployment-repo-for-vcs>
-* --vcs-source-repo-path <path-to-source-repo-for-vcs>`
+* --vcs-source-repo-path <path-to-source-repo-for-vcs>
+* --vcs-state-backend <file|git>
+* --vcs-state-remote <git-remote-to-share-the-vcs-deployment-state>
+* --vcs-lock-expiry <time-in-minutes-after-which-a-vcs-deployment-lock-expires>`
 
 const setCmdExamples = utils.ProjectName + ` ` + setCmdLiteral + ` --http-request-timeout 3600 --export-directory /home/user/exported-apis
 ` + utils.ProjectName + ` ` + setCmdLiteral + ` --http-request-timeout 5000 --export-directory C:\Documents\exported
@@ -61,7 +71,9 @@ const setCmdExamples = utils.ProjectName + ` ` + setCmdLiteral + ` --http-reques
 ` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-deletion-enabled=true
 ` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-config-path /home/user/custom/vcs-config.yaml
 ` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-deployment-repo-path /home/user/custom/deployment
-` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-source-repo-path /home/user/custom/source`
+` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-source-repo-path /home/user/custom/source
+` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-state-backend git --vcs-state-remote origin
+` + utils.ProjectName + ` ` + setCmdLiteral + ` --vcs-lock-expiry 30`
 
 // SetCmd represents the 'set' command
 var SetCmd = &cobra.Command{
@@ -149,6 +161,27 @@ func executeSetCmd(mainConfigFilePath string, cmd *cobra.Command) {
 		configVars.Config.VCSDeploymentRepoPath = flagVCSDeploymentRepoPath
 		fmt.Println("VCS deployment repo path is set to : " + flagVCSDeploymentRepoPath)
 	}
+	if cmd.Flags().Changed(flagVCSStateBackendName) {
+		if flagVCSStateBackend != utils.VCSStateBackendFile && flagVCSStateBackend != utils.VCSStateBackendGit {
+			utils.HandleErrorAndExit("Invalid input for flag --"+flagVCSStateBackendName,
+				errors.New("VCS state backend should be set to either "+utils.VCSStateBackendFile+" or "+
+					utils.VCSStateBackendGit))
+		}
+		configVars.Config.VCSStateBackend = flagVCSStateBackend
+		fmt.Println("VCS state backend is set to : " + flagVCSStateBackend)
+	}
+	if cmd.Flags().Changed(flagVCSStateRemoteName) {
+		configVars.Config.VCSStateRemote = flagVCSStateRemote
+		fmt.Println("VCS state remote is set to : " + flagVCSStateRemote)
+	}
+	if cmd.Flags().Changed(flagVCSLockExpiryName) {
+		if flagVCSLockExpiry <= 0 {
+			utils.HandleErrorAndExit("Invalid input for flag --"+flagVCSLockExpiryName,
+				errors.New("VCS lock expiry should be a positive number of minutes"))
+		}
+		configVars.Config.VCSLockExpiry = flagVCSLockExpiry
+		fmt.Println("VCS lock expiry is set to : " + strconv.Itoa(flagVCSLockExpiry) + " minutes")
+	}
 
 	utils.WriteConfigFile(configVars, mainConfigFilePath)
 }
@@ -189,4 +222,12 @@ func init() {
 		"Path to the source repository to be considered during VCS deploy")
 	SetCmd.Flags().StringVar(&flagVCSDeploymentRepoPath, flagVCSDeploymentRepoPathName, "",
 		"Path to the deoployment repository to be considered during VCS deploy")
+	SetCmd.Flags().StringVar(&flagVCSStateBackend, flagVCSStateBackendName, utils.VCSStateBackendFile,
+		"Where the VCS deployment state is kept. \""+utils.VCSStateBackendFile+"\" keeps it in the VCS config file "+
+			"and \""+utils.VCSStateBackendGit+"\" keeps it in a dedicated git ref of each repository")
+	SetCmd.Flags().StringVar(&flagVCSStateRemote, flagVCSStateRemoteName, "",
+		"Git remote to fetch and push the VCS deployment state when the state backend is \""+
+			utils.VCSStateBackendGit+"\"")
+	SetCmd.Flags().IntVar(&flagVCSLockExpiry, flagVCSLockExpiryName, utils.DefaultVCSLockExpiry,
+		"Number of minutes after which the lock acquired by a VCS deployment on an environment expires")
 }
@@ -0,0 +1,66 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// validate command related usage Info
+const validateCmdLiteral = "validate"
+const validateCmdShortDesc = "Validate an API/API Product/Application project offline"
+const validateCmdLongDesc = `Validate an API, an API Product or an Application project (or an archive of it) for the issues
+which would make it fail to import, without connecting to an environment`
+const validateCmdExamples = utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAPICmdLiteral + ` -f PizzaShackAPI-1.0.0
+` + utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAPIProductCmdLiteral + ` -f LeasingAPIProduct.zip
+` + utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAppCmdLiteral + ` -f SampleApp`
+
+// ValidateCmd represents the validate command
+var ValidateCmd = &cobra.Command{
+	Use:     validateCmdLiteral,
+	Short:   validateCmdShortDesc,
+	Long:    validateCmdLongDesc,
+	Example: validateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + validateCmdLiteral + " called")
+	},
+}
+
+// Prints the issues found in a project, exiting with an error if there are any
+func printValidationIssues(artifactType, projectPath string, issues []string, err error) {
+	if err != nil {
+		utils.HandleErrorAndExit("Error validating the "+artifactType+" project "+projectPath, err)
+	}
+	if len(issues) == 0 {
+		fmt.Println("The " + artifactType + " project " + projectPath + " is valid")
+		return
+	}
+	fmt.Println("Issues found in the " + artifactType + " project " + projectPath + ":")
+	for _, issue := range issues {
+		fmt.Println("  - " + issue)
+	}
+	utils.HandleErrorAndExit(strconv.Itoa(len(issues))+" issue(s) found in the "+artifactType+" project", nil)
+}
+
+func init() {
+	RootCmd.AddCommand(ValidateCmd)
+}
@@ -0,0 +1,55 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var validateAPIFile string
+
+// validate api command related usage Info
+const validateAPICmdLiteral = "api"
+const validateAPICmdShortDesc = "Validate an API project offline"
+const validateAPICmdLongDesc = `Validate an API project (or an archive of it) offline. Checks whether the api.yaml, the definition
+and the api_meta.yaml exist, the api.yaml and the Swagger/OpenAPI definition parse, the operations match the resources
+of the definition, the name, the context and the version do not have invalid characters, the endpoint configuration
+is valid, and the sequences, the certificates and the documents referred are in the project.`
+const validateAPICmdExamples = utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAPICmdLiteral + ` -f PizzaShackAPI-1.0.0`
+
+// validateAPICmd represents the validate api command
+var validateAPICmd = &cobra.Command{
+	Use:     validateAPICmdLiteral + " (--file <path-to-the-project-or-the-archive>)",
+	Short:   validateAPICmdShortDesc,
+	Long:    validateAPICmdLongDesc,
+	Example: validateAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + validateCmdLiteral + " " + validateAPICmdLiteral + " called")
+		issues, err := impl.ValidateAPIProject(validateAPIFile)
+		printValidationIssues("API", validateAPIFile, issues, err)
+	},
+}
+
+func init() {
+	ValidateCmd.AddCommand(validateAPICmd)
+	validateAPICmd.Flags().StringVarP(&validateAPIFile, "file", "f", "",
+		"Path of the API project directory or the archive to be validated")
+	_ = validateAPICmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,54 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var validateAPIProductFile string
+
+// validate api-product command related usage Info
+const validateAPIProductCmdLiteral = "api-product"
+const validateAPIProductCmdShortDesc = "Validate an API Product project offline"
+const validateAPIProductCmdLongDesc = `Validate an API Product project (or an archive of it) offline. Checks whether the
+api_product.yaml, the definition and the api_product_meta.yaml exist and parse, and validates each of the dependent
+APIs in the APIs directory of the project as an API project.`
+const validateAPIProductCmdExamples = utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAPIProductCmdLiteral + ` -f LeasingAPIProduct.zip`
+
+// validateAPIProductCmd represents the validate api-product command
+var validateAPIProductCmd = &cobra.Command{
+	Use:     validateAPIProductCmdLiteral + " (--file <path-to-the-project-or-the-archive>)",
+	Short:   validateAPIProductCmdShortDesc,
+	Long:    validateAPIProductCmdLongDesc,
+	Example: validateAPIProductCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + validateCmdLiteral + " " + validateAPIProductCmdLiteral + " called")
+		issues, err := impl.ValidateAPIProductProject(validateAPIProductFile)
+		printValidationIssues("API Product", validateAPIProductFile, issues, err)
+	},
+}
+
+func init() {
+	ValidateCmd.AddCommand(validateAPIProductCmd)
+	validateAPIProductCmd.Flags().StringVarP(&validateAPIProductFile, "file", "f", "",
+		"Path of the API Product project directory or the archive to be validated")
+	_ = validateAPIProductCmd.MarkFlagRequired("file")
+}
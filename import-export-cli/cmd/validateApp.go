@@ -0,0 +1,54 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var validateAppFile string
+
+// validate app command related usage Info
+const validateAppCmdLiteral = "app"
+const validateAppCmdShortDesc = "Validate an Application project offline"
+const validateAppCmdLongDesc = `Validate an Application project (or an archive of it) offline. Checks whether the
+application.yaml and the application_meta.yaml exist, and the application.yaml parses with the name and the owner of
+the Application.`
+const validateAppCmdExamples = utils.ProjectName + ` ` + validateCmdLiteral + ` ` + validateAppCmdLiteral + ` -f SampleApp`
+
+// validateAppCmd represents the validate app command
+var validateAppCmd = &cobra.Command{
+	Use:     validateAppCmdLiteral + " (--file <path-to-the-project-or-the-archive>)",
+	Short:   validateAppCmdShortDesc,
+	Long:    validateAppCmdLongDesc,
+	Example: validateAppCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + validateCmdLiteral + " " + validateAppCmdLiteral + " called")
+		issues, err := impl.ValidateAppProject(validateAppFile)
+		printValidationIssues("Application", validateAppFile, issues, err)
+	},
+}
+
+func init() {
+	ValidateCmd.AddCommand(validateAppCmd)
+	validateAppCmd.Flags().StringVarP(&validateAppFile, "file", "f", "",
+		"Path of the Application project directory or the archive to be validated")
+	_ = validateAppCmd.MarkFlagRequired("file")
+}
@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -30,7 +31,10 @@ const vcsCmdLongDesc = `Checks status and deploys projects to the specified envi
 use this command, 'git' must be installed in the system.'`
 const vcsCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsInitCmdLiteral + `
 ` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsStatusCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev`
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsDiffCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsRollbackCmdLiteral + ` -e dev --to 1
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsUnlockCmdLiteral + ` -e dev`
 
 // vcsCmd represents the vcs command
 var VCSCmd = &cobra.Command{
@@ -43,6 +47,19 @@ var VCSCmd = &cobra.Command{
 	},
 }
 
+// Returns the scope of the repositories given using --scope and --path for the vcs commands
+// scopeName is the name of the scope given using --scope
+// paths are the subtrees of the repositories given using --path
+func getVCSScopeParams(scopeName string, paths []string) git.ScopeParams {
+	if scopeName == "" && len(paths) > 0 {
+		utils.HandleErrorAndExit("The scope should be named using --scope when limiting the projects using --path", nil)
+	}
+	if err := git.ValidateScopeName(scopeName); err != nil {
+		utils.HandleErrorAndExit("Invalid value for --scope", err)
+	}
+	return git.ScopeParams{Name: scopeName, Paths: paths}
+}
+
 func init() {
 	RootCmd.AddCommand(VCSCmd)
 }
@@ -19,8 +19,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -30,6 +33,12 @@ import (
 
 var flagVCSDeployEnvName string    // name of the environment the project changes need to be deployed
 var flagVCSDeploySkipRollback bool // specifies whether rolling back on error needs to be avoided
+var flagVCSDeployParallel int      // number of projects of the same type to be deployed at the same time
+var flagVCSDeployDryRun bool       // specifies whether only the plan of the deployment needs to be shown
+var flagVCSDeployReport string     // path of the file to write the deployment report into
+var flagVCSDeployScope string      // name of the scope which the state of the deployment is kept against
+var flagVCSDeployPaths []string    // subtrees of the repositories to limit the deployment to
+var flagVCSDeployApprove string    // token approving the changes which need an approval on a protected environment
 
 // deploy command related usage Info
 const deployCmdLiteral = "deploy"
@@ -38,10 +47,18 @@ const deployCmdLongDesc = `Deploys projects to the specified environment specifi
 Only the changed projects compared to the revision at the last successful deployment will be deployed. 
 If any project(s) got failed during the deployment, by default, the operation will rollback the environment to the last successful state. 
 If this needs to be avoided, use --skip-rollback=true
+Use --dry-run to see the changes without deploying, and --parallel to deploy the projects of the same type in parallel. 
+The environment is locked while deploying. Use 'apictl vcs unlock' to clear the lock of a terminated deployment.
 NOTE: --environment (-e) flag is mandatory`
 
 const deployCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true`
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --parallel 5
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --dry-run
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --report report.json
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --report junit.xml
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --scope payments --path teams/payments
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e prod --approve 3f9a1c0b7d2e`
 
 // deployCmd represents the deploy command
 var DeployCmd = &cobra.Command{
@@ -60,27 +77,164 @@ var DeployCmd = &cobra.Command{
 			fmt.Println("VSC source repo path cannot be empty. Set it using apictl set command.")
 			os.Exit(1)
 		}
-		credential, err := GetCredentials(flagVCSDeployEnvName)
-		if err != nil {
-			utils.HandleErrorAndExit("Error getting credentials", err)
+		if flagVCSDeployParallel < 1 {
+			utils.HandleErrorAndExit("The value of --parallel should be a positive number", nil)
 		}
-		accessOAuthToken, err := credentials.GetOAuthAccessToken(credential, flagVCSDeployEnvName)
-		if err != nil {
-			utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
+		scopeParams := getVCSScopeParams(flagVCSDeployScope, flagVCSDeployPaths)
+		if flagVCSDeployDryRun {
+			plan := git.PlanChangedFiles(flagVCSDeployEnvName, scopeParams)
+			printDeploymentPlan(plan)
+			changes, approvalToken := git.GetChangesRequiringApproval(flagVCSDeployEnvName, scopeParams)
+			if len(changes) > 0 {
+				fmt.Println()
+				printChangesRequiringApproval(flagVCSDeployEnvName, approvalToken, changes)
+			}
+			if _, failedCount := plan.Count(); failedCount > 0 {
+				utils.HandleErrorAndExit("There are project(s) that would fail to deploy.", nil)
+			}
+			return
 		}
-		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
-		if failedProjects != nil && len(failedProjects) > 0 && flagVCSDeploySkipRollback == false {
-			fmt.Println("\nRolling back to the last successful revision as there are failures..")
-			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName)
+		// the changes are approved before acquiring the lock, so that a refused deployment does not leave the
+		//  environment locked
+		changes, approvalToken := git.GetChangesRequiringApproval(flagVCSDeployEnvName, scopeParams)
+		if len(changes) > 0 {
+			approveProtectedChanges(flagVCSDeployEnvName, approvalToken, changes)
+		}
+		// an environment having only a Micro Integrator is deployed with the MI CApp projects only, which use the
+		//  credentials of 'apictl mi login'
+		var accessOAuthToken string
+		if utils.APIMExistsInEnv(flagVCSDeployEnvName, utils.MainConfigFilePath) {
+			credential, err := GetCredentials(flagVCSDeployEnvName)
+			if err != nil {
+				utils.HandleErrorAndExit("Error getting credentials", err)
+			}
+			accessOAuthToken, err = credentials.GetOAuthAccessToken(credential, flagVCSDeployEnvName)
 			if err != nil {
-				utils.HandleErrorAndExit("There are project deployment failures. Failed to rollback.", err)
-			} else {
-				utils.HandleErrorAndExit("There are project deployment failures. Rolled back to the last successful revision.", err)
+				utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
 			}
 		}
+		releaseLock := git.LockEnvironment(flagVCSDeployEnvName)
+		err := deployChangedProjects(accessOAuthToken, scopeParams)
+		// the lock is released before exiting on an error
+		releaseLock()
+		if err != nil {
+			utils.HandleErrorAndExit("Error while deploying the project(s) to "+flagVCSDeployEnvName, err)
+		}
 	},
 }
 
+// Deploys the changed projects to the environment, and rolls back the environment if any of the projects failed
+// accessOAuthToken is the access token to access the APIM product REST APIs
+// scopeParams is the part of the repositories which is deployed
+// Returns error, if the deployment could not be completed or any of the projects failed
+func deployChangedProjects(accessOAuthToken string, scopeParams git.ScopeParams) error {
+	if flagVCSDeployReport != "" {
+		git.StartDeploymentReport(flagVCSDeployEnvName)
+	}
+	failedProjects, rollbackRequested, err := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName,
+		scopeParams, flagVCSDeployParallel)
+	if err != nil {
+		return err
+	}
+	// the report is written before rolling back, so that it records the results of the deployment itself
+	if flagVCSDeployReport != "" {
+		if err := git.WriteDeploymentReport(flagVCSDeployReport); err != nil {
+			utils.HandleErrorAndContinue("Error while writing the deployment report", err)
+		}
+	}
+	// a project can require rolling back when its post-deploy hooks fail, regardless of --skip-rollback
+	if failedProjects != nil && len(failedProjects) > 0 &&
+		(flagVCSDeploySkipRollback == false || rollbackRequested) {
+		// the API Manager is not rolled back when the projects failed only on the Microgateways, as that does not
+		//  undo the deployments to the other Microgateways
+		if !git.RequiresRollback(failedProjects) && !rollbackRequested {
+			return errors.New("There are project deployment failures on the Microgateways. Skipped rolling " +
+				"back as the projects were deployed to the API Manager. Those are deployed again with the next " +
+				"deployment.")
+		}
+		fmt.Println("\nRolling back to the last successful revision as there are failures..")
+		err := git.Rollback(accessOAuthToken, flagVCSDeployEnvName, scopeParams, flagVCSDeployParallel)
+		if err != nil {
+			return errors.New("There are project deployment failures. Failed to rollback: " + err.Error())
+		}
+		return errors.New("There are project deployment failures. Rolled back to the last successful revision.")
+	}
+	return nil
+}
+
+// Exits unless the changes to a protected environment are approved using --approve or confirmed interactively
+// environment is the environment name
+// approvalToken is the token which approves the changes
+// changes is the list of changes which need an approval
+func approveProtectedChanges(environment, approvalToken string, changes []*git.ProtectedChange) {
+	if flagVCSDeployApprove != "" {
+		if flagVCSDeployApprove != approvalToken {
+			printChangesRequiringApproval(environment, approvalToken, changes)
+			utils.HandleErrorAndExit("The value of --approve does not match the changes to deploy. The changes "+
+				"might have been updated after those were approved.", nil)
+		}
+		utils.Logln(utils.LogPrefixInfo + "The changes to " + environment + " are approved using --approve")
+		return
+	}
+	printChangesRequiringApproval(environment, approvalToken, changes)
+	isConfirmStr, err := utils.ReadInputString("Deploy the above changes to "+environment,
+		utils.Default{Value: "N", IsDefault: true}, "", false)
+	// the input cannot be read when running non-interactively, which is considered as not approved
+	if err != nil || !(strings.EqualFold(isConfirmStr, "y") || strings.EqualFold(isConfirmStr, "yes")) {
+		utils.HandleErrorAndExit("The deployment to the protected environment "+environment+" is not approved. "+
+			"Use --approve "+approvalToken+" to approve the above changes.", nil)
+	}
+}
+
+// Prints the changes to a protected environment which need an approval
+func printChangesRequiringApproval(environment, approvalToken string, changes []*git.ProtectedChange) {
+	fmt.Println("The following change(s) to the protected environment '" + environment + "' need an approval (" +
+		strconv.Itoa(len(changes)) + ")")
+	for i, change := range changes {
+		fmt.Println(strconv.Itoa(i+1) + ": " + change.String())
+	}
+	fmt.Println("Approval token: " + approvalToken)
+}
+
+// Prints the actions that would be performed on each project of the deployment plan
+func printDeploymentPlan(plan *git.DeploymentPlan) {
+	totalCount, failedCount := plan.Count()
+	if totalCount == 0 {
+		fmt.Println("Everything is up-to-date")
+		return
+	}
+	fmt.Println("Deployment plan for environment '" + plan.Environment + "' (" + strconv.Itoa(totalCount) + ")")
+	printPlannedProjects(utils.ProjectTypeApi, plan.ProjectsPerType[utils.ProjectTypeApi])
+	printPlannedProjects(utils.ProjectTypeApiProduct, plan.ProjectsPerType[utils.ProjectTypeApiProduct])
+	printPlannedProjects(utils.ProjectTypeApplication, plan.ProjectsPerType[utils.ProjectTypeApplication])
+	printPlannedProjects(utils.ProjectTypeMICApp, plan.ProjectsPerType[utils.ProjectTypeMICApp])
+	if failedCount > 0 {
+		fmt.Println("\n" + strconv.Itoa(failedCount) + " project(s) would fail to deploy")
+	}
+}
+
+// Prints the actions that would be performed on the projects of a given type
+func printPlannedProjects(projectType string, plannedProjects []*git.PlannedProjectAction) {
+	if len(plannedProjects) == 0 {
+		return
+	}
+	fmt.Println("\n" + projectType + "s (" + strconv.Itoa(len(plannedProjects)) + ") ...")
+	for i, plannedProject := range plannedProjects {
+		var failed string
+		if plannedProject.FailedDuringPreviousDeploy {
+			failed = "[failed]"
+		}
+		fmt.Println(strconv.Itoa(i+1) + ": [" + plannedProject.Action + "]\t" + failed + "\t" + plannedProject.NickName +
+			": (" + plannedProject.RelativePath + ")")
+		if plannedProject.Error != "" {
+			fmt.Println("\tError... " + plannedProject.Error)
+		}
+		if len(plannedProject.HooksToRun) > 0 {
+			fmt.Println("\tWould run the hooks: " + strings.Join(plannedProject.HooksToRun, ", "))
+		}
+	}
+}
+
 func init() {
 	VCSCmd.AddCommand(DeployCmd)
 
@@ -91,6 +245,21 @@ func init() {
 	DeployCmd.Flags().BoolVarP(&flagVCSDeploySkipRollback, "skipRollback", "", false,
 		"Specifies whether rolling back to the last successful revision during an error situation should be skipped")
 	DeployCmd.Flags().MarkDeprecated("skipRollback", "Use skip-rollback flag")
+	DeployCmd.Flags().IntVarP(&flagVCSDeployParallel, "parallel", "", 1,
+		"Number of projects of the same type to deploy in parallel. APIs are still deployed before API Products, and API "+
+			"Products before Applications")
+	DeployCmd.Flags().BoolVarP(&flagVCSDeployDryRun, "dry-run", "", false,
+		"Shows the projects that would be created, updated or deleted, and the projects that would fail to deploy, "+
+			"without deploying those")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployReport, "report", "", "",
+		"Path of the file to write the deployment report into (JUnit XML if the file ends with .xml, JSON otherwise)")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployScope, "scope", "", "",
+		"Name of the scope to deploy. The deployment state of each scope is kept separately")
+	DeployCmd.Flags().StringSliceVarP(&flagVCSDeployPaths, "path", "", []string{},
+		"Subtrees of the repositories (relative to the repository root) to limit the scope to. Requires --scope")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployApprove, "approve", "", "",
+		"Token approving the changes which need an approval on a protected environment. The token is printed when "+
+			"the changes are blocked")
 
 	_ = DeployCmd.MarkFlagRequired("environment")
 }
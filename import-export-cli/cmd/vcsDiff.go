@@ -0,0 +1,144 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSDiffEnvName string // name of the environment to compare the changes with
+var flagVCSDiffFormat string  // format of the output to be printed
+
+// diff command related usage Info
+const vcsDiffCmdLiteral = "diff"
+const vcsDiffCmdShortDesc = "Shows the changes of the projects that are ready to deploy"
+const vcsDiffCmdLongDesc = `Shows the changes of the projects that are ready to deploy to the specified environment by --environment(-e).
+The definitions of each project (api.yaml, api_product.yaml or application.yaml) in the working copy, including the
+uncommitted changes which are deployed as well, are compared field by field with the
+last successfully deployed revision, and the endpoint, policy, scope, operation and lifecycle changes are shown along
+with the resources (paths and verbs) changed in Definitions/swagger.yaml
+NOTE: --environment (-e) flag is mandatory`
+
+const vcsDiffCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsDiffCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsDiffCmdLiteral + ` -e dev --format "{{ jsonPretty . }}"`
+
+// VCSDiffCmd represents the vcs diff command
+var VCSDiffCmd = &cobra.Command{
+	Use:     vcsDiffCmdLiteral,
+	Short:   vcsDiffCmdShortDesc,
+	Long:    vcsDiffCmdLongDesc,
+	Example: vcsDiffCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsDiffCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSDiffEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSDiffEnvName, "does not exists. Add it using add env")
+			os.Exit(1)
+		}
+
+		fromRev, projectDiffs := git.GetDiff(flagVCSDiffEnvName)
+		if flagVCSDiffFormat != "" {
+			printDiffResultJson(flagVCSDiffFormat, projectDiffs)
+			return
+		}
+		if len(projectDiffs) == 0 {
+			fmt.Println("Everything is up-to-date")
+			return
+		}
+		if fromRev == "" {
+			fmt.Println("No successful deployments found. All the projects are shown as new.")
+		} else {
+			fmt.Println("Changes since " + fromRev)
+		}
+		fmt.Println("Projects with changes (" + strconv.Itoa(len(projectDiffs)) + ")")
+		for _, projectDiff := range projectDiffs {
+			printProjectDiff(projectDiff)
+		}
+	},
+}
+
+// Prints the changes of a single project in a human readable form
+func printProjectDiff(projectDiff *git.ProjectDiff) {
+	fmt.Println("\n" + projectDiff.Type + ": " + projectDiff.NickName + " (" + projectDiff.RelativePath + ")")
+	if projectDiff.Deleted {
+		fmt.Println("  [delete]")
+		return
+	}
+	if projectDiff.New {
+		fmt.Println("  [new]")
+	} else if len(projectDiff.FieldChanges) == 0 && len(projectDiff.DefinitionChanges) == 0 {
+		fmt.Println("  No changes in the definitions")
+		return
+	}
+	for _, fieldChange := range projectDiff.FieldChanges {
+		switch fieldChange.Change {
+		case git.ChangeAdded:
+			fmt.Printf("  %-10s + %s: %v\n", fieldChange.Category, fieldChange.Field, fieldChange.NewValue)
+		case git.ChangeRemoved:
+			fmt.Printf("  %-10s - %s: %v\n", fieldChange.Category, fieldChange.Field, fieldChange.OldValue)
+		default:
+			fmt.Printf("  %-10s ~ %s: %v -> %v\n", fieldChange.Category, fieldChange.Field, fieldChange.OldValue,
+				fieldChange.NewValue)
+		}
+	}
+	if len(projectDiff.DefinitionChanges) > 0 {
+		fmt.Println("  Definitions/swagger.yaml:")
+		for _, definitionChange := range projectDiff.DefinitionChanges {
+			fmt.Printf("    %-8s %-7s %s\n", definitionChange.Change, definitionChange.Verb, definitionChange.Path)
+		}
+	}
+}
+
+// Prints the changes of the projects using the given format
+func printDiffResultJson(format string, projectDiffs []*git.ProjectDiff) {
+	resultContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		if err := t.Execute(w, projectDiffs); err != nil {
+			return err
+		}
+		_, _ = w.Write([]byte{'\n'})
+		return nil
+	}
+
+	// execute context
+	if err := resultContext.Write(renderer, git.ProjectDiff{}); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSDiffCmd)
+
+	VCSDiffCmd.Flags().StringVarP(&flagVCSDiffEnvName, "environment", "e", "", "Name of the "+
+		"environment to compare the project(s) with")
+	VCSDiffCmd.Flags().StringVarP(&flagVCSDiffFormat, "format", "", "",
+		"Pretty-print changes (only supported \"{{ jsonPretty . }}\" and \"{{ json . }}\")")
+
+	_ = VCSDiffCmd.MarkFlagRequired("environment")
+}
@@ -33,7 +33,23 @@ const vcsInitCmdShortDesc = "Initializes a GIT repository with API Controller"
 const vcsInitCmdLongDesc = `Initializes a GIT repository with API Controller (apictl). Before start using a GIT repository 
 for 'vcs' commands, the GIT repository should be initialized once via 'vcs init'. This will create a file 'vcs.yaml'
 in the root location of the GIT repository, which is used by API Controller  to uniquely identify the GIT repository. 
-'vcs.yaml' should be committed to the GIT repository.`
+'vcs.yaml' should be committed to the GIT repository.
+When multiple teams share the same GIT repository, the projects deployed to each environment can be limited by adding 
+include and exclude path globs of the environment into 'vcs.yaml' as below. The globs are matched against the path of 
+each project relative to the repository root and each of its parent directories.
+  environments:
+    dev:
+      include:
+      - teams/payments/*
+      exclude:
+      - teams/payments/legacy
+The API projects can also be deployed to Microgateway adapter environments (added using 'mg add env') along with the 
+environment, by adding those as the microgateways of the environment into 'vcs.yaml'. An API project can override 
+these targets using the 'deploy.microgateway' section of its 'api_meta.yaml'.
+  environments:
+    dev:
+      microgateways:
+      - mg-dev`
 
 const vcsInitCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsInitCmdLiteral
 
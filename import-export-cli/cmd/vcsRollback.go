@@ -0,0 +1,112 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSRollbackEnvName string // name of the environment to rollback
+var flagVCSRollbackTo string      // index or the commit id of the successful revision to rollback to
+var flagVCSRollbackParallel int   // number of projects of the same type to be deployed at the same time
+var flagVCSRollbackScope string   // name of the scope to rollback
+var flagVCSRollbackPaths []string // subtrees of the repository which the deployment to rollback was limited to
+
+// rollback command related usage Info
+const vcsRollbackCmdLiteral = "rollback"
+const vcsRollbackCmdShortDesc = "Rolls back the environment to a previously successful revision"
+const vcsRollbackCmdLongDesc = `Rolls back the environment specified by --environment(-e) to a previously successful revision 
+of the source repository specified by --to. The value of --to can be the index of a successful revision retained in the 
+VCS deployment state (0 is the latest successful revision, 1 is the one before it, and so on) or the commit id of it.
+The projects changed since the revision are deployed as they were at the revision, and the projects which did not exist 
+at the revision are deleted. This can be used even if the last deployment was successful.
+If the deployment was limited to a scope using --scope and --path, use the same scope and paths to rollback the scope.
+NOTE: Both the flags --environment (-e) and --to are mandatory`
+
+const vcsRollbackCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsRollbackCmdLiteral + ` -e prod --to 1
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsRollbackCmdLiteral + ` -e prod --to 5d8e1c2a`
+
+// VCSRollbackCmd represents the vcs rollback command
+var VCSRollbackCmd = &cobra.Command{
+	Use:     vcsRollbackCmdLiteral,
+	Short:   vcsRollbackCmdShortDesc,
+	Long:    vcsRollbackCmdLongDesc,
+	Example: vcsRollbackCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsRollbackCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSRollbackEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSRollbackEnvName, "does not exists. Add it using add env")
+			os.Exit(1)
+		}
+		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+		if mainConfig.Config.VCSSourceRepoPath == "" {
+			fmt.Println("VSC source repo path cannot be empty. Set it using apictl set command.")
+			os.Exit(1)
+		}
+		if flagVCSRollbackParallel < 1 {
+			utils.HandleErrorAndExit("The value of --parallel should be a positive number", nil)
+		}
+		// an environment having only a Micro Integrator is rolled back with the MI CApp projects only
+		var accessOAuthToken string
+		if utils.APIMExistsInEnv(flagVCSRollbackEnvName, utils.MainConfigFilePath) {
+			credential, err := GetCredentials(flagVCSRollbackEnvName)
+			if err != nil {
+				utils.HandleErrorAndExit("Error getting credentials", err)
+			}
+			accessOAuthToken, err = credentials.GetOAuthAccessToken(credential, flagVCSRollbackEnvName)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while getting an access token for rolling back the project(s)", err)
+			}
+		}
+		scopeParams := getVCSScopeParams(flagVCSRollbackScope, flagVCSRollbackPaths)
+		releaseLock := git.LockEnvironment(flagVCSRollbackEnvName)
+		err := git.RollbackToRevision(accessOAuthToken, flagVCSRollbackEnvName, flagVCSRollbackTo, scopeParams,
+			flagVCSRollbackParallel)
+		// the lock is released before exiting on an error
+		releaseLock()
+		if err != nil {
+			utils.HandleErrorAndExit("Failed to rollback "+flagVCSRollbackEnvName, err)
+		}
+		fmt.Println("\nSuccessfully rolled back " + flagVCSRollbackEnvName)
+	},
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSRollbackCmd)
+
+	VCSRollbackCmd.Flags().StringVarP(&flagVCSRollbackEnvName, "environment", "e", "", "Name of the "+
+		"environment to rollback")
+	VCSRollbackCmd.Flags().StringVarP(&flagVCSRollbackTo, "to", "", "",
+		"Index (0 is the latest) or the commit id of the successful revision to rollback to")
+	VCSRollbackCmd.Flags().IntVarP(&flagVCSRollbackParallel, "parallel", "", 1,
+		"Number of projects of the same type to deploy in parallel")
+	VCSRollbackCmd.Flags().StringVarP(&flagVCSRollbackScope, "scope", "", "",
+		"Name of the scope which the deployment was limited to")
+	VCSRollbackCmd.Flags().StringSliceVarP(&flagVCSRollbackPaths, "path", "", []string{},
+		"Subtrees of the repository (relative to the repository root) which the scope was limited to. Requires --scope")
+
+	_ = VCSRollbackCmd.MarkFlagRequired("environment")
+	_ = VCSRollbackCmd.MarkFlagRequired("to")
+}
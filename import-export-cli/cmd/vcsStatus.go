@@ -34,14 +34,20 @@ import (
 
 var flagVCSStatusEnvName string // name of the environment to be added
 var flagVCSStatusFormat string  // format of the output to be printed
+var flagVCSStatusScope string   // name of the scope to show the status of
+var flagVCSStatusPaths []string // subtrees of the repository to limit the status to
 
 // push command related usage Info
 const vcsStatusCmdLiteral = "status"
 const vcsStatusCmdShortDesc = "Shows the list of projects that are ready to deploy"
 const vcsStatusCmdLongDesc = `Shows the list of projects that are ready to deploy to the specified environment by --environment(-e)
+Only the projects in the include globs and out of the exclude globs of the environment in vcs.yaml are considered. 
+Use --scope to show the status of a scope, and --path to limit the projects of the scope to the given subtrees of the 
+repository.
 NOTE: --environment (-e) flag is mandatory`
 
-const vcsStatusCmdCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsStatusCmdLiteral + ` -e dev`
+const vcsStatusCmdCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsStatusCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsStatusCmdLiteral + ` -e dev --scope payments --path teams/payments`
 
 // pushCmd represents the push command
 var VCSStatusCmd = &cobra.Command{
@@ -55,8 +61,10 @@ var VCSStatusCmd = &cobra.Command{
 			fmt.Println(flagVCSStatusEnvName, "does not exists. Add it using add env")
 			os.Exit(1)
 		}
+		scopeParams := getVCSScopeParams(flagVCSStatusScope, flagVCSStatusPaths)
 
-		_, totalProjectsToUpdate, updatedProjectsPerType := git.GetStatus(flagVCSStatusEnvName, git.FromRevTypeLastAttempted)
+		_, totalProjectsToUpdate, updatedProjectsPerType := git.GetStatus(flagVCSStatusEnvName,
+			git.FromRevTypeLastAttempted, scopeParams)
 		if totalProjectsToUpdate == 0 {
 			fmt.Println("Everything is up-to-date")
 			return
@@ -70,6 +78,7 @@ var VCSStatusCmd = &cobra.Command{
 			printProjectsToUpdate(utils.ProjectTypeApi, updatedProjectsPerType[utils.ProjectTypeApi])
 			printProjectsToUpdate(utils.ProjectTypeApiProduct, updatedProjectsPerType[utils.ProjectTypeApiProduct])
 			printProjectsToUpdate(utils.ProjectTypeApplication, updatedProjectsPerType[utils.ProjectTypeApplication])
+			printProjectsToUpdate(utils.ProjectTypeMICApp, updatedProjectsPerType[utils.ProjectTypeMICApp])
 		}
 	},
 }
@@ -85,7 +94,9 @@ func printProjectsToUpdate(projectType string, projects []*params.ProjectParams)
 			} else {
 				operation = "[save]"
 			}
-			if projectParam.FailedDuringPreviousDeploy {
+			if projectParam.Blocked {
+				failed = "[blocked]"
+			} else if projectParam.FailedDuringPreviousDeploy {
 				failed = "[failed]"
 			}
 			fmt.Println(strconv.Itoa(i+1) + ": " + operation + "\t" + failed + "\t" + projectParam.NickName +
@@ -119,6 +130,10 @@ func init() {
 		"environment to check the project(s) status")
 	VCSStatusCmd.Flags().StringVarP(&flagVCSStatusFormat, "format", "", "",
 		"Pretty-print status (only supported \"{{ jsonPretty . }}\" and \"{{ json . }}\")")
+	VCSStatusCmd.Flags().StringVarP(&flagVCSStatusScope, "scope", "", "",
+		"Name of the scope to show the status of")
+	VCSStatusCmd.Flags().StringSliceVarP(&flagVCSStatusPaths, "path", "", []string{},
+		"Subtrees of the repository (relative to the repository root) to limit the scope to. Requires --scope")
 
 	_ = VCSStatusCmd.MarkFlagRequired("environment")
 }
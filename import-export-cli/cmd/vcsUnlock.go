@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSUnlockEnvName string // name of the environment to clear the deployment lock
+
+// "vcs unlock" command related usage Info
+const vcsUnlockCmdLiteral = "unlock"
+const vcsUnlockCmdShortDesc = "Clears the deployment lock of an environment"
+const vcsUnlockCmdLongDesc = `Clears the deployment lock of the environment specified by --environment(-e).
+A deployment locks the environment until it is completed, and a lock left by a deployment which was terminated 
+unexpectedly is kept until it expires. This clears such a stale lock, so that the environment can be deployed again.
+Make sure that no deployment is running against the environment before clearing the lock.
+NOTE: --environment (-e) flag is mandatory`
+
+const vcsUnlockCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsUnlockCmdLiteral + ` -e dev`
+
+// VCSUnlockCmd represents the vcs unlock command
+var VCSUnlockCmd = &cobra.Command{
+	Use:     vcsUnlockCmdLiteral,
+	Short:   vcsUnlockCmdShortDesc,
+	Long:    vcsUnlockCmdLongDesc,
+	Example: vcsUnlockCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsUnlockCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSUnlockEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSUnlockEnvName, "does not exists. Add it using add env")
+			os.Exit(1)
+		}
+		lock := git.UnlockEnvironment(flagVCSUnlockEnvName)
+		if lock == nil {
+			fmt.Println("Environment " + flagVCSUnlockEnvName + " is not locked")
+			return
+		}
+		fmt.Println("Cleared the deployment lock of " + flagVCSUnlockEnvName + " held by " + lock.String())
+	},
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSUnlockCmd)
+
+	VCSUnlockCmd.Flags().StringVarP(&flagVCSUnlockEnvName, "environment", "e", "", "Name of the "+
+		"environment to clear the deployment lock")
+
+	_ = VCSUnlockCmd.MarkFlagRequired("environment")
+}
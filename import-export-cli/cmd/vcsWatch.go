@@ -0,0 +1,141 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSWatchEnvName string           // name of the environment the project changes need to be deployed
+var flagVCSWatchInterval time.Duration   // time between two polls of the repositories
+var flagVCSWatchMaxBackoff time.Duration // maximum time to wait before retrying a failed deployment
+var flagVCSWatchParallel int             // number of projects of the same type to be deployed at the same time
+var flagVCSWatchHealthAddress string     // address of the health endpoint
+var flagVCSWatchScope string             // name of the scope which the state of the deployments is kept against
+var flagVCSWatchPaths []string           // subtrees of the repositories to limit the deployments to
+
+// "vcs watch" command related usage Info
+const vcsWatchCmdLiteral = "watch"
+const vcsWatchCmdShortDesc = "Continuously deploys the changes of the repositories to the specified environment"
+const vcsWatchCmdLongDesc = `Watches the source and the deployment repositories and deploys the changed projects to the environment 
+specified by --environment(-e), until it is stopped. The repositories are pulled from their remotes (if any) every 
+--interval, and the changed projects are deployed as in 'vcs deploy' whenever the HEAD of any of the repositories moves.
+Changes to a protected environment which need an approval are not deployed, and are reported as failures until 
+those are deployed using 'vcs deploy'. A failed deployment is not rolled back, unless a post-deploy hook of a project 
+having rollbackOnFailure fails. Instead, it is retried after waiting twice as long as the previous attempt, up to 
+--max-backoff. The projects failed during a deployment are retried with the next deployment.
+The status of the last run is served at http://<--health-address>/health, which responds with 200 if the last run was 
+successful and with 503 otherwise. Use an empty --health-address to disable the health endpoint.
+NOTE: --environment (-e) flag is mandatory`
+
+const vcsWatchCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsWatchCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsWatchCmdLiteral + ` -e dev --interval 1m --max-backoff 1h
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsWatchCmdLiteral + ` -e dev --health-address 0.0.0.0:9095`
+
+// VCSWatchCmd represents the vcs watch command
+var VCSWatchCmd = &cobra.Command{
+	Use:     vcsWatchCmdLiteral,
+	Short:   vcsWatchCmdShortDesc,
+	Long:    vcsWatchCmdLongDesc,
+	Example: vcsWatchCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsWatchCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSWatchEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSWatchEnvName, "does not exists. Add it using add env")
+			os.Exit(1)
+		}
+		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+		if mainConfig.Config.VCSSourceRepoPath == "" {
+			fmt.Println("VSC source repo path cannot be empty. Set it using apictl set command.")
+			os.Exit(1)
+		}
+		if flagVCSWatchInterval <= 0 {
+			utils.HandleErrorAndExit("The value of --interval should be a positive duration", nil)
+		}
+		if flagVCSWatchParallel < 1 {
+			utils.HandleErrorAndExit("The value of --parallel should be a positive number", nil)
+		}
+		// the credentials are read (or prompted) once, and an access token is taken from those for every deployment
+		getAccessToken := func() (string, error) {
+			return "", nil
+		}
+		// an environment having only a Micro Integrator is deployed with the MI CApp projects only, which use the
+		//  credentials of 'apictl mi login'
+		if utils.APIMExistsInEnv(flagVCSWatchEnvName, utils.MainConfigFilePath) {
+			credential, err := GetCredentials(flagVCSWatchEnvName)
+			if err != nil {
+				utils.HandleErrorAndExit("Error getting credentials", err)
+			}
+			getAccessToken = func() (string, error) {
+				return credentials.GetOAuthAccessToken(credential, flagVCSWatchEnvName)
+			}
+		}
+		scopeParams := getVCSScopeParams(flagVCSWatchScope, flagVCSWatchPaths)
+		watcher := git.NewWatcher(flagVCSWatchEnvName, scopeParams, flagVCSWatchInterval, flagVCSWatchMaxBackoff,
+			flagVCSWatchParallel, getAccessToken)
+		if flagVCSWatchHealthAddress != "" {
+			if err := watcher.ServeHealth(flagVCSWatchHealthAddress); err != nil {
+				utils.HandleErrorAndExit("Error while starting the health endpoint", err)
+			}
+			fmt.Println("Serving the health endpoint at http://" + flagVCSWatchHealthAddress + "/health")
+		}
+
+		// the run in progress is completed before stopping, so that the environment is not left locked
+		stop := make(chan struct{})
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			fmt.Println("Stopping after the current run..")
+			close(stop)
+		}()
+		fmt.Println("Watching the repositories for " + flagVCSWatchEnvName + " every " + flagVCSWatchInterval.String())
+		watcher.Run(stop)
+	},
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSWatchCmd)
+
+	VCSWatchCmd.Flags().StringVarP(&flagVCSWatchEnvName, "environment", "e", "", "Name of the "+
+		"environment to deploy the project(s)")
+	VCSWatchCmd.Flags().DurationVarP(&flagVCSWatchInterval, "interval", "", time.Minute,
+		"Time between two polls of the repositories")
+	VCSWatchCmd.Flags().DurationVarP(&flagVCSWatchMaxBackoff, "max-backoff", "", 30*time.Minute,
+		"Maximum time to wait before retrying a failed deployment")
+	VCSWatchCmd.Flags().IntVarP(&flagVCSWatchParallel, "parallel", "", 1,
+		"Number of projects of the same type to deploy in parallel")
+	VCSWatchCmd.Flags().StringVarP(&flagVCSWatchHealthAddress, "health-address", "", "localhost:9095",
+		"Address (host:port) to serve the health endpoint at")
+	VCSWatchCmd.Flags().StringVarP(&flagVCSWatchScope, "scope", "", "",
+		"Name of the scope to deploy. The deployment state of each scope is kept separately")
+	VCSWatchCmd.Flags().StringSliceVarP(&flagVCSWatchPaths, "path", "", []string{},
+		"Subtrees of the repositories (relative to the repository root) to limit the scope to. Requires --scope")
+
+	_ = VCSWatchCmd.MarkFlagRequired("environment")
+}
@@ -27,6 +27,8 @@ import (
 const Git = "git"
 const VCSConfigFileName = "vcs_config.yaml"
 const VCSRepoInfoFileName = "vcs.yaml"
+const VCSLockFileName = "vcs_lock.yaml"
+const VCSLocksDirName = "vcs_locks"
 
 const FromRevTypeLastAttempted = "last_attempted"
 const FromRevTypeLastSuccessful = "last_successful"
@@ -0,0 +1,179 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// blockedProjectError is returned when a project is not deployed as one or more projects it depends on were failed
+//  during the same deployment
+type blockedProjectError struct {
+	failedDependencies []string
+}
+
+func (e *blockedProjectError) Error() string {
+	return "depends on the failed project(s): " + strings.Join(e.failedDependencies, ", ")
+}
+
+// Resolves the member APIs of the updated API Product projects which are also maintained as API projects in the
+//  repository, and sets them as the dependencies of each API Product project.
+// repoBasePath is the basepath of the git repository (or the directory which the revision is extracted into)
+// revision is the revision of the repository which the projects are deployed from
+// updatedProjectsPerType is a map of project type -> projects which consists of the projects to deploy
+func resolveApiProductDependencies(repoBasePath, revision string, updatedProjectsPerType map[string][]*params.ProjectParams) {
+	var apiProjectsInRepo map[string]*params.ProjectParams
+	for _, projectParam := range updatedProjectsPerType[utils.ProjectTypeApiProduct] {
+		if projectParam.Deleted {
+			continue
+		}
+		// The API projects of the repository are scanned only once, and only if there are API Products to deploy
+		if apiProjectsInRepo == nil {
+			apiProjectsInRepo = getApiProjectsInRepo(repoBasePath, revision)
+		}
+		apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
+		if err != nil {
+			utils.Logln(utils.LogPrefixWarning+"Unable to read the member APIs of "+projectParam.RelativePath, err)
+			continue
+		}
+		projectParam.Dependencies = nil
+		for _, memberApi := range apiProductInfo.Data.APIs {
+			apiProject, found := apiProjectsInRepo[memberApi.Name+"-"+memberApi.Version]
+			if !found {
+				continue
+			}
+			utils.Logln(utils.LogPrefixInfo + projectParam.RelativePath + " depends on " + apiProject.RelativePath)
+			projectParam.Dependencies = append(projectParam.Dependencies, params.ProjectInfo{
+				Name:    memberApi.Name,
+				Version: memberApi.Version,
+			})
+		}
+	}
+}
+
+// Returns all the API projects committed to the current repository at the given revision
+// repoBasePath is the basepath of the git repository (or the directory which the revision is extracted into)
+// revision is the revision of the repository to scan
+// Returns map[string]*params.ProjectParams, a map of name-version of the API -> API project
+func getApiProjectsInRepo(repoBasePath, revision string) map[string]*params.ProjectParams {
+	apiProjects := make(map[string]*params.ProjectParams)
+	files, err := listFilesAtRevision(revision)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while listing the files of the repository", err)
+	}
+	for _, file := range files {
+		if filepath.Base(file) != utils.MetaFileAPI {
+			continue
+		}
+		projectPath := filepath.Join(repoBasePath, filepath.FromSlash(filepath.Dir(file)))
+		metaData, err := LoadMetaDataFile(filepath.Join(projectPath, utils.MetaFileAPI))
+		if err != nil {
+			utils.Logln(utils.LogPrefixWarning+"Unable to read "+file, err)
+			continue
+		}
+		apiProjects[metaData.Name+"-"+metaData.Version] = &params.ProjectParams{
+			Type:         utils.ProjectTypeApi,
+			AbsolutePath: projectPath,
+			RelativePath: filepath.FromSlash(filepath.Dir(file)),
+			NickName:     filepath.Base(projectPath),
+			MetaData:     metaData,
+		}
+	}
+	return apiProjects
+}
+
+// Returns the dependencies of the given project which were failed during the current deployment
+// projectParam is the project to check the dependencies of
+// failedProjects is the map of project type -> projects which are keeping the projects failed during the deployment
+// Returns []string, name-version of each failed dependency
+func getFailedDependencies(projectParam *params.ProjectParams,
+	failedProjects map[string][]*params.ProjectParams) []string {
+	var failedDependencies []string
+	for _, dependency := range projectParam.Dependencies {
+		for _, failedProject := range failedProjects[utils.ProjectTypeApi] {
+			if failedProject.MetaData != nil && failedProject.MetaData.Name == dependency.Name &&
+				failedProject.MetaData.Version == dependency.Version {
+				failedDependencies = append(failedDependencies, dependency.Name+"-"+dependency.Version)
+				break
+			}
+		}
+	}
+	return failedDependencies
+}
+
+// Returns a copy of the API Product project without the member APIs which are maintained as API projects in the
+//  repository. Those are already deployed from their own projects, hence should be neither imported nor updated
+//  again from the APIs bundled with the API Product.
+// projectPath is the path of the API Product project
+// dependencies are the member APIs of the API Product maintained as API projects in the repository
+// Returns string, the path of the copy, or projectPath if the API Product does not bundle any of the dependencies
+// Returns bool, true if the API Product still bundles any other member APIs
+// Returns func(), the function which removes the copy
+func excludeDependenciesFromApiProduct(projectPath string, dependencies []params.ProjectInfo) (string, bool, func(),
+	error) {
+	noCleanup := func() {}
+	apisDir := filepath.Join(projectPath, "APIs")
+	bundledApis, err := ioutil.ReadDir(apisDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return projectPath, false, noCleanup, nil
+		}
+		return "", false, noCleanup, err
+	}
+	dependencyDirs := make(map[string]bool)
+	for _, dependency := range dependencies {
+		dependencyDirs[dependency.Name+"-"+dependency.Version] = true
+	}
+	var bundledDependencies []string
+	for _, bundledApi := range bundledApis {
+		if dependencyDirs[bundledApi.Name()] {
+			bundledDependencies = append(bundledDependencies, bundledApi.Name())
+		}
+	}
+	hasOtherApis := len(bundledApis) > len(bundledDependencies)
+	if len(bundledDependencies) == 0 {
+		return projectPath, hasOtherApis, noCleanup, nil
+	}
+
+	copyPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return "", false, noCleanup, err
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(filepath.Dir(copyPath)); err != nil {
+			utils.Logln(utils.LogPrefixError + err.Error())
+		}
+	}
+	for _, bundledDependency := range bundledDependencies {
+		utils.Logln(utils.LogPrefixInfo + "Excluding " + bundledDependency + " from the APIs bundled with " +
+			filepath.Base(projectPath) + " as it is deployed from the API project")
+		if err := os.RemoveAll(filepath.Join(copyPath, "APIs", bundledDependency)); err != nil {
+			cleanup()
+			return "", false, noCleanup, err
+		}
+	}
+	return copyPath, hasOtherApis, cleanup, nil
+}
@@ -0,0 +1,124 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const testApiProductDefinition = `type: api_product
+data:
+  name: Shop
+  apis:
+    - name: PizzaShack
+      version: 1.0.0
+    - name: Petstore
+      version: 1.0.0
+`
+
+func TestResolveApiProductDependencies(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-repo")
+	defer os.RemoveAll(tmpDir)
+	repoDir := filepath.Join(tmpDir, "repo")
+	initStateTestRepo(t, repoDir)
+	// only PizzaShack is maintained as an API project in the repository
+	commitTestFiles(t, map[string]string{
+		"PizzaShack/" + utils.MetaFileAPI:     "name: PizzaShack\nversion: 1.0.0\n",
+		"Other/" + utils.MetaFileAPI:          "name: Other\nversion: 1.0.0\n",
+		"Shop/" + utils.MetaFileAPIProduct:    "name: Shop\nversion: 1.0.0\n",
+		"Shop/api_product.yaml":               testApiProductDefinition,
+		"Deleted/" + utils.MetaFileAPIProduct: "name: Deleted\nversion: 1.0.0\n",
+		"Deleted/api_product.yaml":            testApiProductDefinition,
+	}, "first")
+
+	shop := &params.ProjectParams{Type: utils.ProjectTypeApiProduct, AbsolutePath: filepath.Join(repoDir, "Shop"),
+		RelativePath: "Shop"}
+	deleted := &params.ProjectParams{Type: utils.ProjectTypeApiProduct, AbsolutePath: filepath.Join(repoDir, "Deleted"),
+		RelativePath: "Deleted", Deleted: true}
+	resolveApiProductDependencies(repoDir, "HEAD", map[string][]*params.ProjectParams{
+		utils.ProjectTypeApiProduct: {shop, deleted},
+	})
+	assert.Equal(t, []params.ProjectInfo{{Name: "PizzaShack", Version: "1.0.0"}}, shop.Dependencies)
+	assert.Empty(t, deleted.Dependencies, "the dependencies of a deleted project should not be resolved")
+}
+
+func TestGetFailedDependencies(t *testing.T) {
+	apiProduct := &params.ProjectParams{Type: utils.ProjectTypeApiProduct, Dependencies: []params.ProjectInfo{
+		{Name: "PizzaShack", Version: "1.0.0"},
+		{Name: "Petstore", Version: "1.0.0"},
+	}}
+	failedProjects := map[string][]*params.ProjectParams{
+		utils.ProjectTypeApi: {
+			{Type: utils.ProjectTypeApi, MetaData: &utils.MetaData{Name: "Petstore", Version: "2.0.0"}},
+			{Type: utils.ProjectTypeApi, MetaData: &utils.MetaData{Name: "PizzaShack", Version: "1.0.0"}},
+			// a deleted project does not have the meta data
+			{Type: utils.ProjectTypeApi, NickName: "Petstore"},
+		},
+	}
+	assert.Equal(t, []string{"PizzaShack-1.0.0"}, getFailedDependencies(apiProduct, failedProjects))
+
+	assert.Empty(t, getFailedDependencies(apiProduct, map[string][]*params.ProjectParams{}),
+		"there should be no failed dependencies")
+	assert.Empty(t, getFailedDependencies(&params.ProjectParams{Type: utils.ProjectTypeApiProduct}, failedProjects),
+		"there should be no failed dependencies")
+}
+
+func TestExcludeDependenciesFromApiProduct(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-product")
+	defer os.RemoveAll(tmpDir)
+	projectPath := filepath.Join(tmpDir, "Shop")
+	for _, apiDir := range []string{"PizzaShack-1.0.0", "Petstore-1.0.0"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(projectPath, "APIs", apiDir), os.ModePerm), "err should be nil")
+	}
+	pizzaShack := []params.ProjectInfo{{Name: "PizzaShack", Version: "1.0.0"}}
+
+	copyPath, hasOtherApis, cleanup, err := excludeDependenciesFromApiProduct(projectPath, pizzaShack)
+	assert.Nil(t, err, "err should be nil")
+	assert.NotEqual(t, projectPath, copyPath, "the dependencies should be excluded from a copy")
+	assert.True(t, hasOtherApis, "Petstore should still be bundled")
+	bundledApis, _ := ioutil.ReadDir(filepath.Join(copyPath, "APIs"))
+	assert.Equal(t, 1, len(bundledApis))
+	assert.Equal(t, "Petstore-1.0.0", bundledApis[0].Name())
+	originalApis, _ := ioutil.ReadDir(filepath.Join(projectPath, "APIs"))
+	assert.Equal(t, 2, len(originalApis), "the project itself should not be changed")
+	cleanup()
+	_, err = os.Stat(copyPath)
+	assert.True(t, os.IsNotExist(err), "the copy should be removed")
+
+	copyPath, hasOtherApis, cleanup, err = excludeDependenciesFromApiProduct(projectPath,
+		append(pizzaShack, params.ProjectInfo{Name: "Petstore", Version: "1.0.0"}))
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, hasOtherApis, "no other APIs should be bundled")
+	cleanup()
+
+	// an API Product without the bundled APIs is used as it is
+	assert.Nil(t, os.RemoveAll(filepath.Join(projectPath, "APIs")), "err should be nil")
+	copyPath, hasOtherApis, _, err = excludeDependenciesFromApiProduct(projectPath, pizzaShack)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, projectPath, copyPath)
+	assert.False(t, hasOtherApis, "no other APIs should be bundled")
+}
@@ -0,0 +1,139 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Deploys the projects of a single project type (API, API Product, App..) using a bounded pool of workers. Deleted
+//  projects are not deployed here, they are tracked in deletedProjectsPerType and handled after all the saves.
+// projects are the updated projects which belong to a single project type
+// parallel is the maximum number of projects that are deployed at the same time
+// deploy is the function which imports a single project to the environment, writing its output to the given writer
+// deletedProjectsPerType is the map of project type -> projects which are keeping the projects to delete
+// failedProjects is the map of project type -> projects which are keeping the projects failed during the deployment
+// blockedProjects is the map of project type -> projects which are keeping the projects not deployed as the projects
+//  those depend on were failed
+// Returns bool, true if any deleted projects exist among the given projects
+func deployProjectsOfType(projects []*params.ProjectParams, parallel int, deploy func(*params.ProjectParams, io.Writer) error,
+	deletedProjectsPerType, failedProjects, blockedProjects map[string][]*params.ProjectParams) bool {
+	var hasDeletedProjects bool
+	var projectsToDeploy []int
+	for i, projectParam := range projects {
+		// if the project is a deleted one, we do it later. So keep it for now.
+		if projectParam.Deleted {
+			handleProjectDeletion(i, projectParam, deletedProjectsPerType)
+			hasDeletedProjects = true
+			continue
+		}
+		projectsToDeploy = append(projectsToDeploy, i)
+	}
+	if len(projectsToDeploy) == 0 {
+		return hasDeletedProjects
+	}
+
+	// errors are kept against the index of the project so that the failed projects are recorded in the same order
+	//  regardless of the order the workers complete
+	errs := make([]error, len(projects))
+	var outputLock sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	workers := utils.Min(parallel, len(projectsToDeploy))
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				projectParam := projects[i]
+				// when deploying in parallel, the output of each project is buffered and printed at once after the
+				//  project is deployed, so that the output of the projects do not interleave
+				var output bytes.Buffer
+				var out io.Writer = os.Stdout
+				if workers > 1 {
+					out = &output
+				}
+				fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+				projectParam.Blocked = false
+				startTime := time.Now()
+				errs[i] = deploy(projectParam, out)
+				recordProjectResult(projectParam, startTime, errs[i])
+				_, projectParam.MicrogatewayFailed = errs[i].(*microgatewayDeployError)
+				hookErr, isHookErr := errs[i].(*deployHookError)
+				projectParam.RollbackRequested = isHookErr && hookErr.rollbackRequested
+				if _, isBlocked := errs[i].(*blockedProjectError); isBlocked {
+					// blocked projects are tracked separately from the failed ones, as the project itself was not
+					//  attempted. Those are retried with the next deployment.
+					projectParam.Blocked = true
+					fmt.Fprintln(out, "Blocked... "+errs[i].Error())
+				} else if errs[i] != nil {
+					fmt.Fprintln(out, "Error... "+getDeployErrorMessage(errs[i]))
+				}
+				if workers > 1 {
+					printDeployOutput(&outputLock, os.Stdout, output.String())
+				}
+			}
+		}()
+	}
+	for _, i := range projectsToDeploy {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if _, isBlocked := err.(*blockedProjectError); isBlocked {
+			blockedProjects[projects[i].Type] = append(blockedProjects[projects[i].Type], projects[i])
+		} else if err != nil {
+			failedProjects[projects[i].Type] = append(failedProjects[projects[i].Type], projects[i])
+		}
+	}
+	return hasDeletedProjects
+}
+
+// Returns the message of an error occurred while deploying a project. The response of a failed Microgateway
+//  deployment is included, as it is not written by the impl functions unlike the response of a failed import.
+func getDeployErrorMessage(err error) string {
+	if mgErr, isMgErr := err.(*microgatewayDeployError); isMgErr {
+		if responseErr, ok := mgErr.err.(*utils.HttpResponseError); ok && responseErr.Body != "" {
+			return err.Error() + "\nResponse: " + responseErr.Body
+		}
+	}
+	return err.Error()
+}
+
+// Prints the buffered output of a project deployed by a worker while avoiding interleaving with the output of the
+//  other workers
+func printDeployOutput(outputLock *sync.Mutex, stdout io.Writer, output string) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	fmt.Fprint(stdout, output)
+}
@@ -0,0 +1,137 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package git
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Returns the given number of API projects named API1, API2..
+func getTestApiProjects(count int) []*params.ProjectParams {
+	var projects []*params.ProjectParams
+	for i := 1; i <= count; i++ {
+		name := "API" + strconv.Itoa(i)
+		projects = append(projects, &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: name,
+			RelativePath: name, MetaData: &utils.MetaData{Name: name, Version: "1.0.0"}})
+	}
+	return projects
+}
+
+func TestDeployProjectsOfTypeRecordsFailedProjectsInOrder(t *testing.T) {
+	projects := getTestApiProjects(6)
+	deploy := func(projectParam *params.ProjectParams, out io.Writer) error {
+		// the projects deployed first complete last
+		index, _ := strconv.Atoi(projectParam.NickName[3:])
+		time.Sleep(time.Duration(len(projects)-index) * 10 * time.Millisecond)
+		if index%2 == 0 {
+			return errors.New("failed to deploy " + projectParam.NickName)
+		}
+		return nil
+	}
+	deletedProjects := make(map[string][]*params.ProjectParams)
+	failedProjects := make(map[string][]*params.ProjectParams)
+
+	hasDeletedProjects := deployProjectsOfType(projects, 3, deploy, deletedProjects, failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.False(t, hasDeletedProjects, "there should be no deleted projects")
+	assert.Equal(t, []*params.ProjectParams{projects[1], projects[3], projects[5]}, failedProjects[utils.ProjectTypeApi])
+	assert.Empty(t, deletedProjects, "there should be no deleted projects")
+}
+
+func TestDeployProjectsOfTypeLimitsParallelDeployments(t *testing.T) {
+	var running, maxRunning int32
+	deploy := func(projectParam *params.ProjectParams, out io.Writer) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+	failedProjects := make(map[string][]*params.ProjectParams)
+
+	deployProjectsOfType(getTestApiProjects(8), 2, deploy, make(map[string][]*params.ProjectParams), failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning), "at most 2 projects should be deployed at once")
+	assert.Empty(t, failedProjects, "there should be no failed projects")
+
+	maxRunning = 0
+	deployProjectsOfType(getTestApiProjects(3), 0, deploy, make(map[string][]*params.ProjectParams), failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning), "projects should be deployed one by one")
+}
+
+func TestDeployProjectsOfTypeSkipsDeletedProjects(t *testing.T) {
+	projects := getTestApiProjects(3)
+	projects[1].Deleted = true
+	var deployed []string
+	deploy := func(projectParam *params.ProjectParams, out io.Writer) error {
+		deployed = append(deployed, projectParam.NickName)
+		return nil
+	}
+	deletedProjects := make(map[string][]*params.ProjectParams)
+	failedProjects := make(map[string][]*params.ProjectParams)
+
+	hasDeletedProjects := deployProjectsOfType(projects, 1, deploy, deletedProjects, failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.True(t, hasDeletedProjects, "the deleted project should be reported")
+	assert.Equal(t, []string{"API1", "API3"}, deployed)
+	assert.Equal(t, []*params.ProjectParams{projects[1]}, deletedProjects[utils.ProjectTypeApi])
+	assert.Empty(t, failedProjects, "there should be no failed projects")
+
+	// only the deleted projects are tracked when there is nothing to deploy
+	deletedProjects = make(map[string][]*params.ProjectParams)
+	hasDeletedProjects = deployProjectsOfType(projects[1:2], 2, deploy, deletedProjects, failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.True(t, hasDeletedProjects, "the deleted project should be reported")
+	assert.Equal(t, []*params.ProjectParams{projects[1]}, deletedProjects[utils.ProjectTypeApi])
+}
+
+func TestDeployProjectsOfTypeTracksBlockedProjectsSeparately(t *testing.T) {
+	projects := getTestApiProjects(3)
+	deploy := func(projectParam *params.ProjectParams, out io.Writer) error {
+		switch projectParam.NickName {
+		case "API1":
+			return &blockedProjectError{failedDependencies: []string{"Petstore-1.0.0"}}
+		case "API2":
+			return errors.New("failed to deploy API2")
+		}
+		return nil
+	}
+	failedProjects := make(map[string][]*params.ProjectParams)
+	blockedProjects := make(map[string][]*params.ProjectParams)
+
+	deployProjectsOfType(projects, 2, deploy, make(map[string][]*params.ProjectParams), failedProjects, blockedProjects)
+	assert.Equal(t, []*params.ProjectParams{projects[0]}, blockedProjects[utils.ProjectTypeApi])
+	assert.Equal(t, []*params.ProjectParams{projects[1]}, failedProjects[utils.ProjectTypeApi])
+	assert.True(t, projects[0].Blocked, "the blocked project should be marked")
+	assert.False(t, projects[1].Blocked, "the failed project should not be marked as blocked")
+}
@@ -0,0 +1,425 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Types of changes of a field or a resource
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Categories of the field level changes of a project
+const (
+	ChangeCategoryEndpoint  = "endpoint"
+	ChangeCategoryPolicy    = "policy"
+	ChangeCategoryScope     = "scope"
+	ChangeCategoryOperation = "operation"
+	ChangeCategoryLifecycle = "lifecycle"
+	ChangeCategoryOther     = "other"
+)
+
+// ProjectDiff represents the changes of a single project between the last successful revision and the working copy
+type ProjectDiff struct {
+	Type              string              `json:"type"`
+	NickName          string              `json:"nickName"`
+	RelativePath      string              `json:"relativePath"`
+	New               bool                `json:"new,omitempty"`
+	Deleted           bool                `json:"deleted,omitempty"`
+	FieldChanges      []*FieldChange      `json:"fieldChanges,omitempty"`
+	DefinitionChanges []*DefinitionChange `json:"definitionChanges,omitempty"`
+}
+
+// FieldChange represents a change of a single field of api.yaml, api_product.yaml or application.yaml
+type FieldChange struct {
+	Category string      `json:"category"`
+	Field    string      `json:"field"`
+	Change   string      `json:"change"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// DefinitionChange represents a change of a resource (path and verb) of Definitions/swagger.yaml
+type DefinitionChange struct {
+	Path   string `json:"path"`
+	Verb   string `json:"verb"`
+	Change string `json:"change"`
+}
+
+// Fields which are updated by APIM on each export, hence not considered as changes
+var diffIgnoredFields = map[string]bool{
+	"id":                   true,
+	"createdTime":          true,
+	"lastUpdatedTime":      true,
+	"lastUpdatedTimestamp": true,
+	"usedProductIds":       true,
+}
+
+// Categories of the top level fields of the project definition files. The fields not listed here are categorized
+//  as "other".
+var diffFieldCategories = map[string]string{
+	"endpointConfig":             ChangeCategoryEndpoint,
+	"endpointImplementationType": ChangeCategoryEndpoint,
+	"endpointSecurity":           ChangeCategoryEndpoint,
+	"policies":                   ChangeCategoryPolicy,
+	"apiThrottlingPolicy":        ChangeCategoryPolicy,
+	"mediationPolicies":          ChangeCategoryPolicy,
+	"throttlingPolicy":           ChangeCategoryPolicy,
+	"scopes":                     ChangeCategoryScope,
+	"operations":                 ChangeCategoryOperation,
+	"apis":                       ChangeCategoryOperation,
+	"lifeCycleStatus":            ChangeCategoryLifecycle,
+	"state":                      ChangeCategoryLifecycle,
+	"status":                     ChangeCategoryLifecycle,
+}
+
+// HTTP verbs which can be defined under a path of a swagger definition
+var swaggerVerbs = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Returns the semantic changes of the projects which are ready to deploy to the given environment, by comparing the
+//  definitions of the projects at the last successful revision with the working copy, which the projects are
+//  deployed from
+// environment is the environment name
+// Returns string, the last successful revision the changes are compared with (empty if never deployed successfully)
+// Returns []*ProjectDiff, the changes of each project
+func GetDiff(environment string) (string, []*ProjectDiff) {
+	repoId, _, updatedProjectsPerType := GetStatus(environment, FromRevTypeLastSuccessful, ScopeParams{})
+	envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment, ScopeParams{})
+	var fromRev string
+	if len(envVCSConfig.LastSuccessfulRev) > 0 {
+		fromRev = envVCSConfig.LastSuccessfulRev[0]
+	}
+
+	var projectDiffs []*ProjectDiff
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication,
+		utils.ProjectTypeMICApp} {
+		for _, projectParam := range updatedProjectsPerType[projectType] {
+			projectDiff, err := getProjectDiff(fromRev, projectParam)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while getting the changes of "+projectParam.RelativePath, err)
+			}
+			projectDiffs = append(projectDiffs, projectDiff)
+		}
+	}
+	return fromRev, projectDiffs
+}
+
+// Returns the semantic changes of a single project between the given revision and the working copy
+// fromRev is the revision to compare with the working copy. If empty, the project is considered as a new project
+// projectParam is the project to get the changes of
+func getProjectDiff(fromRev string, projectParam *params.ProjectParams) (*ProjectDiff, error) {
+	projectDiff := &ProjectDiff{
+		Type:         projectParam.Type,
+		NickName:     projectParam.NickName,
+		RelativePath: projectParam.RelativePath,
+		Deleted:      projectParam.Deleted,
+	}
+	if projectParam.Deleted {
+		return projectDiff, nil
+	}
+
+	var definitionFileName string
+	switch projectParam.Type {
+	case utils.ProjectTypeApi:
+		definitionFileName = "api"
+	case utils.ProjectTypeApiProduct:
+		definitionFileName = "api_product"
+	case utils.ProjectTypeApplication:
+		definitionFileName = "application"
+	case utils.ProjectTypeMICApp:
+		// the CApps are binaries, hence only the changes of the meta file are shown
+		definitionFileName = strings.TrimSuffix(utils.MetaFileMICApp, ".yaml")
+	}
+	projectPath := filepath.ToSlash(projectParam.RelativePath)
+
+	oldDefinition, err := readYamlOrJSONAtRevision(fromRev, path.Join(projectPath, definitionFileName))
+	if err != nil {
+		return nil, err
+	}
+	newDefinition, err := readYamlOrJSONInWorkingCopy(filepath.Join(projectParam.AbsolutePath, definitionFileName))
+	if err != nil {
+		return nil, err
+	}
+	// Every field of a project which did not exist in the previous revision is a new field, hence only the
+	//  project is marked as new
+	if oldDefinition == nil {
+		projectDiff.New = true
+	} else {
+		projectDiff.FieldChanges = diffProjectDefinitions(oldDefinition, newDefinition)
+	}
+
+	if projectParam.Type == utils.ProjectTypeApi || projectParam.Type == utils.ProjectTypeApiProduct {
+		oldSwagger, err := readYamlOrJSONAtRevision(fromRev, path.Join(projectPath, "Definitions", "swagger"))
+		if err != nil {
+			return nil, err
+		}
+		newSwagger, err := readYamlOrJSONInWorkingCopy(filepath.Join(projectParam.AbsolutePath, "Definitions",
+			"swagger"))
+		if err != nil {
+			return nil, err
+		}
+		projectDiff.DefinitionChanges = diffSwaggerResources(oldSwagger, newSwagger)
+	}
+	return projectDiff, nil
+}
+
+// Reads a YAML or JSON file at the given revision and returns the content as a generic JSON structure. The file name
+//  should be given without the extension, and the YAML file is looked up first. If the file does not exist in the
+//  revision, nil will be returned.
+// revision is the git revision to read the file from. If empty, nil will be returned
+// fileName is the path of the file relative to the repository root without the extension
+func readYamlOrJSONAtRevision(revision, fileName string) (map[string]interface{}, error) {
+	if revision == "" {
+		return nil, nil
+	}
+	for _, extension := range []string{".yaml", ".json"} {
+		content, err := getFileContentAtRevision(revision, fileName+extension)
+		if err == object.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalYamlOrJSON([]byte(content))
+	}
+	return nil, nil
+}
+
+// Reads a YAML or JSON file of the working copy and returns the content as a generic JSON structure. The file name
+//  should be given without the extension, and the YAML file is looked up first. If the file does not exist, nil will
+//  be returned.
+// fileName is the absolute path of the file without the extension
+func readYamlOrJSONInWorkingCopy(fileName string) (map[string]interface{}, error) {
+	for _, extension := range []string{".yaml", ".json"} {
+		content, err := ioutil.ReadFile(fileName + extension)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalYamlOrJSON(content)
+	}
+	return nil, nil
+}
+
+// Returns the content of a file at the given revision
+// revision is the git revision to read the file from
+// fileName is the path of the file relative to the repository root
+func getFileContentAtRevision(revision, fileName string) (string, error) {
+	return readFileAtRevision(revision, fileName)
+}
+
+// Converts the content of a YAML or JSON file into a generic JSON structure
+func unmarshalYamlOrJSON(content []byte) (map[string]interface{}, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(jsonContent, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Compares the "data" section of two project definition files (api.yaml, api_product.yaml or application.yaml) and
+//  returns the changed fields
+func diffProjectDefinitions(oldDefinition, newDefinition map[string]interface{}) []*FieldChange {
+	var oldData, newData map[string]interface{}
+	if oldDefinition != nil {
+		oldData, _ = oldDefinition["data"].(map[string]interface{})
+	}
+	if newDefinition != nil {
+		newData, _ = newDefinition["data"].(map[string]interface{})
+	}
+
+	var fieldChanges []*FieldChange
+	for _, key := range sortedUnionOfKeys(oldData, newData) {
+		if diffIgnoredFields[key] {
+			continue
+		}
+		category, found := diffFieldCategories[key]
+		if !found {
+			category = ChangeCategoryOther
+		}
+		oldValue, hasOld := oldData[key]
+		newValue, hasNew := newData[key]
+		fieldChanges = append(fieldChanges, diffValues(category, key, oldValue, hasOld, newValue, hasNew)...)
+	}
+	return fieldChanges
+}
+
+// Recursively compares two values of a field and returns the changes
+// category is the category of the top level field the value belongs to
+// field is the path of the field (ex: endpointConfig.production_endpoints.url)
+func diffValues(category, field string, oldValue interface{}, hasOld bool, newValue interface{},
+	hasNew bool) []*FieldChange {
+	if !hasOld && !hasNew {
+		return nil
+	}
+	if !hasOld {
+		return []*FieldChange{{Category: category, Field: field, Change: ChangeAdded, NewValue: newValue}}
+	}
+	if !hasNew {
+		return []*FieldChange{{Category: category, Field: field, Change: ChangeRemoved, OldValue: oldValue}}
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		var fieldChanges []*FieldChange
+		for _, key := range sortedUnionOfKeys(oldMap, newMap) {
+			if diffIgnoredFields[key] {
+				continue
+			}
+			oldChild, hasOldChild := oldMap[key]
+			newChild, hasNewChild := newMap[key]
+			fieldChanges = append(fieldChanges, diffValues(category, field+"."+key, oldChild, hasOldChild,
+				newChild, hasNewChild)...)
+		}
+		return fieldChanges
+	}
+
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList {
+		return diffLists(category, field, oldList, newList)
+	}
+
+	return []*FieldChange{{Category: category, Field: field, Change: ChangeModified, OldValue: oldValue,
+		NewValue: newValue}}
+}
+
+// Compares two lists of a field. The items are matched with their identity (ex: verb and target of an operation)
+//  instead of the position, so reordering the items is not considered as a change.
+func diffLists(category, field string, oldList, newList []interface{}) []*FieldChange {
+	oldItems := make(map[string]interface{})
+	newItems := make(map[string]interface{})
+	for _, item := range oldList {
+		oldItems[getListItemKey(item)] = item
+	}
+	for _, item := range newList {
+		newItems[getListItemKey(item)] = item
+	}
+
+	var fieldChanges []*FieldChange
+	for _, key := range sortedUnionOfKeys(oldItems, newItems) {
+		oldItem, hasOldItem := oldItems[key]
+		newItem, hasNewItem := newItems[key]
+		fieldChanges = append(fieldChanges, diffValues(category, field+"["+key+"]", oldItem, hasOldItem,
+			newItem, hasNewItem)...)
+	}
+	return fieldChanges
+}
+
+// Returns the identity of an item of a list. Operations are identified by the verb and target, scopes, member APIs
+//  and the other named items by the name. Any other item is identified by its content.
+func getListItemKey(item interface{}) string {
+	if itemMap, isMap := item.(map[string]interface{}); isMap {
+		if verb, hasVerb := itemMap["verb"]; hasVerb {
+			return fmt.Sprint(verb) + " " + fmt.Sprint(itemMap["target"])
+		}
+		if scope, hasScope := itemMap["scope"].(map[string]interface{}); hasScope {
+			return fmt.Sprint(scope["name"])
+		}
+		if name, hasName := itemMap["name"]; hasName {
+			if version, hasVersion := itemMap["version"]; hasVersion {
+				return fmt.Sprint(name) + "-" + fmt.Sprint(version)
+			}
+			return fmt.Sprint(name)
+		}
+	}
+	content, _ := json.Marshal(item)
+	return string(content)
+}
+
+// Compares the resources (path and verb) of two swagger definitions and returns the added, removed and modified
+//  resources
+func diffSwaggerResources(oldSwagger, newSwagger map[string]interface{}) []*DefinitionChange {
+	var oldPaths, newPaths map[string]interface{}
+	if oldSwagger != nil {
+		oldPaths, _ = oldSwagger["paths"].(map[string]interface{})
+	}
+	if newSwagger != nil {
+		newPaths, _ = newSwagger["paths"].(map[string]interface{})
+	}
+
+	var definitionChanges []*DefinitionChange
+	for _, resourcePath := range sortedUnionOfKeys(oldPaths, newPaths) {
+		oldResource, _ := oldPaths[resourcePath].(map[string]interface{})
+		newResource, _ := newPaths[resourcePath].(map[string]interface{})
+		for _, verb := range swaggerVerbs {
+			oldOperation, hasOld := oldResource[verb]
+			newOperation, hasNew := newResource[verb]
+			var change string
+			if hasOld && !hasNew {
+				change = ChangeRemoved
+			} else if !hasOld && hasNew {
+				change = ChangeAdded
+			} else if hasOld && hasNew && !reflect.DeepEqual(oldOperation, newOperation) {
+				change = ChangeModified
+			}
+			if change != "" {
+				definitionChanges = append(definitionChanges, &DefinitionChange{
+					Path:   resourcePath,
+					Verb:   strings.ToUpper(verb),
+					Change: change,
+				})
+			}
+		}
+	}
+	return definitionChanges
+}
+
+// Returns the sorted union of the keys of two maps
+func sortedUnionOfKeys(first, second map[string]interface{}) []string {
+	keySet := make(map[string]bool)
+	for key := range first {
+		keySet[key] = true
+	}
+	for key := range second {
+		keySet[key] = true
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func loadYamlForDiff(t *testing.T, content string) map[string]interface{} {
+	jsonContent, err := utils.YamlToJson([]byte(content))
+	assert.Nil(t, err, "err should be nil")
+	var result map[string]interface{}
+	assert.Nil(t, json.Unmarshal(jsonContent, &result), "err should be nil")
+	return result
+}
+
+func TestDiffProjectDefinitions(t *testing.T) {
+	oldDefinition := loadYamlForDiff(t, `
+data:
+  id: 1
+  lifeCycleStatus: CREATED
+  policies: [Gold]
+  endpointConfig:
+    production_endpoints:
+      url: https://old.example.com
+  operations:
+    - target: /menu
+      verb: GET
+      throttlingPolicy: Unlimited
+    - target: /order
+      verb: POST
+      throttlingPolicy: Unlimited
+`)
+	newDefinition := loadYamlForDiff(t, `
+data:
+  id: 2
+  lifeCycleStatus: PUBLISHED
+  policies: [Gold]
+  endpointConfig:
+    production_endpoints:
+      url: https://new.example.com
+  operations:
+    - target: /order
+      verb: POST
+      throttlingPolicy: 10KPerMin
+    - target: /menu
+      verb: GET
+      throttlingPolicy: Unlimited
+    - target: /order
+      verb: DELETE
+`)
+	changes := diffProjectDefinitions(oldDefinition, newDefinition)
+	assert.Equal(t, 4, len(changes), "should ignore the id and the order of the operations")
+
+	assert.Equal(t, ChangeCategoryEndpoint, changes[0].Category)
+	assert.Equal(t, "endpointConfig.production_endpoints.url", changes[0].Field)
+	assert.Equal(t, ChangeModified, changes[0].Change)
+
+	assert.Equal(t, ChangeCategoryLifecycle, changes[1].Category)
+	assert.Equal(t, "PUBLISHED", changes[1].NewValue)
+
+	assert.Equal(t, ChangeCategoryOperation, changes[2].Category)
+	assert.Equal(t, "operations[DELETE /order]", changes[2].Field)
+	assert.Equal(t, ChangeAdded, changes[2].Change)
+
+	assert.Equal(t, "operations[POST /order].throttlingPolicy", changes[3].Field)
+	assert.Equal(t, "10KPerMin", changes[3].NewValue)
+}
+
+func TestDiffProjectDefinitionsWithoutPreviousRevision(t *testing.T) {
+	newDefinition := loadYamlForDiff(t, `
+data:
+  name: PizzaShackAPI
+  scopes: []
+`)
+	changes := diffProjectDefinitions(nil, newDefinition)
+	assert.Equal(t, 2, len(changes))
+	for _, change := range changes {
+		assert.Equal(t, ChangeAdded, change.Change)
+	}
+}
+
+func TestDiffSwaggerResources(t *testing.T) {
+	oldSwagger := loadYamlForDiff(t, `
+paths:
+  /menu:
+    get:
+      summary: menu
+  /order:
+    post:
+      summary: order
+    put:
+      summary: update
+`)
+	newSwagger := loadYamlForDiff(t, `
+paths:
+  /menu:
+    get:
+      summary: list the menu
+  /order:
+    post:
+      summary: order
+  /order/{orderId}:
+    delete:
+      summary: delete
+`)
+	changes := diffSwaggerResources(oldSwagger, newSwagger)
+	assert.Equal(t, []*DefinitionChange{
+		{Path: "/menu", Verb: "GET", Change: ChangeModified},
+		{Path: "/order", Verb: "PUT", Change: ChangeRemoved},
+		{Path: "/order/{orderId}", Verb: "DELETE", Change: ChangeAdded},
+	}, changes)
+}
+
+func TestGetProjectDiffComparesWithWorkingCopy(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-diff")
+	defer os.RemoveAll(tmpDir)
+	repoDir := filepath.Join(tmpDir, "repo")
+	initStateTestRepo(t, repoDir)
+
+	firstCommit := commitTestFiles(t, map[string]string{
+		"PizzaShack/api.yaml":                 "data:\n  lifeCycleStatus: CREATED\n",
+		"PizzaShack/Definitions/swagger.yaml": "paths:\n  /menu:\n    get: {}\n",
+	}, "first")
+	// the uncommitted changes are deployed, hence those are compared as well
+	assert.Nil(t, ioutil.WriteFile(filepath.Join("PizzaShack", "api.yaml"),
+		[]byte("data:\n  lifeCycleStatus: PUBLISHED\n"), 0644), "err should be nil")
+	projectParam := &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PizzaShack",
+		RelativePath: "PizzaShack", AbsolutePath: filepath.Join(repoDir, "PizzaShack")}
+
+	projectDiff, err := getProjectDiff(firstCommit, projectParam)
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, projectDiff.New, "the project should not be new")
+	assert.Equal(t, 1, len(projectDiff.FieldChanges))
+	assert.Equal(t, "PUBLISHED", projectDiff.FieldChanges[0].NewValue)
+	assert.Empty(t, projectDiff.DefinitionChanges, "the swagger should not be changed")
+
+	// errors other than a missing file are not treated as a missing project
+	_, err = getProjectDiff("0000000000000000000000000000000000000000", projectParam)
+	assert.NotNil(t, err, "err should not be nil")
+}
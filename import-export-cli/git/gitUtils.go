@@ -29,9 +29,11 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	miImpl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"gopkg.in/yaml.v2"
@@ -51,43 +53,87 @@ func getVCSConfigFromFileSilently(filePath string) *VCSConfig {
 	return &vcsConfig
 }
 
-// Reads and returns the environment specific information from the VCS config along with the full VCS config
+// Reads and returns the environment specific information (deployment state) from the configured state backend
 // repoId is the id of the git repository (located in vcs.yaml)
 // environment is the name of the environment
-// Returns VCSConfig, the full VCS configuration
 // Returns Environment, the environment specific VCS configuration
 // Returns bool, whether the environment is available in the VCS configuration or not
-func getVCSEnvironmentDetails(repoId, environment string) (VCSConfig, Environment, bool) {
-	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
-	if mainConfig.Config.VCSConfigFilePath != "" {
-		VCSConfigFilePath = mainConfig.Config.VCSConfigFilePath
+func getVCSEnvironmentDetails(repoId, environment string, scopeParams ScopeParams) (Environment, bool) {
+	envVCSConfig, hasEnv, err := getStateBackend().Load(getStateKey(repoId, environment, scopeParams), environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the deployment state of "+environment, err)
+	}
+	for _, legacyStateKey := range getLegacyStateKeys(repoId, environment, scopeParams) {
+		if hasEnv {
+			break
+		}
+		// The state of the scope was kept against an id derived from the paths (and the globs) earlier. It is
+		//  migrated to the current key when the state is saved next.
+		envVCSConfig, hasEnv, err = getStateBackend().Load(legacyStateKey, environment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while reading the deployment state of "+environment, err)
+		}
+		if hasEnv {
+			utils.Logln(utils.LogPrefixInfo + "Migrating the deployment state of " + environment + " from " +
+				legacyStateKey)
+		}
+	}
+	if hasEnv {
+		basePath, err := getRepoBaseDir()
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting repository base folder location", err)
+		}
+		resolvePersistedProjects(envVCSConfig.FailedProjects, basePath)
+		resolvePersistedProjects(envVCSConfig.BlockedProjects, basePath)
+	}
+	return envVCSConfig, hasEnv
+}
+
+// Returns copies of the given projects to keep in the deployment state. Only the path of a project relative to the
+//  repository is kept (using forward slashes), as the repository may be located elsewhere when the state is loaded.
+// projectsPerType is the map of project type -> projects
+func getProjectsToPersist(projectsPerType map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+	if projectsPerType == nil {
+		return nil
+	}
+	projectsToPersist := make(map[string][]*params.ProjectParams)
+	for projectType, projects := range projectsPerType {
+		for _, projectParam := range projects {
+			projectToPersist := *projectParam
+			projectToPersist.AbsolutePath = ""
+			projectToPersist.RelativePath = filepath.ToSlash(projectParam.RelativePath)
+			projectsToPersist[projectType] = append(projectsToPersist[projectType], &projectToPersist)
+		}
 	}
-	vcsConfig := getVCSConfigFromFileSilently(VCSConfigFilePath)
-	if vcsConfig.Repos == nil {
-		vcsConfig.Repos = make(map[string]Repo)
+	return projectsToPersist
+}
+
+// Resolves the absolute paths of the projects loaded from the deployment state against the current location of the
+//  repository
+// projectsPerType is the map of project type -> projects loaded from the deployment state
+// repoBasePath is the basepath of the git repository
+func resolvePersistedProjects(projectsPerType map[string][]*params.ProjectParams, repoBasePath string) {
+	for _, projects := range projectsPerType {
+		for _, projectParam := range projects {
+			projectParam.RelativePath = filepath.FromSlash(projectParam.RelativePath)
+			projectParam.AbsolutePath = filepath.Join(repoBasePath, projectParam.RelativePath)
+		}
 	}
-	envVCSConfig, hasEnv := vcsConfig.Repos[repoId].Environments[environment]
-	return *vcsConfig, envVCSConfig, hasEnv
 }
 
 // Returns the status of the projects indicating the projects to deploy (need to save, delete or failed previously).
 // Environment is the environment name
 // fromRevType is the type of the revision the status should be taken by comparing with the current revision. The allowed values are "last_attempted", "last_successful"
+// scopeParams is the part of the repositories which is deployed
 // Returns string, id of the git repository (located in vcs.yaml)
 // Returns int, the total number of projects to deploy
 // Returns map[string][]*params.ProjectParams, the details of the projects that needs to deploy
-func GetStatus(environment, fromRevType string) (string, int, map[string][]*params.ProjectParams) {
+func GetStatus(environment, fromRevType string, scopeParams ScopeParams) (string, int,
+	map[string][]*params.ProjectParams) {
 	var envRevision string
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
-	repoId, err := getRepoId()
-	if err != nil {
-		utils.HandleErrorAndExit("Error while retrieving repository id", err)
-	}
-	if repoId == "" {
-		utils.HandleErrorAndExit("The repository info: vcs.yaml is not found in the repository root. "+
-			"If this is the first time you are using this repo, please initialize it with 'vcs init'.", nil)
-	}
-	_, envVCSConfig, hasEnv := getVCSEnvironmentDetails(repoId, environment)
+	repoId := getRepoIdOrExit()
+	envVCSConfig, hasEnv := getVCSEnvironmentDetails(repoId, environment, scopeParams)
 	if hasEnv {
 		if fromRevType == FromRevTypeLastAttempted {
 			envRevision = envVCSConfig.LastAttemptedRev
@@ -105,35 +151,91 @@ func GetStatus(environment, fromRevType string) (string, int, map[string][]*para
 		utils.HandleErrorAndExit("Error while getting repository base folder location", err)
 	}
 
-	var changedFiles string
+	var changedFileList []string
 	if envRevision == "" {
-		changedFiles, _ = executeGitCommand("ls-tree", "-r", "HEAD", "--name-only", "--full-tree")
-	} else if mainConfig.Config.VCSDeletionEnabled {
-		changedFiles, _ = executeGitCommand("diff", "--name-only", envRevision)
+		changedFileList, err = listFilesAtRevision("HEAD")
 	} else {
-		changedFiles, _ = executeGitCommand("diff", "--diff-filter=d", "--name-only", envRevision)
+		changedFileList, err = getChangedFilesSince(envRevision, mainConfig.Config.VCSDeletionEnabled)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the changed files of the repository", err)
 	}
 
-	//remove slashes (/) unix-format path separators with OS specific path separator
-	changedFiles = strings.ReplaceAll(changedFiles, "/", string(filepath.Separator))
-
-	changedFileList := strings.Split(changedFiles, "\n")
-	// remove the last empty element
-	if len(changedFileList) > 0 {
-		changedFileList = changedFileList[:len(changedFileList)-1]
+	//replace slashes (/) unix-format path separators with OS specific path separator
+	for i, changedFile := range changedFileList {
+		changedFileList[i] = filepath.FromSlash(changedFile)
 	}
 
 	if utils.VerboseModeEnabled() {
 		logChangedFiles(changedFileList)
 	}
 
+	scope := getRepoScope(mainConfig, environment, basePath, scopeParams)
+	totalProjectsToUpdate, updatedProjectsPerType, updatedProjectsPerProjectPath :=
+		getProjectsOfChangedFiles(envVCSConfig, scope, basePath, changedFileList)
+
+	// The projects brought into the scope by editing the globs or the paths of the scope are deployed even if those
+	//  were not changed
+	previousScope := scope.withFilters(envVCSConfig.Include, envVCSConfig.Exclude, envVCSConfig.Paths)
+	if envRevision != "" && scope.projectDirs == nil && !scope.hasSameFilters(previousScope) {
+		totalProjectsToUpdate += addProjectsNewInScope(envVCSConfig, scope, previousScope, basePath,
+			updatedProjectsPerType, updatedProjectsPerProjectPath)
+	}
+
+	//append failed projects to the updated project list if exists
+	for _, failedProjectsInEachType := range envVCSConfig.FailedProjects {
+		for _, failedProjectInEachType := range failedProjectsInEachType {
+			if updatedProjectsPerProjectPath[failedProjectInEachType.AbsolutePath] == nil {
+				updatedProjectsPerProjectPath[failedProjectInEachType.AbsolutePath] = failedProjectInEachType
+				updatedProjectsPerType[failedProjectInEachType.Type] =
+					append(updatedProjectsPerType[failedProjectInEachType.Type], failedProjectInEachType)
+				failedProjectInEachType.FailedDuringPreviousDeploy = true
+				totalProjectsToUpdate++
+			}
+		}
+	}
+
+	//append blocked projects to the updated project list if exists, as those were not deployed
+	for _, blockedProjectsInEachType := range envVCSConfig.BlockedProjects {
+		for _, blockedProjectInEachType := range blockedProjectsInEachType {
+			if updatedProjectsPerProjectPath[blockedProjectInEachType.AbsolutePath] == nil {
+				updatedProjectsPerProjectPath[blockedProjectInEachType.AbsolutePath] = blockedProjectInEachType
+				updatedProjectsPerType[blockedProjectInEachType.Type] =
+					append(updatedProjectsPerType[blockedProjectInEachType.Type], blockedProjectInEachType)
+				blockedProjectInEachType.Blocked = true
+				totalProjectsToUpdate++
+			}
+		}
+	}
+
+	// API Products are deployed after the APIs, so the member APIs maintained in the same repository are tracked to
+	//  avoid deploying an API Product when any of those were failed
+	resolveApiProductDependencies(basePath, "HEAD", updatedProjectsPerType)
+
+	return repoId, totalProjectsToUpdate, updatedProjectsPerType
+}
+
+// Identifies the projects which the given changed files belong to
+// envVCSConfig is the environment specific VCS configuration used to mark the projects failed previously
+// scope is the scope of the repository which the projects outside are ignored
+// repoBasePath is the basepath of the git repository
+// changedFileList is the list of changed files relative to the repository base path
+// Returns int, the total number of projects identified
+// Returns map[string][]*params.ProjectParams, a map of project type -> projects
+// Returns map[string]*params.ProjectParams, a map of absolute path of the project -> project
+func getProjectsOfChangedFiles(envVCSConfig Environment, scope deploymentScope, repoBasePath string,
+	changedFileList []string) (int, map[string][]*params.ProjectParams, map[string]*params.ProjectParams) {
 	changedPathInfoMap := make(map[string]*params.ProjectParams)
 	updatedProjectsPerType := make(map[string][]*params.ProjectParams)
 	updatedProjectsPerProjectPath := make(map[string]*params.ProjectParams)
 
 	var totalProjectsToUpdate = 0
 	for _, changedFile := range changedFileList {
-		projectParam := getProjectInfoFromProjectFile(envVCSConfig, basePath, changedFile, changedPathInfoMap)
+		projectParam := getProjectInfoFromProjectFile(envVCSConfig, repoBasePath, changedFile, changedPathInfoMap)
+		if projectParam.Type != utils.ProjectTypeNone && !scope.contains(projectParam.RelativePath) {
+			utils.Logln(utils.LogPrefixInfo + "Skipping " + projectParam.RelativePath + " as it is out of the scope")
+			continue
+		}
 		if projectParam.Type != utils.ProjectTypeNone {
 			if updatedProjectsPerType[projectParam.Type] == nil {
 				updatedProjectsPerType[projectParam.Type] = []*params.ProjectParams{}
@@ -145,30 +247,62 @@ func GetStatus(environment, fromRevType string) (string, int, map[string][]*para
 			}
 		}
 	}
+	return totalProjectsToUpdate, updatedProjectsPerType, updatedProjectsPerProjectPath
+}
 
-	//append failed projects to the updated project list if exists
-	for _, failedProjectsInEachType := range envVCSConfig.FailedProjects {
-		for _, failedProjectInEachType := range failedProjectsInEachType {
-			if updatedProjectsPerProjectPath[failedProjectInEachType.AbsolutePath] == nil {
-				updatedProjectsPerProjectPath[failedProjectInEachType.AbsolutePath] = failedProjectInEachType
-				updatedProjectsPerType[failedProjectInEachType.Type] =
-					append(updatedProjectsPerType[failedProjectInEachType.Type], failedProjectInEachType)
-				failedProjectInEachType.FailedDuringPreviousDeploy = true
-				totalProjectsToUpdate++
+// Identifies the projects of the current revision which are in the scope but were out of the previous scope, and adds
+//  those to the given maps
+// envVCSConfig is the environment specific VCS configuration used to mark the projects failed previously
+// scope is the current scope of the repository
+// previousScope is the scope of the repository when the environment was deployed last
+// repoBasePath is the basepath of the git repository
+// Returns int, the number of projects added
+func addProjectsNewInScope(envVCSConfig Environment, scope, previousScope deploymentScope, repoBasePath string,
+	updatedProjectsPerType map[string][]*params.ProjectParams,
+	updatedProjectsPerProjectPath map[string]*params.ProjectParams) int {
+	fileList, err := listFilesAtRevision("HEAD")
+	if err != nil {
+		utils.HandleErrorAndExit("Error while listing the files of the repository", err)
+	}
+	for i, file := range fileList {
+		fileList[i] = filepath.FromSlash(file)
+	}
+
+	var addedProjects int
+	_, projectsPerType, _ := getProjectsOfChangedFiles(envVCSConfig, scope, repoBasePath, fileList)
+	for projectType, projects := range projectsPerType {
+		for _, projectParam := range projects {
+			if previousScope.contains(projectParam.RelativePath) ||
+				updatedProjectsPerProjectPath[projectParam.AbsolutePath] != nil {
+				continue
 			}
+			utils.Logln(utils.LogPrefixInfo + "Adding " + projectParam.RelativePath + " as it is new in the scope")
+			updatedProjectsPerProjectPath[projectParam.AbsolutePath] = projectParam
+			updatedProjectsPerType[projectType] = append(updatedProjectsPerType[projectType], projectParam)
+			addedProjects++
 		}
 	}
-
-	return repoId, totalProjectsToUpdate, updatedProjectsPerType
+	return addedProjects
 }
 
 // Returns whether the given project was failed to deploy previously
 // environment is the environment name
 // Returns bool indicating whether the given project was failed previously
 func failedDuringEarlierDeploy(vcsEnvConfig Environment, projectParams *params.ProjectParams) bool {
-	failedProjectsForType := vcsEnvConfig.FailedProjects[projectParams.Type]
-	for _, failedProject := range failedProjectsForType {
-		if failedProject.RelativePath == projectParams.RelativePath {
+	return containsProject(vcsEnvConfig.FailedProjects, projectParams)
+}
+
+// Returns whether the given project was blocked by its failed dependencies during the previous deployment
+// environment is the environment name
+// Returns bool indicating whether the given project was blocked previously
+func blockedDuringEarlierDeploy(vcsEnvConfig Environment, projectParams *params.ProjectParams) bool {
+	return containsProject(vcsEnvConfig.BlockedProjects, projectParams)
+}
+
+// Returns whether the given project is in the map of project type -> projects
+func containsProject(projectsPerType map[string][]*params.ProjectParams, projectParams *params.ProjectParams) bool {
+	for _, project := range projectsPerType[projectParams.Type] {
+		if project.RelativePath == projectParams.RelativePath {
 			return true
 		}
 	}
@@ -178,24 +312,28 @@ func failedDuringEarlierDeploy(vcsEnvConfig Environment, projectParams *params.P
 // Rollbacks the projects to the initial state when any of the projects were failed during deployment
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func Rollback(accessToken, environment string) error {
+// scopeParams is the part of the repositories which is deployed
+// parallel is the maximum number of projects of the same type that are deployed at the same time
+func Rollback(accessToken, environment string, scopeParams ScopeParams, parallel int) error {
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
 	changeDirectoryToSourceRepo(mainConfig)
 
 	// Get the status of the source repo
-	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastSuccessful)
-	_, envVCSConfigSourceRepo, hasEnvSourceRepo := getVCSEnvironmentDetails(sourceRepoId, environment)
+	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastSuccessful, scopeParams)
+	envVCSConfigSourceRepo, hasEnvSourceRepo := getVCSEnvironmentDetails(sourceRepoId, environment, scopeParams)
 
-	var deploymentRepoId, currentBranchDeploymentRepo, tmpBranchNameDeploymentRepo string
+	var deploymentRepoId string
 	var envVCSConfigDeploymentRepo Environment
 	var hasEnvDeploymentRepo bool
 	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
 	if mainConfig.Config.VCSDeploymentRepoPath != "" {
 		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
 		// Get the status of the deployment repo
-		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted)
-		_, envVCSConfigDeploymentRepo, hasEnvDeploymentRepo = getVCSEnvironmentDetails(deploymentRepoId, environment)
+		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted,
+			scopeParams)
+		envVCSConfigDeploymentRepo, hasEnvDeploymentRepo = getVCSEnvironmentDetails(deploymentRepoId, environment,
+			scopeParams)
 	}
 
 	if mainConfig.Config.VCSDeploymentRepoPath != "" {
@@ -226,103 +364,66 @@ func Rollback(accessToken, environment string) error {
 		lastSuccessfulRevisionDeploymentRepo = envVCSConfigDeploymentRepo.LastSuccessfulRev[0]
 	}
 
-	// Change directory to source repo and checkout to a new branch from the revision
-	changeDirectoryToSourceRepo(mainConfig)
-	currentBranchSourceRepo := getCurrentBranch()
-	tmpBranchNameSourceRepo := "tmp-" + lastSuccessfulRevisionSourceRepo[0:8]
-	checkoutNewBranchFromRevision(tmpBranchNameSourceRepo, lastSuccessfulRevisionSourceRepo)
-
-	// Change directory to deployment repo and checkout to a new branch from the revision
-	if mainConfig.Config.VCSDeploymentRepoPath != "" {
-		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
-		currentBranchDeploymentRepo = getCurrentBranch()
-		tmpBranchNameDeploymentRepo = "tmp-" + lastSuccessfulRevisionDeploymentRepo[0:8]
-		checkoutNewBranchFromRevision(tmpBranchNameDeploymentRepo, lastSuccessfulRevisionDeploymentRepo)
-
-	}
-
-	// Again change directory to the source repo and deploy the updated projects
-	changeDirectoryToSourceRepo(mainConfig)
-	deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
-
-	// Again change directory to the source repo (because inside deployUpdatedProjects the directory must have changed to the deployment)
-	changeDirectoryToSourceRepo(mainConfig)
-	// Checkout to the current branch and delete the tmp branch in source repo
-	checkoutBranch(currentBranchSourceRepo)
-	deleteTmpBranch(tmpBranchNameSourceRepo)
-
-	// Chanage directory to the deployment repo
-	if mainConfig.Config.VCSDeploymentRepoPath != "" {
-		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
-		// Checkout to the current branch and delete the tmp branch in deployment repo
-		checkoutBranch(currentBranchDeploymentRepo)
-		deleteTmpBranch(tmpBranchNameDeploymentRepo)
-
-	}
-
-	return nil
-}
-
-// Creates a new branch from the given revision
-// tmpBranchName is the new branch that is checkout from the revision
-// revision is the git commit id
-func checkoutNewBranchFromRevision(tmpBranchName, revision string) {
-	_, err := executeGitCommand("checkout", "-b", tmpBranchName, revision)
-	if err != nil {
-		utils.HandleErrorAndExit("Error while checking out last successful commit ("+
-			revision+") for rolling back", err)
-	}
-}
-
-// Switches to the given branch name
-// branchName is the name of the branch that should switch into
-func checkoutBranch(branchName string) {
-	_, err := executeGitCommand("checkout", branchName)
-	if err != nil {
-		utils.HandleErrorAndExit("Error while checking out branch "+branchName, err)
-	}
-}
-
-// Returns the name of the current branch of the repository where the user is executing apictl commands
-func getCurrentBranch() string {
-	branch, err := executeGitCommand("rev-parse", "--abbrev-ref", "HEAD")
-	if err != nil {
-		utils.HandleErrorAndExit("Error while getting current branch", err)
-	}
-	return strings.TrimSpace(branch)
-}
-
-// Deletes the given branch. The branch name must start with "tmp-"
-func deleteTmpBranch(tmpBranch string) {
-	//done as a security check
-	if !strings.HasPrefix(tmpBranch, "tmp-") {
-		utils.HandleErrorAndExit("Cannot remove branches not starting with 'tmp-'", nil)
-	}
-	_, err := executeGitCommand("branch", "-D", tmpBranch)
+	// Extract the last successful revisions of the source and the deployment repos, and deploy the updated projects
+	//  from those without checking out the revisions in the repos
+	workspace, err := newRevisionWorkspace(mainConfig, lastSuccessfulRevisionSourceRepo,
+		lastSuccessfulRevisionDeploymentRepo)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while deleting the temp branch "+tmpBranch, err)
+		return err
 	}
+	defer workspace.remove()
+	_, _, _, _, err = deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, scopeParams,
+		workspace, totalProjectsToUpdate, updatedProjectsPerType, parallel)
+	return err
 }
 
 // Deletes the projects from the environment that are identified as deleted.
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
+// workspace is the workspace which the definitions of the deleted projects are read from
 // deletedProjectsPerType A map that has keys as Apps/APIs or API Products and values as deleted projects of each type
 // This will return the failed projects with the same structure at the end if such projects exist during deletion.
-func deployProjectDeletions(accessToken, environment string, deletedProjectsPerType map[string][]*params.ProjectParams,
-	failedProjects map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+func deployProjectDeletions(accessToken, environment string, workspace *revisionWorkspace,
+	deletedProjectsPerType, failedProjects map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+
+	// Deleting MI CApp projects
+	miCAppProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeMICApp]
+	if len(miCAppProjectsToDelete) != 0 {
+		fmt.Println("\nMI CApps (" + strconv.Itoa(len(miCAppProjectsToDelete)) + ") ...")
+		for i, projectParam := range miCAppProjectsToDelete {
+			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			startTime := time.Now()
+			cAppName, err := getCAppName(workspace.mapPath(projectParam.AbsolutePath))
+			if handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
+				continue
+			}
+			err = miImpl.UndeployCompositeApp(environment, cAppName)
+			recordProjectResult(projectParam, startTime, err)
+			handleIfError(err, failedProjects, projectParam)
+		}
+	}
+
 	// Deleting Application projects
 	applicationProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjectsToDelete) != 0 {
 		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjectsToDelete)) + ") ...")
 		for i, projectParam := range applicationProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
+			startTime := time.Now()
+			if err := validateAPIMExistsInEnv(mainConfig, environment); handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
+				continue
+			}
+			appInfo, _, err := impl.GetApplicationDefinition(workspace.mapPath(projectParam.AbsolutePath))
 			if handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
 				continue
 			}
 			resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Data.Applicationinfo.Name,
 				appInfo.Data.Applicationinfo.Owner)
+			recordProjectResult(projectParam, startTime, err)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
 			}
@@ -336,11 +437,18 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjectsToDelete)) + ") ...")
 		for i, projectParam := range apiProductProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
+			startTime := time.Now()
+			if err := validateAPIMExistsInEnv(mainConfig, environment); handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
+				continue
+			}
+			apiProductInfo, _, err := impl.GetAPIProductDefinition(workspace.mapPath(projectParam.AbsolutePath))
 			if handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
 				continue
 			}
 			resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.Data.Name, apiProductInfo.Data.Provider)
+			recordProjectResult(projectParam, startTime, err)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
 			}
@@ -352,13 +460,29 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 	apiProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApi]
 	if len(apiProjectsToDelete) != 0 {
 		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjectsToDelete)) + ") ...")
+		defaultMicrogatewayTargets := getDefaultMicrogatewayTargets(environment)
 		for i, projectParam := range apiProjectsToDelete {
 			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
+			startTime := time.Now()
+			if err := validateAPIMExistsInEnv(mainConfig, environment); handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
+				continue
+			}
+			apiInfo, _, err := impl.GetAPIDefinition(workspace.mapPath(projectParam.AbsolutePath))
 			if handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
+				continue
+			}
+			// the Microgateway targets are read from the api_meta.yaml of the project as it was deployed
+			metaData, _ := LoadMetaDataFile(filepath.Join(workspace.mapPath(projectParam.AbsolutePath), utils.MetaFileAPI))
+			err = undeployApiFromMicrogateways(getMicrogatewayTargets(defaultMicrogatewayTargets, environment, metaData),
+				apiInfo.Data.Name, apiInfo.Data.Version)
+			if handleIfError(err, failedProjects, projectParam) {
+				recordProjectResult(projectParam, startTime, err)
 				continue
 			}
 			resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.Data.Name, apiInfo.Data.Version, apiInfo.Data.Provider)
+			recordProjectResult(projectParam, startTime, err)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
 			}
@@ -369,6 +493,19 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 	return failedProjects
 }
 
+// Returns an error if the environment does not have an API Manager to deploy the API, API Product and Application
+//  projects into. The main config is loaded once per deployment and passed in, rather than reading it for each
+//  project.
+// mainConfig is the main config which the environments are read from
+// environment is the environment name
+func validateAPIMExistsInEnv(mainConfig *utils.MainConfig, environment string) error {
+	envEndpoints, exists := mainConfig.Environments[environment]
+	if !exists || (envEndpoints.ApiManagerEndpoint == "" && !utils.RequiredAPIMEndpointsExists(&envEndpoints)) {
+		return errors.New("no API Manager is configured for " + environment)
+	}
+	return nil
+}
+
 // Logs the error and appends the failed project given from projectParam into the failedProjects map.
 func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams) bool {
 	if err != nil {
@@ -384,51 +521,70 @@ func handleIfError(err error, failedProjects map[string][]*params.ProjectParams,
 // sourceRepoId is the id of the source git repository (located in vcs.yaml)
 // deploymentRepoId is the id of the deployment git repository (located in vcs.yaml)
 // environment is the environment name
+// scopeParams is the part of the repositories which is deployed
+// workspace is the workspace which the projects are deployed from, or nil to deploy from the working copies
 // totalProjectsToUpdate is the number of total projects that needs to be deployed.
 // updatedProjectsPerType is a map of string -> ProjectParams which consists of updated projects per each type (API, App..)
+// parallel is the maximum number of projects of the same type that are deployed at the same time
 // Returns bool, true if any deleted projects exists so the process should continue with project deletion path
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  deleted projects
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //  failed during the deployment
-func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment string, totalProjectsToUpdate int,
-	updatedProjectsPerType map[string][]*params.ProjectParams) (bool, map[string][]*params.ProjectParams,
-	map[string][]*params.ProjectParams) {
+// Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
+//  not deployed as the projects those depend on were failed
+// Returns error, if the deployment state could not be updated
+func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment string, scopeParams ScopeParams,
+	workspace *revisionWorkspace, totalProjectsToUpdate int, updatedProjectsPerType map[string][]*params.ProjectParams,
+	parallel int) (bool, map[string][]*params.ProjectParams, map[string][]*params.ProjectParams,
+	map[string][]*params.ProjectParams, error) {
 	if totalProjectsToUpdate == 0 {
 		fmt.Println("Everything is up-to-date")
-		return false, nil, nil
+		return false, nil, nil, nil, nil
 	}
 
 	fmt.Println("Deploying Projects (" + strconv.Itoa(totalProjectsToUpdate) + ")...")
 
 	var failedProjects = make(map[string][]*params.ProjectParams)
+	var blockedProjects = make(map[string][]*params.ProjectParams)
 	var hasDeletedProjects bool
 	var deletedProjectsPerType = make(map[string][]*params.ProjectParams)
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	// the projects are read from the workspace if the deployment is not from the working copies
+	projectsConfig := workspace.mapConfig(mainConfig)
 
 	// deploying API projects
 	apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
 	if len(apiProjects) != 0 {
 		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
-		for i, projectParam := range apiProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
+		defaultMicrogatewayTargets := getDefaultMicrogatewayTargets(environment)
+		deployApiProject := func(projectParam *params.ProjectParams, out io.Writer) error {
+			if err := validateAPIMExistsInEnv(mainConfig, environment); err != nil {
+				return err
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			if dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation); !dirExists {
-				projectDeploymentParamsDirLocation = ""
+			sourceProjectPath := generateSourceProjectPath(projectsConfig, projectParam)
+			projectDeploymentParamsDirLocation := getDeploymentProjectPathIfExists(projectsConfig, projectParam)
+			microgatewayTargets := getMicrogatewayTargets(defaultMicrogatewayTargets, environment, projectParam.MetaData)
+			if err := validateMicrogatewayTargets(microgatewayTargets); err != nil {
+				return err
+			}
+			if err := runPreDeployHooks(environment, projectParam, workspace.mapPath(projectParam.AbsolutePath)); err != nil {
+				return err
 			}
-			err := impl.ImportAPIToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+			err := impl.ImportAPIToEnv(out, accessToken, environment, sourceProjectPath,
 				projectDeploymentParamsDirLocation, importParams.Update, importParams.PreserveProvider, false, false, false)
 			if err != nil {
-				fmt.Println("Error... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				return err
 			}
+			if err := deployApiToMicrogateways(out, microgatewayTargets, sourceProjectPath); err != nil {
+				return err
+			}
+			return runPostDeployHooks(environment, projectParam, workspace.mapPath(projectParam.AbsolutePath))
+		}
+		if deployProjectsOfType(apiProjects, parallel, deployApiProject, deletedProjectsPerType, failedProjects,
+			blockedProjects) {
+			hasDeletedProjects = true
 		}
 	}
 
@@ -436,26 +592,47 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	apiProductProjects := updatedProjectsPerType[utils.ProjectTypeApiProduct]
 	if len(apiProductProjects) != 0 {
 		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
-		for i, projectParam := range apiProductProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
+		deployApiProductProject := func(projectParam *params.ProjectParams, out io.Writer) error {
+			if err := validateAPIMExistsInEnv(mainConfig, environment); err != nil {
+				return err
+			}
+			if failedDependencies := getFailedDependencies(projectParam, failedProjects); len(failedDependencies) > 0 {
+				return &blockedProjectError{failedDependencies: failedDependencies}
+			}
+			if err := runPreDeployHooks(environment, projectParam, workspace.mapPath(projectParam.AbsolutePath)); err != nil {
+				return err
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			if dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation); !dirExists {
-				projectDeploymentParamsDirLocation = ""
+			sourceProjectPath := generateSourceProjectPath(projectsConfig, projectParam)
+			// The member APIs which are maintained as API projects in the repository are already deployed from
+			//  their own projects. Hence, those are excluded from the APIs bundled with the API Product.
+			if len(projectParam.Dependencies) > 0 {
+				projectPath, hasOtherApis, cleanup, err := excludeDependenciesFromApiProduct(sourceProjectPath,
+					projectParam.Dependencies)
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				sourceProjectPath = projectPath
+				if !hasOtherApis && (importParams.ImportAPIs || importParams.UpdateAPIs) {
+					utils.Logln(utils.LogPrefixInfo + "Skipping importing the member APIs of " +
+						projectParam.NickName + " as those are deployed from the API projects")
+					importParams.ImportAPIs = false
+					importParams.UpdateAPIs = false
+				}
 			}
-			err := impl.ImportAPIProductToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+			projectDeploymentParamsDirLocation := getDeploymentProjectPathIfExists(projectsConfig, projectParam)
+			err := impl.ImportAPIProductToEnv(out, accessToken, environment, sourceProjectPath,
 				projectDeploymentParamsDirLocation, importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
 				importParams.PreserveProvider, false, false, false)
 			if err != nil {
-				fmt.Println("\terror... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				return err
 			}
+			return runPostDeployHooks(environment, projectParam, workspace.mapPath(projectParam.AbsolutePath))
+		}
+		if deployProjectsOfType(apiProductProjects, parallel, deployApiProductProject, deletedProjectsPerType,
+			failedProjects, blockedProjects) {
+			hasDeletedProjects = true
 		}
 	}
 
@@ -463,65 +640,100 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	applicationProjects := updatedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjects) != 0 {
 		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
-		for i, projectParam := range applicationProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
+		deployApplicationProject := func(projectParam *params.ProjectParams, out io.Writer) error {
+			if err := validateAPIMExistsInEnv(mainConfig, environment); err != nil {
+				return err
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			_, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath, projectParam.MetaData.Owner,
+			_, err := impl.ImportApplicationToEnv(out, accessToken, environment,
+				workspace.mapPath(projectParam.AbsolutePath), projectParam.MetaData.Owner,
 				importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
+			return err
+		}
+		if deployProjectsOfType(applicationProjects, parallel, deployApplicationProject, deletedProjectsPerType,
+			failedProjects, blockedProjects) {
+			hasDeletedProjects = true
+		}
+	}
+
+	// deploying MI CApp projects. Those do not depend on the APIM projects.
+	miCAppProjects := updatedProjectsPerType[utils.ProjectTypeMICApp]
+	if len(miCAppProjects) != 0 {
+		fmt.Println("\nMI CApps (" + strconv.Itoa(len(miCAppProjects)) + ") ...")
+		deployMICAppProject := func(projectParam *params.ProjectParams, out io.Writer) error {
+			cAppFile, err := getCAppFile(workspace.mapPath(projectParam.AbsolutePath))
 			if err != nil {
-				fmt.Println("\terror... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				return err
 			}
+			return miImpl.DeployCompositeApp(out, environment, cAppFile)
+		}
+		if deployProjectsOfType(miCAppProjects, parallel, deployMICAppProject, deletedProjectsPerType,
+			failedProjects, blockedProjects) {
+			hasDeletedProjects = true
 		}
 	}
 
 	// If there are no deleted projects, update the VCS config file as there is nothing remaining to do.
 	//  If there are deleted projects, this needs to handle after deleting those.
 	if !hasDeletedProjects {
-		updateVCSConfig(sourceRepoId, environment, failedProjects)
+		err := updateVCSConfig(sourceRepoId, environment, scopeParams, workspace.getSourceRevision(), failedProjects,
+			blockedProjects)
+		if err != nil {
+			return hasDeletedProjects, deletedProjectsPerType, failedProjects, blockedProjects, err
+		}
 	}
 	if mainConfig.Config.VCSDeploymentRepoPath != "" && deploymentRepoId != "" {
 		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
-		updateVCSConfig(deploymentRepoId, environment, failedProjects)
+		err := updateVCSConfig(deploymentRepoId, environment, scopeParams, workspace.getDeploymentRevision(),
+			failedProjects, blockedProjects)
+		if err != nil {
+			return hasDeletedProjects, deletedProjectsPerType, failedProjects, blockedProjects, err
+		}
 	}
 
-	return hasDeletedProjects, deletedProjectsPerType, failedProjects
+	return hasDeletedProjects, deletedProjectsPerType, failedProjects, blockedProjects, nil
 }
 
-// This method is responsible for updating the vcs configuration file at the end of the deployment
+// This method is responsible for updating the deployment state in the state backend at the end of the deployment
 // repoId is the id of the git repository (located in vcs.yaml)
 // environment is the environment name
+// scopeParams is the part of the repository which was deployed
+// revision is the revision of the repository which was deployed. If empty, the latest revision of the repository is
+//  considered as deployed
 // failedProjects are a map of project type to failed projects during the previous deployment
-func updateVCSConfig(repoId, environment string, failedProjects map[string][]*params.ProjectParams) {
-	vcsConfig, envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment)
-	var err error
-	envVCSConfig.LastAttemptedRev, err = getLatestCommitId()
-	if err != nil {
-		utils.HandleErrorAndExit("Error while getting latest commit-id", err)
+// blockedProjects are a map of project type to projects blocked by the failed projects during the previous deployment
+func updateVCSConfig(repoId, environment string, scopeParams ScopeParams, revision string,
+	failedProjects, blockedProjects map[string][]*params.ProjectParams) error {
+	if revision == "" {
+		var err error
+		revision, err = getLatestCommitId()
+		if err != nil {
+			return errors.New("unable to read the latest commit-id: " + err.Error())
+		}
+	}
+	envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment, scopeParams)
+	envVCSConfig.LastAttemptedRev = revision
+	envVCSConfig.FailedProjects = getProjectsToPersist(failedProjects)
+	envVCSConfig.BlockedProjects = getProjectsToPersist(blockedProjects)
+	if mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath); !isDeploymentRepo(mainConfig, ".") {
+		scope := getDeploymentScope(environment, scopeParams)
+		envVCSConfig.Include = scope.includes
+		envVCSConfig.Exclude = scope.excludes
+		envVCSConfig.Paths = scope.paths
 	}
-	envVCSConfig.FailedProjects = failedProjects
 
-	if len(failedProjects) == 0 {
+	if len(failedProjects) == 0 && len(blockedProjects) == 0 {
 		if len(envVCSConfig.LastSuccessfulRev) == 0 || len(envVCSConfig.LastSuccessfulRev) > 0 &&
 			envVCSConfig.LastSuccessfulRev[0] != envVCSConfig.LastAttemptedRev {
 			persistedLast := envVCSConfig.LastSuccessfulRev[0:utils.Min(lastSuccessfulCommitsToKeep-1, len(envVCSConfig.LastSuccessfulRev))]
 			envVCSConfig.LastSuccessfulRev = append([]string{envVCSConfig.LastAttemptedRev}, persistedLast...)
 		}
 	}
-	_, hasRepo := vcsConfig.Repos[repoId]
-	if !hasRepo {
-		vcsConfig.Repos[repoId] = Repo{
-			Environments: map[string]Environment{},
-		}
+	if err := getStateBackend().Save(getStateKey(repoId, environment, scopeParams), environment,
+		envVCSConfig); err != nil {
+		return errors.New("unable to save the deployment state of " + environment + ": " + err.Error())
 	}
-	vcsConfig.Repos[repoId].Environments[environment] = envVCSConfig
-	utils.WriteConfigFile(vcsConfig, VCSConfigFilePath)
+	return nil
 }
 
 // Logs the deletion project info message and appends the project to delete (projectParam) into deletedProjectsPerType map.
@@ -540,19 +752,28 @@ func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedPro
 // Deploy all the changes to the specified environment.
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func DeployChangedFiles(accessToken, environment string) map[string][]*params.ProjectParams {
+// scopeParams is the part of the repositories which is deployed
+// parallel is the maximum number of projects of the same type that are deployed at the same time
+// Returns map[string][]*params.ProjectParams, a map of project type -> projects failed during the deployment
+// Returns bool, true if a post-deploy hook of a project which requires rolling back on failure failed
+// Returns error, if the deployment could not be completed
+func DeployChangedFiles(accessToken, environment string, scopeParams ScopeParams,
+	parallel int) (map[string][]*params.ProjectParams, bool, error) {
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
 	changeDirectoryToSourceRepo(mainConfig)
 	// Get the status of the source repo
-	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted)
+	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted, scopeParams)
+	recordPlannedActions(sourceRepoId, environment, scopeParams, sourceRepoUpdatedProjectsPerType)
 
 	var deploymentRepoId string
 	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
 	if mainConfig.Config.VCSDeploymentRepoPath != "" {
 		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
 		// Get the status of the deployment repo
-		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted)
+		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted,
+			scopeParams)
+		recordPlannedActions(deploymentRepoId, environment, scopeParams, deploymentRepoUpdatedProjectsPerType)
 	}
 
 	// Get the aggregated status of both the source and the deployment repos
@@ -561,39 +782,46 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 
 	// Again change directory to the source repo and deploy the updated projects
 	changeDirectoryToSourceRepo(mainConfig)
-	hasDeletedProjects, deletedProjectsPerType, failedProjects :=
-		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+	hasDeletedProjects, deletedProjectsPerType, failedProjects, blockedProjects, err :=
+		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, scopeParams, nil,
+			totalProjectsToUpdate, updatedProjectsPerType, parallel)
+	if err != nil {
+		return failedProjects, false, err
+	}
 
 	// Deletion will only be considered for source repo
 	if hasDeletedProjects {
 		changeDirectoryToSourceRepo(mainConfig)
 		//check whether project deletion is disabled
 		if !mainConfig.Config.VCSDeletionEnabled {
-			utils.HandleErrorAndExit("Error: there are projects to delete while project "+
-				"deletion is disabled via VCS", nil)
+			return failedProjects, false, errors.New("there are projects to delete while project deletion is " +
+				"disabled via VCS")
 		}
 
 		// work on deleted files
-		_, envVCSConfig, hasEnv := getVCSEnvironmentDetails(sourceRepoId, environment)
+		envVCSConfig, hasEnv := getVCSEnvironmentDetails(sourceRepoId, environment, scopeParams)
 		if !hasEnv || len(envVCSConfig.LastSuccessfulRev) == 0 {
-			utils.HandleErrorAndExit("Error: there are projects to delete but no last successful "+
-				"revision available in vcs config (vcs_config.yaml)", nil)
-			return nil
+			return failedProjects, false, errors.New("there are projects to delete but no last successful " +
+				"revision available in the VCS deployment state")
+		}
+		// The deleted projects are read from the last successful revision, which is extracted without checking it out
+		workspace, err := newRevisionWorkspace(mainConfig, envVCSConfig.LastSuccessfulRev[0], "")
+		if err != nil {
+			return failedProjects, false, errors.New("unable to read the last successful revision to find the " +
+				"projects to delete: " + err.Error())
 		}
-		currentBranch := getCurrentBranch()
-		lastSuccessfulRev := envVCSConfig.LastSuccessfulRev[0]
-		tmpBranchName := "tmp-" + lastSuccessfulRev[0:8]
-
 		fmt.Println("\nDeleting projects ..")
-		checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRev)
-		failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects)
-		checkoutBranch(currentBranch)
-		deleteTmpBranch(tmpBranchName)
+		failedProjects = deployProjectDeletions(accessToken, environment, workspace, deletedProjectsPerType,
+			failedProjects)
+		workspace.remove()
 
 		// Update the VCS config with failed projects, last attempted and last successful revisions
-		updateVCSConfig(sourceRepoId, environment, failedProjects)
+		if err := updateVCSConfig(sourceRepoId, environment, scopeParams, "", failedProjects,
+			blockedProjects); err != nil {
+			return failedProjects, false, err
+		}
 	}
-	return failedProjects
+	return failedProjects, isRollbackRequestedByHooks(failedProjects), nil
 }
 
 // Create 'vcs.yaml' in the repository root folder with a unique id (uuid) for the repository.
@@ -607,9 +835,9 @@ func InitializeRepo(force bool) error {
 	if !force && utils.IsFileExist(vcsInfoPath) {
 		return errors.New("the repository is already initialized")
 	}
-	repoInfo := RepoInfo{
-		Id: uuid.New().String(),
-	}
+	// the environment scopes of a reinitialized repository are retained
+	repoInfo, _ := getRepoInfo()
+	repoInfo.Id = uuid.New().String()
 	utils.WriteConfigFile(repoInfo, vcsInfoPath)
 	return nil
 }
@@ -624,38 +852,40 @@ func getVcsYamlPath() (string, error) {
 	return vcsInfoPath, nil
 }
 
+// Returns the id of the current working repository. Exits if the repository is not initialized.
+func getRepoIdOrExit() string {
+	repoId, err := getRepoId()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while retrieving repository id", err)
+	}
+	if repoId == "" {
+		utils.HandleErrorAndExit("The repository info: vcs.yaml is not found in the repository root. "+
+			"If this is the first time you are using this repo, please initialize it with 'vcs init'.", nil)
+	}
+	return repoId
+}
+
 // Returns the id of the current working repository by reading vcs.yaml
 func getRepoId() (string, error) {
+	repoInfo, err := getRepoInfo()
+	return repoInfo.Id, err
+}
+
+// Returns the repository info of the current working repository by reading vcs.yaml. An empty repository info is
+//  returned if the repository is not initialized.
+func getRepoInfo() (RepoInfo, error) {
+	var repoInfo RepoInfo
 	vcsInfoPath, err := getVcsYamlPath()
 	if err != nil {
-		return "", err
+		return repoInfo, err
 	}
-	var repoInfo RepoInfo
 	data, err := ioutil.ReadFile(vcsInfoPath)
 	if err == nil {
 		if err := yaml.Unmarshal(data, &repoInfo); err != nil {
 			utils.HandleErrorAndExit("Error parsing "+vcsInfoPath, err)
 		}
 	}
-	return repoInfo.Id, nil
-}
-
-// Retrieves the base location of the git repository
-func getRepoBaseDir() (string, error) {
-	baseDir, err := executeGitCommand("rev-parse", "--show-toplevel")
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(baseDir), nil
-}
-
-// Retrieves the latest commit of the git repository
-func getLatestCommitId() (string, error) {
-	latestCommit, err := executeGitCommand("rev-parse", "HEAD")
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(latestCommit), nil
+	return repoInfo, nil
 }
 
 // Prints the changed files in the terminal
@@ -682,6 +912,7 @@ func getProjectInfoFromProjectFile(envVCSConfig Environment, repoBasePath string
 			// once we identified the project type, check whether the project is failed previously. If so, mark it as
 			//  failed. This is used to show failed projects by the "status" command.
 			projectParams.FailedDuringPreviousDeploy = failedDuringEarlierDeploy(envVCSConfig, projectParams)
+			projectParams.Blocked = blockedDuringEarlierDeploy(envVCSConfig, projectParams)
 			return projectParams
 		}
 	}
@@ -723,6 +954,9 @@ func checkProjectTypeOfSpecificPath(repoBasePath, fullPath string,
 		if strings.HasSuffix(fullPath, utils.MetaFileApplication) {
 			projectParams.Type = utils.ProjectTypeApplication
 		}
+		if strings.HasSuffix(fullPath, utils.MetaFileMICApp) {
+			projectParams.Type = utils.ProjectTypeMICApp
+		}
 		//This means project type is set from any of the above condition.
 		//  Then set the correct basePath of the project.
 		if projectParams.Type != utils.ProjectTypeNone {
@@ -766,6 +1000,13 @@ func checkProjectTypeOfSpecificPath(repoBasePath, fullPath string,
 			if err != nil {
 				utils.HandleErrorAndExit("Error while parsing "+utils.MetaFileApplication+" file:"+fullPathWithFileName, err)
 			}
+		case utils.MetaFileMICApp:
+			metaData, err := LoadMetaDataFile(fullPathWithFileName)
+			projectParams.MetaData = metaData
+			projectParams.Type = utils.ProjectTypeMICApp
+			if err != nil {
+				utils.HandleErrorAndExit("Error while parsing "+utils.MetaFileMICApp+" file:"+fullPathWithFileName, err)
+			}
 		}
 		if projectParams.Type != utils.ProjectTypeNone {
 			//breaks from for loop
@@ -790,6 +1031,18 @@ func getSubPaths(parent string, path string) (paths []string) {
 
 // Executes the give git command as args list and returns the output
 func executeGitCommand(args ...string) (string, error) {
+	return runGitCommand(os.Stderr, args...)
+}
+
+// Executes the give git command as args list and returns the output without printing the errors of the command to the
+//  terminal. This is used when the command is expected to fail in certain cases (ex: reading a file which does not
+//  exist in the given revision)
+func executeGitCommandSilently(args ...string) (string, error) {
+	return runGitCommand(ioutil.Discard, args...)
+}
+
+// Executes the give git command as args list while writing the errors of the command to stderr, and returns the output
+func runGitCommand(stderr io.Writer, args ...string) (string, error) {
 	cmd := exec.Command(Git, args...)
 
 	if utils.VerboseModeEnabled() {
@@ -797,7 +1050,7 @@ func executeGitCommand(args ...string) (string, error) {
 	}
 
 	var errBuf bytes.Buffer
-	cmd.Stderr = io.MultiWriter(os.Stderr, &errBuf)
+	cmd.Stderr = io.MultiWriter(stderr, &errBuf)
 
 	output, err := cmd.Output()
 
@@ -856,6 +1109,13 @@ func aggregateSourceAndDeploymentStatusResults(sourceRepoUpdatedProjectsPerType,
 	addProjectsToUniqueList(deploymentRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
 		&updatedApplicationProjects, utils.ProjectTypeApplication, &totalNumberOfProjects)
 
+	finalAggregatedProjectsPerType[utils.ProjectTypeMICApp] = []*params.ProjectParams{}
+	var updatedMICAppProjects []string // This will be used only for search to know whether a project is already there
+	addProjectsToUniqueList(sourceRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
+		&updatedMICAppProjects, utils.ProjectTypeMICApp, &totalNumberOfProjects)
+	addProjectsToUniqueList(deploymentRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
+		&updatedMICAppProjects, utils.ProjectTypeMICApp, &totalNumberOfProjects)
+
 	return totalNumberOfProjects, finalAggregatedProjectsPerType
 }
 
@@ -923,3 +1183,13 @@ func generateDeploymentProjectPath(mainConfig *utils.MainConfig, projectParam *p
 	return mainConfig.Config.VCSDeploymentRepoPath + string(os.PathSeparator) +
 		utils.DeploymentDirPrefix + projectParam.MetaData.Name + "-" + projectParam.MetaData.Version
 }
+
+// getDeploymentProjectPathIfExists returns the deployment project path of an API/API Product if it exists in the
+//  deployment repo. Otherwise, returns an empty string.
+func getDeploymentProjectPathIfExists(mainConfig *utils.MainConfig, projectParam *params.ProjectParams) string {
+	projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
+	if dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation); !dirExists {
+		return ""
+	}
+	return projectDeploymentParamsDirLocation
+}
@@ -0,0 +1,51 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPersistedProjectsKeepOnlyRelativePath(t *testing.T) {
+	oldBasePath := filepath.Join("old", "checkout")
+	relativePath := filepath.Join("teams", "payments", "PizzaShack")
+	failedProject := &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PizzaShack",
+		AbsolutePath: filepath.Join(oldBasePath, relativePath), RelativePath: relativePath}
+	failedProjects := map[string][]*params.ProjectParams{utils.ProjectTypeApi: {failedProject}}
+
+	persistedProjects := getProjectsToPersist(failedProjects)
+	assert.Equal(t, 1, len(persistedProjects[utils.ProjectTypeApi]), "the project should be persisted")
+	persistedProject := persistedProjects[utils.ProjectTypeApi][0]
+	assert.Equal(t, "", persistedProject.AbsolutePath, "absolute path should not be persisted")
+	assert.Equal(t, "teams/payments/PizzaShack", persistedProject.RelativePath)
+	assert.Equal(t, filepath.Join(oldBasePath, relativePath), failedProject.AbsolutePath,
+		"the given project should not be changed")
+	assert.Nil(t, getProjectsToPersist(nil))
+
+	newBasePath := filepath.Join("new", "checkout")
+	resolvePersistedProjects(persistedProjects, newBasePath)
+	assert.Equal(t, relativePath, persistedProject.RelativePath)
+	assert.Equal(t, filepath.Join(newBasePath, relativePath), persistedProject.AbsolutePath,
+		"absolute path should be resolved against the current repository")
+}
@@ -0,0 +1,328 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// API and API Product projects can declare hooks in the deploy.hooks section of their meta file, which are run by
+//  vcs deploy around importing the project. A hook is either a local command (run in the project directory) or one
+//  of the built-in checks below.
+//
+//   deploy:
+//     hooks:
+//       preDeploy:
+//       - check: swagger-lint
+//       - command: ./scripts/validate.sh
+//       postDeploy:
+//       - check: smoke
+//         gateway: ${GATEWAY_URL}
+//         path: /menu
+//       rollbackOnFailure: true
+//
+// As the meta file is substituted with the environment variables in the ${VAR} form when loading, the commands should
+//  refer to the variables passed to those (ie: APICTL_ENVIRONMENT) in the $VAR form.
+
+// Built-in checks which can be used as deploy hooks
+const (
+	// Lints the Swagger/OpenAPI definition of the project
+	HookCheckSwaggerLint = "swagger-lint"
+	// Sends a GET request to a resource of the deployed API through the gateway
+	HookCheckSmoke = "smoke"
+)
+
+// Stages which the deploy hooks are run at
+const (
+	hookStagePreDeploy  = "pre-deploy"
+	hookStagePostDeploy = "post-deploy"
+)
+
+// Default time in seconds to wait for a hook to complete
+const defaultCommandHookTimeout = 300
+const defaultSmokeHookTimeout = 60
+
+// Time to wait before retrying a smoke request, as the API may not be available in the gateway right after importing
+const smokeRequestRetryInterval = 5 * time.Second
+
+// Returns whether a post-deploy hook of any of the failed projects requested rolling back the environment
+// failedProjects is the map of project type -> projects failed during the deployment
+func isRollbackRequestedByHooks(failedProjects map[string][]*params.ProjectParams) bool {
+	for _, failedProjectsOfType := range failedProjects {
+		for _, failedProject := range failedProjectsOfType {
+			if failedProject.RollbackRequested {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deployHookError is returned when a deploy hook of a project fails
+type deployHookError struct {
+	stage string
+	hook  string
+	err   error
+	// whether the environment should be rolled back, as the project requires rolling back on failure
+	rollbackRequested bool
+}
+
+func (e *deployHookError) Error() string {
+	return e.stage + " hook '" + e.hook + "' failed: " + e.err.Error()
+}
+
+// Runs the pre-deploy hooks of the project. The project should not be imported if any of those fail.
+// environment is the environment name
+// projectParam is the project to be deployed
+// projectPath is the path of the project directory
+func runPreDeployHooks(environment string, projectParam *params.ProjectParams, projectPath string) error {
+	if projectParam.MetaData == nil {
+		return nil
+	}
+	return runDeployHooks(hookStagePreDeploy, projectParam.MetaData.DeployConfig.Hooks.PreDeploy, environment,
+		projectParam, projectPath)
+}
+
+// Runs the built-in checks among the pre-deploy hooks of the project which do not need the project to be deployed (ie:
+//  swagger-lint). This is used when planning a deployment (--dry-run), which should not run the commands of the
+//  hooks as those can have side effects.
+// projectParam is the project to be deployed
+// projectPath is the path of the project directory
+func runPreDeployChecks(projectParam *params.ProjectParams, projectPath string) error {
+	if projectParam.MetaData == nil {
+		return nil
+	}
+	for _, hook := range projectParam.MetaData.DeployConfig.Hooks.PreDeploy {
+		if hook.Command != "" || hook.Check != HookCheckSwaggerLint {
+			continue
+		}
+		if err := runSwaggerLintCheck(projectPath); err != nil {
+			return &deployHookError{stage: hookStagePreDeploy, hook: getDeployHookName(hook), err: err}
+		}
+	}
+	return nil
+}
+
+// Returns the hooks of the project which would be run when deploying the project, but are not run when planning the
+//  deployment. Each hook is prefixed with its stage (ie: pre-deploy './scripts/validate.sh').
+// projectParam is the project to be deployed
+func getHooksNotRunInPlan(projectParam *params.ProjectParams) []string {
+	if projectParam.MetaData == nil {
+		return nil
+	}
+	var hooks []string
+	for _, hook := range projectParam.MetaData.DeployConfig.Hooks.PreDeploy {
+		if hook.Command != "" || hook.Check != HookCheckSwaggerLint {
+			hooks = append(hooks, hookStagePreDeploy+" '"+getDeployHookName(hook)+"'")
+		}
+	}
+	for _, hook := range projectParam.MetaData.DeployConfig.Hooks.PostDeploy {
+		hooks = append(hooks, hookStagePostDeploy+" '"+getDeployHookName(hook)+"'")
+	}
+	return hooks
+}
+
+// Runs the post-deploy hooks of the project. The returned error requests rolling back the environment if any of those
+//  fail while the project requires rolling back on failure
+// environment is the environment name
+// projectParam is the deployed project
+// projectPath is the path of the project directory
+func runPostDeployHooks(environment string, projectParam *params.ProjectParams, projectPath string) error {
+	if projectParam.MetaData == nil {
+		return nil
+	}
+	hooks := projectParam.MetaData.DeployConfig.Hooks
+	err := runDeployHooks(hookStagePostDeploy, hooks.PostDeploy, environment, projectParam, projectPath)
+	if hookErr, ok := err.(*deployHookError); ok && hooks.RollbackOnFailure {
+		hookErr.rollbackRequested = true
+	}
+	return err
+}
+
+// Runs the given hooks in order, until one of those fails
+func runDeployHooks(stage string, hooks []utils.DeployHook, environment string, projectParam *params.ProjectParams,
+	projectPath string) error {
+	for _, hook := range hooks {
+		hookName := getDeployHookName(hook)
+		utils.Logln(utils.LogPrefixInfo + "Running the " + stage + " hook '" + hookName + "' of " +
+			projectParam.NickName)
+		var err error
+		switch {
+		case hook.Command != "":
+			err = runHookCommand(hook, environment, projectParam, projectPath)
+		case hook.Check == HookCheckSwaggerLint:
+			err = runSwaggerLintCheck(projectPath)
+		case hook.Check == HookCheckSmoke:
+			err = runSmokeCheck(hook, projectParam, projectPath)
+		default:
+			err = errors.New("a hook should have either a command or one of the checks: " + HookCheckSwaggerLint +
+				", " + HookCheckSmoke)
+		}
+		if err != nil {
+			return &deployHookError{stage: stage, hook: hookName, err: err}
+		}
+	}
+	return nil
+}
+
+// Returns the name of the hook to show in the output
+func getDeployHookName(hook utils.DeployHook) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	if hook.Command != "" {
+		return hook.Command
+	}
+	return hook.Check
+}
+
+// Runs the command of the hook in the project directory. The details of the deployment are passed to the command as
+//  the environment variables APICTL_ENVIRONMENT, APICTL_PROJECT_TYPE, APICTL_PROJECT_NAME and APICTL_PROJECT_VERSION.
+func runHookCommand(hook utils.DeployHook, environment string, projectParam *params.ProjectParams,
+	projectPath string) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = defaultCommandHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	}
+	cmd.Dir = projectPath
+	cmd.Env = append(os.Environ(),
+		"APICTL_ENVIRONMENT="+environment,
+		"APICTL_PROJECT_TYPE="+projectParam.Type,
+		"APICTL_PROJECT_NAME="+projectParam.MetaData.Name,
+		"APICTL_PROJECT_VERSION="+projectParam.MetaData.Version,
+	)
+	// the output is collected and shown only on failures, as the projects can be deployed in parallel
+	output, err := cmd.CombinedOutput()
+	utils.Logln(utils.LogPrefixInfo + "Output of '" + hook.Command + "':\n" + string(output))
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New("timed out after " + strconv.Itoa(timeout) + " seconds")
+	}
+	if err != nil {
+		return errors.New(err.Error() + "\n" + strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Lints the Swagger/OpenAPI definition of the project
+func runSwaggerLintCheck(projectPath string) error {
+	issues, err := impl.LintAPIDefinition(projectPath)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return errors.New(strconv.Itoa(len(issues)) + " issue(s) found in the definition:\n\t" +
+			strings.Join(issues, "\n\t"))
+	}
+	return nil
+}
+
+// Sends a GET request to the resource of the deployed API (or API Product) through the gateway, until it responds
+//  with the expected status (200 by default) or the hook times out
+func runSmokeCheck(hook utils.DeployHook, projectParam *params.ProjectParams, projectPath string) error {
+	if hook.Gateway == "" {
+		return errors.New("the gateway URL of the smoke check is not given")
+	}
+	apiContext, err := getDeployedContext(projectParam, projectPath)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(hook.Gateway, "/") + apiContext + "/" + strings.TrimPrefix(hook.Path, "/")
+	expectedStatus := hook.ExpectedStatus
+	if expectedStatus == 0 {
+		expectedStatus = 200
+	}
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = defaultSmokeHookTimeout
+	}
+
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		utils.Logln(utils.LogPrefixInfo + "Sending the smoke request to " + url)
+		resp, err := utils.InvokeGETRequest(url, hook.Headers)
+		if err == nil && resp.StatusCode() == expectedStatus {
+			return nil
+		}
+		if time.Now().Add(smokeRequestRetryInterval).After(deadline) {
+			if err != nil {
+				return errors.New("GET " + url + ": " + err.Error())
+			}
+			return utils.NewHttpResponseError(resp, "GET "+url+" responded with "+resp.Status()+", expected "+
+				strconv.Itoa(expectedStatus))
+		}
+		time.Sleep(smokeRequestRetryInterval)
+	}
+}
+
+// Returns the context (including the version) which the API or the API Product of the project is deployed with
+func getDeployedContext(projectParam *params.ProjectParams, projectPath string) (string, error) {
+	var content []byte
+	var err error
+	if projectParam.Type == utils.ProjectTypeApiProduct {
+		_, content, err = impl.GetAPIProductDefinition(projectPath)
+	} else {
+		_, content, err = impl.GetAPIDefinition(projectPath)
+	}
+	if err != nil {
+		return "", err
+	}
+	var definition struct {
+		Data struct {
+			Context string `json:"context"`
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return "", err
+	}
+	return resolveDeployedContext(definition.Data.Context, definition.Data.Version), nil
+}
+
+// Returns the context which the API is invoked with in the gateway, which includes the version
+func resolveDeployedContext(apiContext, version string) string {
+	apiContext = "/" + strings.Trim(apiContext, "/")
+	if strings.Contains(apiContext, "{version}") {
+		return strings.Replace(apiContext, "{version}", version, 1)
+	}
+	if version != "" && !strings.HasSuffix(apiContext, "/"+version) {
+		return apiContext + "/" + version
+	}
+	return apiContext
+}
@@ -0,0 +1,138 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Returns an API project with the given hooks in a temporary directory
+func createHookTestProject(t *testing.T, hooks utils.DeployHooks) (*params.ProjectParams, string) {
+	projectPath, _ := ioutil.TempDir("", "apictl-hooks")
+	apiYaml := "type: api\nversion: v4.0.0\ndata:\n  name: PizzaShackAPI\n  context: /pizzashack\n  version: 1.0.0\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(apiYaml), 0644), "err should be nil")
+	projectParam := &params.ProjectParams{
+		Type:     utils.ProjectTypeApi,
+		NickName: "PizzaShackAPI-1.0.0",
+		MetaData: &utils.MetaData{Name: "PizzaShackAPI", Version: "1.0.0",
+			DeployConfig: utils.DeployConfig{Hooks: hooks}},
+	}
+	return projectParam, projectPath
+}
+
+func TestRunPreDeployHookCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook commands of the test use sh")
+	}
+	projectParam, projectPath := createHookTestProject(t, utils.DeployHooks{PreDeploy: []utils.DeployHook{
+		{Command: `test "$APICTL_ENVIRONMENT" = dev && test "$APICTL_PROJECT_NAME" = PizzaShackAPI && touch checked`},
+	}})
+	defer os.RemoveAll(projectPath)
+	assert.Nil(t, runPreDeployHooks("dev", projectParam, projectPath), "err should be nil")
+	assert.FileExists(t, filepath.Join(projectPath, "checked"), "the command should run in the project directory")
+
+	projectParam.MetaData.DeployConfig.Hooks.PreDeploy = append(projectParam.MetaData.DeployConfig.Hooks.PreDeploy,
+		utils.DeployHook{Name: "broken", Command: "echo the endpoint is not reachable; exit 3"})
+	err := runPreDeployHooks("dev", projectParam, projectPath)
+	assert.NotNil(t, err, "err should not be nil")
+	_, isHookErr := err.(*deployHookError)
+	assert.True(t, isHookErr, "err should be a deployHookError")
+	assert.True(t, strings.HasPrefix(err.Error(), "pre-deploy hook 'broken' failed"))
+	assert.Contains(t, err.Error(), "the endpoint is not reachable")
+}
+
+func TestRunPreDeployChecksSkipsCommands(t *testing.T) {
+	projectParam, projectPath := createHookTestProject(t, utils.DeployHooks{
+		PreDeploy:  []utils.DeployHook{{Command: "touch checked"}, {Check: HookCheckSmoke}},
+		PostDeploy: []utils.DeployHook{{Name: "smoke", Check: HookCheckSmoke}},
+	})
+	defer os.RemoveAll(projectPath)
+
+	assert.Nil(t, runPreDeployChecks(projectParam, projectPath), "err should be nil")
+	_, err := os.Stat(filepath.Join(projectPath, "checked"))
+	assert.True(t, os.IsNotExist(err), "the command should not run when planning")
+	assert.Equal(t, []string{"pre-deploy 'touch checked'", "pre-deploy 'smoke'", "post-deploy 'smoke'"},
+		getHooksNotRunInPlan(projectParam))
+
+	projectParam.MetaData.DeployConfig.Hooks = utils.DeployHooks{
+		PreDeploy: []utils.DeployHook{{Check: HookCheckSwaggerLint}}}
+	assert.Empty(t, getHooksNotRunInPlan(projectParam), "the built-in lint check should run when planning")
+}
+
+func TestRunPostDeployHooksRequestsRollback(t *testing.T) {
+	projectParam, projectPath := createHookTestProject(t, utils.DeployHooks{
+		PostDeploy: []utils.DeployHook{{Check: "unknown"}},
+	})
+	defer os.RemoveAll(projectPath)
+
+	err := runPostDeployHooks("dev", projectParam, projectPath)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.False(t, err.(*deployHookError).rollbackRequested,
+		"rollback should not be requested without rollbackOnFailure")
+
+	projectParam.MetaData.DeployConfig.Hooks.RollbackOnFailure = true
+	err = runPostDeployHooks("dev", projectParam, projectPath)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.True(t, err.(*deployHookError).rollbackRequested, "rollback should be requested with rollbackOnFailure")
+
+	projectParam.RollbackRequested = true
+	failedProjects := map[string][]*params.ProjectParams{projectParam.Type: {projectParam}}
+	assert.True(t, isRollbackRequestedByHooks(failedProjects), "rollback should be requested by the failed project")
+	assert.False(t, isRollbackRequestedByHooks(nil), "rollback should not be requested without failed projects")
+}
+
+func TestRunSmokeCheck(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/pizzashack/1.0.0/menu" && request.Header.Get("apikey") == "abc" {
+			writer.WriteHeader(http.StatusOK)
+			return
+		}
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer gateway.Close()
+
+	smokeHook := utils.DeployHook{Check: HookCheckSmoke, Gateway: gateway.URL + "/", Path: "/menu",
+		Headers: map[string]string{"apikey": "abc"}, Timeout: 1}
+	projectParam, projectPath := createHookTestProject(t, utils.DeployHooks{PostDeploy: []utils.DeployHook{smokeHook}})
+	defer os.RemoveAll(projectPath)
+	assert.Nil(t, runPostDeployHooks("dev", projectParam, projectPath), "err should be nil")
+
+	projectParam.MetaData.DeployConfig.Hooks.PostDeploy[0].Path = "/order"
+	err := runPostDeployHooks("dev", projectParam, projectPath)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.Contains(t, err.Error(), "/pizzashack/1.0.0/order responded with 404")
+}
+
+func TestResolveDeployedContext(t *testing.T) {
+	assert.Equal(t, "/pizzashack/1.0.0", resolveDeployedContext("/pizzashack", "1.0.0"))
+	assert.Equal(t, "/pizzashack/1.0.0", resolveDeployedContext("/pizzashack/1.0.0", "1.0.0"))
+	assert.Equal(t, "/1.0.0/pizzashack", resolveDeployedContext("/{version}/pizzashack", "1.0.0"))
+	assert.Equal(t, "/shop", resolveDeployedContext("shop/", ""))
+}
@@ -0,0 +1,130 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// DeploymentLock is an advisory lock acquired by a deployment on an environment of a repository, so that only one
+//  deployment (or rollback) runs against the same environment at a time
+type DeploymentLock struct {
+	Id           string    `yaml:"id"`
+	Owner        string    `yaml:"owner"`
+	Host         string    `yaml:"host"`
+	Pid          int       `yaml:"pid"`
+	AcquiredTime time.Time `yaml:"acquiredTime"`
+	ExpiryTime   time.Time `yaml:"expiryTime"`
+}
+
+// Creates a lock owned by the current user and process which expires after the given duration
+func newDeploymentLock(expiry time.Duration) *DeploymentLock {
+	owner := "unknown"
+	if currentUser, err := user.Current(); err == nil {
+		owner = currentUser.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	now := time.Now().UTC()
+	return &DeploymentLock{
+		Id:           uuid.New().String(),
+		Owner:        owner,
+		Host:         host,
+		Pid:          os.Getpid(),
+		AcquiredTime: now,
+		ExpiryTime:   now.Add(expiry),
+	}
+}
+
+// Returns whether the lock has expired, so that it can be taken over by another deployment
+func (lock *DeploymentLock) isExpired() bool {
+	return time.Now().After(lock.ExpiryTime)
+}
+
+func (lock *DeploymentLock) String() string {
+	return lock.Owner + "@" + lock.Host + " (pid " + strconv.Itoa(lock.Pid) + ") since " +
+		lock.AcquiredTime.Format(time.RFC3339) + ", expires at " + lock.ExpiryTime.Format(time.RFC3339)
+}
+
+// lockHeldError is returned when the deployment lock of an environment is held by another deployment
+type lockHeldError struct {
+	environment string
+	lock        *DeploymentLock
+}
+
+func (e *lockHeldError) Error() string {
+	return "the environment " + e.environment + " is locked by another deployment of " + e.lock.String()
+}
+
+// Acquires the deployment lock of the environment for the source repository. Exits if the lock is held by another
+//  deployment which is not expired yet.
+// environment is the environment name
+// Returns func(), the function to release the lock at the end of the deployment
+func LockEnvironment(environment string) func() {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	expiry := mainConfig.Config.VCSLockExpiry
+	if expiry <= 0 {
+		expiry = utils.DefaultVCSLockExpiry
+	}
+
+	changeDirectoryToSourceRepo(mainConfig)
+	// The lock is kept against the repository rather than the scope, as the scopes of a repository are deployed to
+	//  the same environment
+	repoId := getRepoIdOrExit()
+	lock := newDeploymentLock(time.Duration(expiry) * time.Minute)
+	if err := getStateBackend().AcquireLock(repoId, environment, lock); err != nil {
+		if _, isLockHeld := err.(*lockHeldError); isLockHeld {
+			utils.HandleErrorAndExit("Unable to start the deployment. If the deployment is not running anymore, "+
+				"clear the lock using '"+utils.ProjectName+" vcs unlock -e "+environment+"'", err)
+		}
+		utils.HandleErrorAndExit("Error while acquiring the deployment lock of "+environment, err)
+	}
+	utils.Logln(utils.LogPrefixInfo + "Acquired the deployment lock of " + environment)
+
+	return func() {
+		changeDirectoryToSourceRepo(mainConfig)
+		if err := getStateBackend().ReleaseLock(repoId, environment, lock); err != nil {
+			utils.HandleErrorAndContinue("Error while releasing the deployment lock of "+environment, err)
+			return
+		}
+		utils.Logln(utils.LogPrefixInfo + "Released the deployment lock of " + environment)
+	}
+}
+
+// Clears the deployment lock of the environment for the source repository regardless of the deployment holding it.
+//  This is used to clear the locks left by the deployments which were terminated unexpectedly.
+// environment is the environment name
+// Returns *DeploymentLock, the cleared lock or nil if the environment was not locked
+func UnlockEnvironment(environment string) *DeploymentLock {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	changeDirectoryToSourceRepo(mainConfig)
+	lock, err := getStateBackend().ClearLock(getRepoIdOrExit(), environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while clearing the deployment lock of "+environment, err)
+	}
+	return lock
+}
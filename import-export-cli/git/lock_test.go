@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Verifies the locking behaviour which is common for all the state backends
+func testDeploymentLock(t *testing.T, backend StateBackend) {
+	firstLock := newDeploymentLock(time.Hour)
+	assert.Nil(t, backend.AcquireLock("repo-1", "dev", firstLock), "err should be nil")
+
+	// Another deployment cannot acquire the lock while it is held
+	err := backend.AcquireLock("repo-1", "dev", newDeploymentLock(time.Hour))
+	assert.IsType(t, &lockHeldError{}, err)
+	assert.Equal(t, firstLock.Id, err.(*lockHeldError).lock.Id)
+
+	// Other environments are not locked
+	assert.Nil(t, backend.AcquireLock("repo-1", "prod", newDeploymentLock(time.Hour)), "err should be nil")
+
+	// A deployment cannot release a lock held by another deployment
+	assert.Nil(t, backend.ReleaseLock("repo-1", "dev", newDeploymentLock(time.Hour)), "err should be nil")
+	err = backend.AcquireLock("repo-1", "dev", newDeploymentLock(time.Hour))
+	assert.IsType(t, &lockHeldError{}, err)
+
+	assert.Nil(t, backend.ReleaseLock("repo-1", "dev", firstLock), "err should be nil")
+	expiredLock := newDeploymentLock(-time.Minute)
+	assert.Nil(t, backend.AcquireLock("repo-1", "dev", expiredLock), "err should be nil")
+
+	// An expired lock is taken over
+	secondLock := newDeploymentLock(time.Hour)
+	assert.Nil(t, backend.AcquireLock("repo-1", "dev", secondLock), "err should be nil")
+
+	clearedLock, err := backend.ClearLock("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, secondLock.Id, clearedLock.Id)
+	clearedLock, err = backend.ClearLock("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, clearedLock, "there should not be a lock to clear")
+}
+
+func TestFileStateBackendDeploymentLock(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-lock")
+	defer os.RemoveAll(tmpDir)
+	testDeploymentLock(t, &fileStateBackend{filePath: filepath.Join(tmpDir, VCSConfigFileName)})
+}
+
+func TestGitStateBackendDeploymentLock(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-lock")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	testDeploymentLock(t, newGitStateBackend(""))
+}
+
+func TestGitStateBackendDeploymentLockWithRemote(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-lock")
+	defer os.RemoveAll(tmpDir)
+	remotePath := filepath.Join(tmpDir, "remote.git")
+	_, err := executeGitCommand("init", "--quiet", "--bare", remotePath)
+	assert.Nil(t, err, "err should be nil")
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	_, err = executeGitCommand("remote", "add", "origin", remotePath)
+	assert.Nil(t, err, "err should be nil")
+	testDeploymentLock(t, newGitStateBackend("origin"))
+}
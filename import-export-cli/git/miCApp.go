@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// MI CApp projects are directories with a mi_meta.yaml, which contain the Carbon Application (.car) to deploy to the
+//  Micro Integrator of the environment. The .car file can be committed into the project directory itself, or into
+//  the target directory in the case of an Integration Studio (maven) project which is built before deploying.
+
+// Directory of a maven project which the built .car file is placed in
+const cAppBuildDirName = "target"
+
+// The version suffix of a .car file name (ie: _1.0.0 of HealthCareCompositeApplication_1.0.0.car)
+var cAppVersionSuffixRegex = regexp.MustCompile(`_\d+(\.\d+)*(-SNAPSHOT)?$`)
+
+// Returns the path of the .car file of the MI CApp project in the given path
+// projectPath is the path of the project directory
+func getCAppFile(projectPath string) (string, error) {
+	for _, dir := range []string{projectPath, filepath.Join(projectPath, cAppBuildDirName)} {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		var cAppFiles []string
+		for _, file := range files {
+			if !file.IsDir() && strings.EqualFold(filepath.Ext(file.Name()), utils.CAppFileExtension) {
+				cAppFiles = append(cAppFiles, filepath.Join(dir, file.Name()))
+			}
+		}
+		if len(cAppFiles) > 1 {
+			return "", errors.New("more than one " + utils.CAppFileExtension + " file found in " + dir)
+		}
+		if len(cAppFiles) == 1 {
+			return cAppFiles[0], nil
+		}
+	}
+	return "", errors.New("no " + utils.CAppFileExtension + " file found in " + projectPath + " or in its " +
+		cAppBuildDirName + " directory")
+}
+
+// Returns the name which the MI CApp of the project in the given path is deployed with. The name given in the
+//  mi_meta.yaml is used if available, otherwise it is derived from the name of the .car file.
+// projectPath is the path of the project directory
+func getCAppName(projectPath string) (string, error) {
+	metaData, err := LoadMetaDataFile(filepath.Join(projectPath, utils.MetaFileMICApp))
+	if err != nil {
+		return "", err
+	}
+	if metaData.Name != "" {
+		return metaData.Name, nil
+	}
+	cAppFile, err := getCAppFile(projectPath)
+	if err != nil {
+		return "", err
+	}
+	cAppName := strings.TrimSuffix(filepath.Base(cAppFile), filepath.Ext(cAppFile))
+	return cAppVersionSuffixRegex.ReplaceAllString(cAppName, ""), nil
+}
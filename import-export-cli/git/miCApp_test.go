@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetCAppFile(t *testing.T) {
+	projectPath, _ := ioutil.TempDir("", "apictl-capp")
+	defer os.RemoveAll(projectPath)
+
+	_, err := getCAppFile(projectPath)
+	assert.NotNil(t, err, "err should not be nil when there is no .car file")
+
+	// The .car file built by maven is placed in the target directory
+	buildDir := filepath.Join(projectPath, cAppBuildDirName)
+	assert.Nil(t, os.MkdirAll(buildDir, os.ModePerm), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(buildDir, "HealthCare_1.0.0.car"), []byte{}, 0644),
+		"err should be nil")
+	cAppFile, err := getCAppFile(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, filepath.Join(buildDir, "HealthCare_1.0.0.car"), cAppFile)
+
+	// The .car file committed into the project directory is preferred
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "Orders_1.0.0.car"), []byte{}, 0644),
+		"err should be nil")
+	cAppFile, err = getCAppFile(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, filepath.Join(projectPath, "Orders_1.0.0.car"), cAppFile)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "Orders_1.1.0.car"), []byte{}, 0644),
+		"err should be nil")
+	_, err = getCAppFile(projectPath)
+	assert.NotNil(t, err, "err should not be nil when there are multiple .car files")
+}
+
+func TestGetCAppName(t *testing.T) {
+	projectPath, _ := ioutil.TempDir("", "apictl-capp")
+	defer os.RemoveAll(projectPath)
+	metaFile := filepath.Join(projectPath, utils.MetaFileMICApp)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "HealthCare_1.0.0-SNAPSHOT.car"), []byte{}, 0644),
+		"err should be nil")
+
+	assert.Nil(t, ioutil.WriteFile(metaFile, []byte("version: 1.0.0\n"), 0644), "err should be nil")
+	cAppName, err := getCAppName(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "HealthCare", cAppName)
+
+	assert.Nil(t, ioutil.WriteFile(metaFile, []byte("name: HealthCareCApp\n"), 0644), "err should be nil")
+	cAppName, err = getCAppName(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "HealthCareCApp", cAppName)
+}
+
+func TestCheckProjectTypeOfMICApp(t *testing.T) {
+	repoPath, _ := ioutil.TempDir("", "apictl-capp")
+	defer os.RemoveAll(repoPath)
+	projectPath := filepath.Join(repoPath, "HealthCare")
+	assert.Nil(t, os.MkdirAll(projectPath, os.ModePerm), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.MetaFileMICApp), []byte("name: HealthCare\n"),
+		0644), "err should be nil")
+
+	projectParams := checkProjectTypeOfSpecificPath(repoPath, projectPath, map[string]*params.ProjectParams{})
+	assert.Equal(t, utils.ProjectTypeMICApp, projectParams.Type)
+	assert.Equal(t, "HealthCare", projectParams.RelativePath)
+	assert.False(t, projectParams.Deleted, "the project should not be marked as deleted")
+
+	deletedMetaFile := filepath.Join(repoPath, "Orders", utils.MetaFileMICApp)
+	projectParams = checkProjectTypeOfSpecificPath(repoPath, deletedMetaFile, map[string]*params.ProjectParams{})
+	assert.Equal(t, utils.ProjectTypeMICApp, projectParams.Type)
+	assert.Equal(t, "Orders", projectParams.RelativePath)
+	assert.True(t, projectParams.Deleted, "the project should be marked as deleted")
+}
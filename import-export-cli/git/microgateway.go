@@ -0,0 +1,142 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	mgImpl "github.com/wso2/product-apim-tooling/import-export-cli/impl/mg"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// API projects can be deployed to Microgateway adapter environments (mgw-clusters) along with the environment. The
+//  default targets of an environment are declared in vcs.yaml, and an API project can override those using the
+//  deploy.microgateway section of its api_meta.yaml.
+//
+// vcs.yaml:
+//   environments:
+//     dev:
+//       microgateways: [mg-dev]
+//
+// api_meta.yaml:
+//   deploy:
+//     microgateway:
+//       dev: [mg-dev, mg-dev-internal]
+
+// microgatewayDeployError is returned when an API project is imported to the API Manager, but failed to deploy to a
+//  Microgateway adapter environment. Such a project does not roll the environment back, as rolling back the API
+//  Manager does not undo the deployments to the other Microgateway adapter environments. The project is marked as
+//  failed, so that it is deployed again with the next deployment.
+type microgatewayDeployError struct {
+	target string
+	err    error
+}
+
+func (e *microgatewayDeployError) Error() string {
+	return "failed to deploy to the microgateway " + e.target + ": " + e.err.Error()
+}
+
+// Returns whether the environment needs to be rolled back for the failed projects, which is when any of those failed
+//  before or while importing to the API Manager
+// failedProjects is the map of project type -> projects failed during the deployment
+func RequiresRollback(failedProjects map[string][]*params.ProjectParams) bool {
+	for _, failedProjectsOfType := range failedProjects {
+		for _, failedProject := range failedProjectsOfType {
+			if !failedProject.MicrogatewayFailed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the default Microgateway targets declared for the environment in the vcs.yaml of the current working
+//  repository
+// environment is the environment name
+func getDefaultMicrogatewayTargets(environment string) []string {
+	repoInfo, err := getRepoInfo()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the repository info", err)
+	}
+	return repoInfo.Environments[environment].Microgateways
+}
+
+// Returns the Microgateway targets which the API project needs to be deployed to. The targets declared for the
+//  environment in the api_meta.yaml override the default targets, so that a project can opt out using an empty list.
+// defaultTargets are the default targets of the environment
+// environment is the environment name
+// metaData is the content of the api_meta.yaml of the project
+func getMicrogatewayTargets(defaultTargets []string, environment string, metaData *utils.MetaData) []string {
+	if metaData != nil {
+		if targets, ok := metaData.DeployConfig.Microgateway[environment]; ok {
+			return targets
+		}
+	}
+	return defaultTargets
+}
+
+// Returns an error if any of the targets is not added as a Microgateway adapter environment
+// targets are the names of the Microgateway adapter environments
+func validateMicrogatewayTargets(targets []string) error {
+	for _, target := range targets {
+		if !utils.MgwAdapterEnvExistsInMainConfigFile(target, utils.MainConfigFilePath) {
+			return errors.New("microgateway adapter environment " + target + " does not exist. Add it using " +
+				"'" + utils.ProjectName + " mg add env'")
+		}
+	}
+	return nil
+}
+
+// Deploys the API project to each of the Microgateway targets, overriding the API if it is already deployed
+// out is the writer to which the result of each deployment is written
+// targets are the names of the Microgateway adapter environments
+// projectPath is the path of the API project
+func deployApiToMicrogateways(out io.Writer, targets []string, projectPath string) error {
+	for _, target := range targets {
+		utils.Logln(utils.LogPrefixInfo + "Deploying " + projectPath + " to the microgateway " + target)
+		if err := mgImpl.DeployAPI(out, target, projectPath, map[string]string{}, false, true); err != nil {
+			return &microgatewayDeployError{target: target, err: err}
+		}
+	}
+	return nil
+}
+
+// Undeploys the API from each of the Microgateway targets. An API which is not deployed in a target is skipped, so
+//  that the deletion can be retried after a partial failure.
+// targets are the names of the Microgateway adapter environments
+// apiName is the name of the API
+// apiVersion is the version of the API
+func undeployApiFromMicrogateways(targets []string, apiName, apiVersion string) error {
+	for _, target := range targets {
+		utils.Logln(utils.LogPrefixInfo + "Undeploying " + apiName + ":" + apiVersion + " from the microgateway " +
+			target)
+		err := mgImpl.UndeployAPI(target, map[string]string{"apiName": apiName, "version": apiVersion})
+		if responseErr, ok := err.(*utils.HttpResponseError); ok && responseErr.StatusCode == http.StatusNotFound {
+			utils.Logln(utils.LogPrefixInfo + apiName + ":" + apiVersion + " is not deployed in " + target)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGetMicrogatewayTargets(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-mg")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	vcsYaml := "id: repo-1\nenvironments:\n  dev:\n    microgateways:\n    - mg-dev\n"
+	assert.Nil(t, ioutil.WriteFile(VCSRepoInfoFileName, []byte(vcsYaml), 0644), "err should be nil")
+
+	defaultTargets := getDefaultMicrogatewayTargets("dev")
+	assert.Equal(t, []string{"mg-dev"}, defaultTargets)
+	assert.Empty(t, getDefaultMicrogatewayTargets("prod"), "prod should not have microgateway targets")
+
+	var metaData utils.MetaData
+	apiMeta := "name: PizzaShack\nversion: 1.0.0\ndeploy:\n  microgateway:\n    dev: []\n    prod:\n    - mg-prod\n"
+	assert.Nil(t, yaml.Unmarshal([]byte(apiMeta), &metaData), "err should be nil")
+	assert.Equal(t, []string{"mg-dev"}, getMicrogatewayTargets(defaultTargets, "dev", nil))
+	assert.Empty(t, getMicrogatewayTargets(defaultTargets, "dev", &metaData),
+		"the project should be able to opt out of the default targets")
+	assert.Equal(t, []string{"mg-prod"}, getMicrogatewayTargets(nil, "prod", &metaData))
+	assert.Equal(t, []string{"mg-dev"}, getMicrogatewayTargets(defaultTargets, "dev", &utils.MetaData{}))
+}
+
+func TestDeployProjectsOfTypeMarksMicrogatewayFailures(t *testing.T) {
+	projects := getTestApiProjects(2)
+	deploy := func(projectParam *params.ProjectParams, out io.Writer) error {
+		if projectParam.NickName == "API1" {
+			return &microgatewayDeployError{target: "mg-dev", err: errors.New("connection refused")}
+		}
+		return nil
+	}
+	failedProjects := make(map[string][]*params.ProjectParams)
+	deployProjectsOfType(projects, 1, deploy, make(map[string][]*params.ProjectParams), failedProjects,
+		make(map[string][]*params.ProjectParams))
+	assert.Equal(t, []*params.ProjectParams{projects[0]}, failedProjects[utils.ProjectTypeApi])
+	assert.True(t, projects[0].MicrogatewayFailed, "the project should be marked as failed on the microgateway")
+	assert.False(t, RequiresRollback(failedProjects),
+		"the projects failed only on the microgateways should not require rolling back")
+
+	// a project failing while importing to the API Manager requires rolling back
+	failedProjects[utils.ProjectTypeApi] = append(failedProjects[utils.ProjectTypeApi], projects[1])
+	assert.True(t, RequiresRollback(failedProjects), "the failed project should require rolling back")
+}
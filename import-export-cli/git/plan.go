@@ -0,0 +1,233 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Actions which would be performed on a project during a deployment
+const (
+	ProjectActionCreate = "create"
+	ProjectActionUpdate = "update"
+	ProjectActionDelete = "delete"
+)
+
+// DeploymentPlan represents the changes that would be applied to an environment by deploying the changed projects
+type DeploymentPlan struct {
+	Environment     string                             `json:"environment"`
+	ProjectsPerType map[string][]*PlannedProjectAction `json:"projects"`
+}
+
+// PlannedProjectAction represents the action that would be performed on a single project during a deployment
+type PlannedProjectAction struct {
+	Type                       string `json:"type"`
+	NickName                   string `json:"nickName"`
+	RelativePath               string `json:"relativePath"`
+	Action                     string `json:"action"`
+	FailedDuringPreviousDeploy bool   `json:"failedDuringPreviousDeploy,omitempty"`
+	Error                      string `json:"error,omitempty"`
+	// hooks which would be run when deploying, but are not run when planning as those can have side effects
+	HooksToRun []string `json:"hooksToRun,omitempty"`
+}
+
+// Returns the number of projects in the plan, and the number of projects which would fail to deploy
+func (plan *DeploymentPlan) Count() (int, int) {
+	var total, failed int
+	for _, plannedProjects := range plan.ProjectsPerType {
+		for _, plannedProject := range plannedProjects {
+			total++
+			if plannedProject.Error != "" {
+				failed++
+			}
+		}
+	}
+	return total, failed
+}
+
+// Scans and detects all the changes in projects in the same way as DeployChangedFiles, and goes through all the steps
+//  of deploying those (substituting the environment variables, applying the params of the deployment repository and
+//  zipping the projects) without importing the projects to the environment. Neither the repositories nor the VCS
+//  configuration are changed.
+// environment is the environment name
+// scopeParams is the part of the repositories which is deployed
+// Returns *DeploymentPlan, the action that would be performed on each project along with the errors if any
+func PlanChangedFiles(environment string, scopeParams ScopeParams) *DeploymentPlan {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	plannedActions := make(map[*params.ProjectParams]string)
+
+	changeDirectoryToSourceRepo(mainConfig)
+	// Get the status of the source repo
+	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted, scopeParams)
+	resolvePlannedActions(sourceRepoId, environment, scopeParams, sourceRepoUpdatedProjectsPerType, plannedActions)
+
+	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
+	if mainConfig.Config.VCSDeploymentRepoPath != "" {
+		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
+		// Get the status of the deployment repo
+		var deploymentRepoId string
+		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted,
+			scopeParams)
+		resolvePlannedActions(deploymentRepoId, environment, scopeParams, deploymentRepoUpdatedProjectsPerType,
+			plannedActions)
+	}
+
+	// Get the aggregated status of both the source and the deployment repos
+	_, updatedProjectsPerType := aggregateSourceAndDeploymentStatusResults(sourceRepoUpdatedProjectsPerType,
+		deploymentRepoUpdatedProjectsPerType)
+
+	changeDirectoryToSourceRepo(mainConfig)
+	envVCSConfig, _ := getVCSEnvironmentDetails(sourceRepoId, environment, scopeParams)
+
+	plan := &DeploymentPlan{
+		Environment:     environment,
+		ProjectsPerType: make(map[string][]*PlannedProjectAction),
+	}
+	// projects which would fail are tracked to identify the API Products which would be blocked
+	failedProjects := make(map[string][]*params.ProjectParams)
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication,
+		utils.ProjectTypeMICApp} {
+		for _, projectParam := range updatedProjectsPerType[projectType] {
+			plannedProject := &PlannedProjectAction{
+				Type:                       projectParam.Type,
+				NickName:                   projectParam.NickName,
+				RelativePath:               projectParam.RelativePath,
+				Action:                     plannedActions[projectParam],
+				FailedDuringPreviousDeploy: projectParam.FailedDuringPreviousDeploy,
+			}
+			if !projectParam.Deleted {
+				plannedProject.HooksToRun = getHooksNotRunInPlan(projectParam)
+			}
+			if err := validateProjectDeployment(mainConfig, envVCSConfig, environment, projectParam,
+				failedProjects); err != nil {
+				plannedProject.Error = strings.TrimSpace(err.Error())
+				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+			}
+			plan.ProjectsPerType[projectType] = append(plan.ProjectsPerType[projectType], plannedProject)
+		}
+	}
+	return plan
+}
+
+// Goes through the steps of deploying a single project without importing it to the environment, and returns the
+//  error which would occur while deploying the project, if any.
+func validateProjectDeployment(mainConfig *utils.MainConfig, envVCSConfig Environment, environment string,
+	projectParam *params.ProjectParams, failedProjects map[string][]*params.ProjectParams) error {
+	if projectParam.Deleted {
+		if !mainConfig.Config.VCSDeletionEnabled {
+			return errors.New("project deletion is disabled via VCS")
+		}
+		if len(envVCSConfig.LastSuccessfulRev) == 0 {
+			return errors.New("no last successful revision available in the VCS deployment state to find the project to delete")
+		}
+		return nil
+	}
+
+	if projectParam.Type != utils.ProjectTypeMICApp {
+		if err := validateAPIMExistsInEnv(mainConfig, environment); err != nil {
+			return err
+		}
+	}
+	switch projectParam.Type {
+	case utils.ProjectTypeApi:
+		err := impl.ValidateAPIImport(environment, generateSourceProjectPath(mainConfig, projectParam),
+			getDeploymentProjectPathIfExists(mainConfig, projectParam), false)
+		if err != nil {
+			return err
+		}
+		err = validateMicrogatewayTargets(getMicrogatewayTargets(getDefaultMicrogatewayTargets(environment),
+			environment, projectParam.MetaData))
+		if err != nil {
+			return err
+		}
+		return runPreDeployChecks(projectParam, projectParam.AbsolutePath)
+	case utils.ProjectTypeApiProduct:
+		if failedDependencies := getFailedDependencies(projectParam, failedProjects); len(failedDependencies) > 0 {
+			return &blockedProjectError{failedDependencies: failedDependencies}
+		}
+		err := impl.ValidateAPIProductImport(environment, generateSourceProjectPath(mainConfig, projectParam),
+			getDeploymentProjectPathIfExists(mainConfig, projectParam), false)
+		if err != nil {
+			return err
+		}
+		return runPreDeployChecks(projectParam, projectParam.AbsolutePath)
+	case utils.ProjectTypeApplication:
+		return impl.ValidateApplicationImport(projectParam.AbsolutePath)
+	case utils.ProjectTypeMICApp:
+		if !utils.MIExistsInEnv(environment, utils.MainConfigFilePath) {
+			return errors.New("no Micro Integrator management endpoint is configured for " + environment)
+		}
+		_, err := getCAppFile(projectParam.AbsolutePath)
+		return err
+	}
+	return nil
+}
+
+// Resolves whether each of the updated projects of a repository would be created, updated or deleted in the
+//  environment. A project is considered as an update if it existed at the last successful revision of the repository.
+//  This should be called while the current directory is inside the repository.
+// repoId is the id of the git repository (located in vcs.yaml)
+// environment is the environment name
+// scopeParams is the part of the repository which is deployed
+// updatedProjectsPerType is a map of project type -> projects which consists of the projects to deploy
+// plannedActions is the map of project -> action which the resolved actions are added into
+func resolvePlannedActions(repoId, environment string, scopeParams ScopeParams,
+	updatedProjectsPerType map[string][]*params.ProjectParams, plannedActions map[*params.ProjectParams]string) {
+	envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment, scopeParams)
+	var lastSuccessfulRev string
+	if len(envVCSConfig.LastSuccessfulRev) > 0 {
+		lastSuccessfulRev = envVCSConfig.LastSuccessfulRev[0]
+	}
+	for projectType, projects := range updatedProjectsPerType {
+		for _, projectParam := range projects {
+			if projectParam.Deleted {
+				plannedActions[projectParam] = ProjectActionDelete
+				continue
+			}
+			plannedActions[projectParam] = ProjectActionCreate
+			if lastSuccessfulRev == "" {
+				continue
+			}
+			metaFile := path.Join(filepath.ToSlash(projectParam.RelativePath), getMetaFileName(projectType))
+			if _, err := readFileAtRevision(lastSuccessfulRev, metaFile); err == nil {
+				plannedActions[projectParam] = ProjectActionUpdate
+			}
+		}
+	}
+}
+
+// Returns the name of the meta file (*_meta.yaml) of the given project type
+func getMetaFileName(projectType string) string {
+	switch projectType {
+	case utils.ProjectTypeApiProduct:
+		return utils.MetaFileAPIProduct
+	case utils.ProjectTypeApplication:
+		return utils.MetaFileApplication
+	case utils.ProjectTypeMICApp:
+		return utils.MetaFileMICApp
+	}
+	return utils.MetaFileAPI
+}
@@ -0,0 +1,238 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Reasons which a change of a deployment to a protected environment needs an approval
+const (
+	ProtectedReasonDeletion           = "deletion"
+	ProtectedReasonLifecycleDowngrade = "lifecycle downgrade"
+	ProtectedReasonMaxChanges         = "too many changes"
+)
+
+// Length of the approval token of the changes to a protected environment
+const approvalTokenLength = 12
+
+// Ranks of the lifecycle statuses of an API based on how available the API is to its consumers. Changing the status
+//  of an API to a status with a lower rank is considered as a downgrade.
+var lifecycleStatusRanks = map[string]int{
+	"RETIRED":    0,
+	"BLOCKED":    1,
+	"CREATED":    1,
+	"PROTOTYPED": 2,
+	"DEPRECATED": 3,
+	"PUBLISHED":  4,
+}
+
+// ProtectedChange is a change of a deployment to a protected environment which is blocked until it is approved
+type ProtectedChange struct {
+	Type         string
+	NickName     string
+	RelativePath string
+	Reason       string
+	Details      string
+}
+
+func (change *ProtectedChange) String() string {
+	description := "[" + change.Reason + "] " + change.Type + " " + change.NickName + " (" + change.RelativePath + ")"
+	if change.Details != "" {
+		description += ": " + change.Details
+	}
+	return description
+}
+
+// Returns the changes of the next deployment to the given environment which need an approval, if the environment is
+//  marked as protected in the main config. Those are the projects to delete, the APIs whose lifecycle status would be
+//  downgraded, and all the projects if there are more changes than the maximum allowed for the environment.
+// environment is the environment name
+// scopeParams is the part of the repositories which is deployed
+// Returns []*ProtectedChange, the changes which need an approval (empty if the environment is not protected)
+// Returns string, the token to approve exactly those changes at the current revisions of the repositories
+func GetChangesRequiringApproval(environment string, scopeParams ScopeParams) ([]*ProtectedChange, string) {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	envEndpoints := mainConfig.Environments[environment]
+	if !envEndpoints.Protected {
+		return nil, ""
+	}
+
+	changeDirectoryToSourceRepo(mainConfig)
+	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted, scopeParams)
+	envVCSConfig, _ := getVCSEnvironmentDetails(sourceRepoId, environment, scopeParams)
+	var lastSuccessfulRev string
+	if len(envVCSConfig.LastSuccessfulRev) > 0 {
+		lastSuccessfulRev = envVCSConfig.LastSuccessfulRev[0]
+	}
+	revisions := []string{getRevisionOrExit()}
+	changes := getDeletionChanges(sourceRepoUpdatedProjectsPerType)
+	changes = append(changes, getLifecycleDowngradeChanges(lastSuccessfulRev, sourceRepoUpdatedProjectsPerType)...)
+
+	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
+	if mainConfig.Config.VCSDeploymentRepoPath != "" {
+		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
+		_, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted, scopeParams)
+		revisions = append(revisions, getRevisionOrExit())
+		changeDirectoryToSourceRepo(mainConfig)
+	}
+	totalProjectsToUpdate, updatedProjectsPerType := aggregateSourceAndDeploymentStatusResults(
+		sourceRepoUpdatedProjectsPerType, deploymentRepoUpdatedProjectsPerType)
+	changes = append(changes, getMaxChangesExceededChanges(envEndpoints.MaxChanges, totalProjectsToUpdate,
+		updatedProjectsPerType)...)
+
+	if len(changes) == 0 {
+		return nil, ""
+	}
+	return changes, getApprovalToken(environment, revisions, changes)
+}
+
+// Returns the current revision of the repository in the current directory, or exits if it cannot be read
+func getRevisionOrExit() string {
+	revision, err := getLatestCommitId()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the latest revision of the repository", err)
+	}
+	return revision
+}
+
+// Returns the changes deleting the projects
+// updatedProjectsPerType is a map of project type -> projects which consists of the projects to deploy
+func getDeletionChanges(updatedProjectsPerType map[string][]*params.ProjectParams) []*ProtectedChange {
+	var changes []*ProtectedChange
+	for _, projectType := range getProjectTypes(updatedProjectsPerType) {
+		for _, projectParam := range updatedProjectsPerType[projectType] {
+			if projectParam.Deleted {
+				changes = append(changes, newProtectedChange(projectParam, ProtectedReasonDeletion, ""))
+			}
+		}
+	}
+	return changes
+}
+
+// Returns the changes downgrading the lifecycle status of the APIs, by comparing the status in the api.yaml of each
+//  API at the given revision with the working copy. This should be called while the current directory is inside the repository.
+// lastSuccessfulRev is the last successful revision of the environment. If empty, nothing is considered a downgrade
+// updatedProjectsPerType is a map of project type -> projects which consists of the projects to deploy
+func getLifecycleDowngradeChanges(lastSuccessfulRev string,
+	updatedProjectsPerType map[string][]*params.ProjectParams) []*ProtectedChange {
+	var changes []*ProtectedChange
+	if lastSuccessfulRev == "" {
+		return changes
+	}
+	for _, projectParam := range updatedProjectsPerType[utils.ProjectTypeApi] {
+		if projectParam.Deleted {
+			continue
+		}
+		definitionFile := path.Join(filepath.ToSlash(projectParam.RelativePath), "api")
+		oldDefinition, err := readYamlOrJSONAtRevision(lastSuccessfulRev, definitionFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while reading the definition of "+projectParam.RelativePath, err)
+		}
+		newDefinition, err := readYamlOrJSONInWorkingCopy(filepath.Join(projectParam.AbsolutePath, "api"))
+		if err != nil {
+			utils.HandleErrorAndExit("Error while reading the definition of "+projectParam.RelativePath, err)
+		}
+		oldStatus, newStatus := getLifecycleStatus(oldDefinition), getLifecycleStatus(newDefinition)
+		if isLifecycleDowngrade(oldStatus, newStatus) {
+			changes = append(changes, newProtectedChange(projectParam, ProtectedReasonLifecycleDowngrade,
+				oldStatus+" -> "+newStatus))
+		}
+	}
+	return changes
+}
+
+// Returns all the changes if there are more changes than the given maximum
+// maxChanges is the maximum number of changes allowed without an approval. If not positive, there is no limit
+// totalProjectsToUpdate is the total number of projects to deploy
+// updatedProjectsPerType is a map of project type -> projects which consists of the projects to deploy
+func getMaxChangesExceededChanges(maxChanges, totalProjectsToUpdate int,
+	updatedProjectsPerType map[string][]*params.ProjectParams) []*ProtectedChange {
+	var changes []*ProtectedChange
+	if maxChanges <= 0 || totalProjectsToUpdate <= maxChanges {
+		return changes
+	}
+	details := strconv.Itoa(totalProjectsToUpdate) + " changes exceed the maximum of " + strconv.Itoa(maxChanges)
+	for _, projectType := range getProjectTypes(updatedProjectsPerType) {
+		for _, projectParam := range updatedProjectsPerType[projectType] {
+			changes = append(changes, newProtectedChange(projectParam, ProtectedReasonMaxChanges, details))
+		}
+	}
+	return changes
+}
+
+// Returns the lifecycle status in the "data" section of an API definition, or empty if not available
+func getLifecycleStatus(definition map[string]interface{}) string {
+	data, _ := definition["data"].(map[string]interface{})
+	status, _ := data["lifeCycleStatus"].(string)
+	return strings.ToUpper(status)
+}
+
+// Returns whether changing the lifecycle status of an API from oldStatus to newStatus makes the API less available to
+//  its consumers. Unknown statuses are not considered as downgrades.
+func isLifecycleDowngrade(oldStatus, newStatus string) bool {
+	oldRank, hasOldRank := lifecycleStatusRanks[oldStatus]
+	newRank, hasNewRank := lifecycleStatusRanks[newStatus]
+	return hasOldRank && hasNewRank && newRank < oldRank
+}
+
+// Returns a token which identifies the given changes of the environment at the given revisions of the repositories, so
+//  that an approval given for a set of changes is not valid for any other changes
+func getApprovalToken(environment string, revisions []string, changes []*ProtectedChange) string {
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, change.String())
+	}
+	sort.Strings(descriptions)
+
+	hash := sha256.New()
+	hash.Write([]byte(environment + "\n" + strings.Join(revisions, "\n") + "\n" + strings.Join(descriptions, "\n")))
+	return hex.EncodeToString(hash.Sum(nil))[:approvalTokenLength]
+}
+
+// Returns the project types of the given map in the order those are deployed
+func getProjectTypes(projectsPerType map[string][]*params.ProjectParams) []string {
+	var projectTypes []string
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication,
+		utils.ProjectTypeMICApp} {
+		if len(projectsPerType[projectType]) > 0 {
+			projectTypes = append(projectTypes, projectType)
+		}
+	}
+	return projectTypes
+}
+
+func newProtectedChange(projectParam *params.ProjectParams, reason, details string) *ProtectedChange {
+	return &ProtectedChange{
+		Type:         projectParam.Type,
+		NickName:     projectParam.NickName,
+		RelativePath: projectParam.RelativePath,
+		Reason:       reason,
+		Details:      details,
+	}
+}
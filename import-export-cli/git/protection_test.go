@@ -0,0 +1,120 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func getProtectionTestProjects() map[string][]*params.ProjectParams {
+	return map[string][]*params.ProjectParams{
+		utils.ProjectTypeApi: {
+			{Type: utils.ProjectTypeApi, NickName: "PizzaShack", RelativePath: "PizzaShack"},
+			{Type: utils.ProjectTypeApi, NickName: "Legacy", RelativePath: "Legacy", Deleted: true},
+		},
+		utils.ProjectTypeApplication: {
+			{Type: utils.ProjectTypeApplication, NickName: "DefaultApp", RelativePath: "DefaultApp"},
+		},
+	}
+}
+
+func TestIsLifecycleDowngrade(t *testing.T) {
+	assert.True(t, isLifecycleDowngrade("PUBLISHED", "CREATED"))
+	assert.True(t, isLifecycleDowngrade("PUBLISHED", "BLOCKED"))
+	assert.True(t, isLifecycleDowngrade("DEPRECATED", "RETIRED"))
+	assert.False(t, isLifecycleDowngrade("CREATED", "PUBLISHED"))
+	assert.False(t, isLifecycleDowngrade("PUBLISHED", "PUBLISHED"))
+	assert.False(t, isLifecycleDowngrade("", "CREATED"), "a new API should not be a downgrade")
+	assert.False(t, isLifecycleDowngrade("PUBLISHED", "UNKNOWN"), "unknown statuses should not be downgrades")
+}
+
+func TestGetDeletionAndMaxChangesExceededChanges(t *testing.T) {
+	projects := getProtectionTestProjects()
+	deletions := getDeletionChanges(projects)
+	assert.Equal(t, 1, len(deletions))
+	assert.Equal(t, "[deletion] API Legacy (Legacy)", deletions[0].String())
+
+	assert.Empty(t, getMaxChangesExceededChanges(0, 3, projects), "there should be no limit without a maximum")
+	assert.Empty(t, getMaxChangesExceededChanges(3, 3, projects), "the maximum should be allowed")
+	changes := getMaxChangesExceededChanges(2, 3, projects)
+	assert.Equal(t, 3, len(changes), "every change should be blocked when exceeding the maximum")
+	assert.Equal(t, "[too many changes] Application DefaultApp (DefaultApp): 3 changes exceed the maximum of 2",
+		changes[2].String())
+}
+
+func TestGetLifecycleDowngradeChanges(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-protection")
+	defer os.RemoveAll(tmpDir)
+	repoDir := filepath.Join(tmpDir, "repo")
+	initStateTestRepo(t, repoDir)
+
+	firstCommit := commitTestFiles(t, map[string]string{
+		"PizzaShack/api.yaml": "data:\n  lifeCycleStatus: PUBLISHED\n",
+		"Other/api.yaml":      "data:\n  lifeCycleStatus: CREATED\n",
+	}, "first")
+	commitTestFiles(t, map[string]string{
+		"PizzaShack/api.yaml": "data:\n  lifeCycleStatus: CREATED\n",
+		"Other/api.yaml":      "data:\n  lifeCycleStatus: PUBLISHED\n",
+		"New/api.yaml":        "data:\n  lifeCycleStatus: CREATED\n",
+	}, "second")
+
+	projects := map[string][]*params.ProjectParams{
+		utils.ProjectTypeApi: {
+			{Type: utils.ProjectTypeApi, NickName: "PizzaShack", RelativePath: "PizzaShack",
+				AbsolutePath: filepath.Join(repoDir, "PizzaShack")},
+			{Type: utils.ProjectTypeApi, NickName: "Other", RelativePath: "Other",
+				AbsolutePath: filepath.Join(repoDir, "Other")},
+			{Type: utils.ProjectTypeApi, NickName: "New", RelativePath: "New",
+				AbsolutePath: filepath.Join(repoDir, "New")},
+		},
+	}
+	changes := getLifecycleDowngradeChanges(firstCommit, projects)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, "[lifecycle downgrade] API PizzaShack (PizzaShack): PUBLISHED -> CREATED", changes[0].String())
+	assert.Empty(t, getLifecycleDowngradeChanges("", projects), "nothing should be a downgrade before deploying")
+}
+
+func TestGetApprovalToken(t *testing.T) {
+	changes := getDeletionChanges(getProtectionTestProjects())
+	changes = append(changes, getMaxChangesExceededChanges(1, 3, getProtectionTestProjects())...)
+	token := getApprovalToken("prod", []string{"abc"}, changes)
+	assert.Equal(t, approvalTokenLength, len(token))
+
+	reversedChanges := make([]*ProtectedChange, len(changes))
+	for i, change := range changes {
+		reversedChanges[len(changes)-1-i] = change
+	}
+	assert.Equal(t, token, getApprovalToken("prod", []string{"abc"}, reversedChanges),
+		"the token should not depend on the order of the changes")
+	assert.NotEqual(t, token, getApprovalToken("prod", []string{"def"}, changes),
+		"the token should change with the revision")
+	assert.NotEqual(t, token, getApprovalToken("dev", []string{"abc"}, changes),
+		"the token should change with the environment")
+	assert.NotEqual(t, token, getApprovalToken("prod", []string{"abc"}, changes[:1]),
+		"the token should change with the changes")
+}
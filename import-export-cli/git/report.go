@@ -0,0 +1,271 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Results of a project recorded in the deployment report
+const (
+	ProjectResultDeployed = "deployed"
+	ProjectResultFailed   = "failed"
+	ProjectResultBlocked  = "blocked"
+)
+
+// DeploymentReport is the machine-readable record of a deployment, which can be written as JSON or as a JUnit XML
+//  report for the CI pipelines
+type DeploymentReport struct {
+	Environment     string           `json:"environment"`
+	StartTime       time.Time        `json:"startTime"`
+	DurationSeconds float64          `json:"durationSeconds"`
+	Total           int              `json:"total"`
+	Failed          int              `json:"failed"`
+	Blocked         int              `json:"blocked"`
+	Projects        []*ProjectReport `json:"projects"`
+
+	// actions resolved for the projects before deploying those (project -> create/update/delete)
+	plannedActions map[*params.ProjectParams]string
+	lock           sync.Mutex
+}
+
+// ProjectReport is the result of deploying (or deleting) a single project
+type ProjectReport struct {
+	Type            string  `json:"type"`
+	Path            string  `json:"path"`
+	NickName        string  `json:"nickName"`
+	Action          string  `json:"action"`
+	Result          string  `json:"result"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	HttpStatus      int     `json:"httpStatus,omitempty"`
+	Error           string  `json:"error,omitempty"`
+	ErrorBody       string  `json:"errorBody,omitempty"`
+	Retry           bool    `json:"retry"`
+}
+
+// The report of the deployment in progress. Projects are recorded only while a report is started.
+var deploymentReport *DeploymentReport
+
+// Starts recording the results of the projects deployed to the environment, until the report is written using
+//  WriteDeploymentReport
+// environment is the environment name
+func StartDeploymentReport(environment string) {
+	deploymentReport = &DeploymentReport{
+		Environment:    environment,
+		StartTime:      time.Now().UTC(),
+		Projects:       []*ProjectReport{},
+		plannedActions: make(map[*params.ProjectParams]string),
+	}
+}
+
+// Records the actions (create/update/delete) resolved for the projects of a repository, if a report is started.
+//  This should be called while the current directory is inside the repository.
+func recordPlannedActions(repoId, environment string, scopeParams ScopeParams,
+	updatedProjectsPerType map[string][]*params.ProjectParams) {
+	if deploymentReport == nil {
+		return
+	}
+	resolvePlannedActions(repoId, environment, scopeParams, updatedProjectsPerType, deploymentReport.plannedActions)
+}
+
+// Records the result of deploying (or deleting) a project, if a report is started
+// projectParam is the project deployed
+// startTime is the time the deployment of the project was started at
+// err is the error occurred while deploying the project, if any
+func recordProjectResult(projectParam *params.ProjectParams, startTime time.Time, err error) {
+	if deploymentReport == nil {
+		return
+	}
+	projectReport := &ProjectReport{
+		Type:            projectParam.Type,
+		Path:            filepath.ToSlash(projectParam.RelativePath),
+		NickName:        projectParam.NickName,
+		Result:          ProjectResultDeployed,
+		DurationSeconds: time.Since(startTime).Seconds(),
+		Retry:           projectParam.FailedDuringPreviousDeploy,
+	}
+	if err != nil {
+		projectReport.Result = ProjectResultFailed
+		projectReport.Error = strings.TrimSpace(err.Error())
+		switch e := err.(type) {
+		case *blockedProjectError:
+			projectReport.Result = ProjectResultBlocked
+		case *utils.HttpResponseError:
+			projectReport.HttpStatus = e.StatusCode
+			projectReport.ErrorBody = e.Body
+		}
+	}
+
+	deploymentReport.lock.Lock()
+	defer deploymentReport.lock.Unlock()
+	projectReport.Action = deploymentReport.plannedActions[projectParam]
+	if projectParam.Deleted {
+		projectReport.Action = ProjectActionDelete
+	} else if projectReport.Action == "" {
+		projectReport.Action = ProjectActionUpdate
+	}
+	deploymentReport.Projects = append(deploymentReport.Projects, projectReport)
+}
+
+// Writes the report of the deployment into the given file and stops recording. The report is written in the JUnit
+//  XML format if the file has the .xml extension, and in the JSON format otherwise.
+// filePath is the path of the report file
+func WriteDeploymentReport(filePath string) error {
+	report := deploymentReport
+	deploymentReport = nil
+	if report == nil {
+		return nil
+	}
+	report.DurationSeconds = time.Since(report.StartTime).Seconds()
+	report.Total = len(report.Projects)
+	for _, projectReport := range report.Projects {
+		switch projectReport.Result {
+		case ProjectResultFailed:
+			report.Failed++
+		case ProjectResultBlocked:
+			report.Blocked++
+		}
+	}
+
+	var content []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(filePath), ".xml") {
+		content, err = report.toJUnit()
+	} else {
+		content, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing the deployment report into " + filePath)
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+// JUnit XML structure of the deployment report. Each project type is a test suite, and each project is a test case.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Converts the report into the JUnit XML format
+func (report *DeploymentReport) toJUnit() ([]byte, error) {
+	testSuites := junitTestSuites{
+		Name:     utils.ProjectName + " vcs deploy " + report.Environment,
+		Tests:    report.Total,
+		Failures: report.Failed,
+		Skipped:  report.Blocked,
+		Time:     formatSeconds(report.DurationSeconds),
+	}
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication,
+		utils.ProjectTypeMICApp} {
+		testSuite := junitTestSuite{
+			Name:      report.Environment + "." + projectType,
+			Timestamp: report.StartTime.Format("2006-01-02T15:04:05"),
+		}
+		var duration float64
+		for _, projectReport := range report.Projects {
+			if projectReport.Type != projectType {
+				continue
+			}
+			testCase := junitTestCase{
+				Name:      projectReport.NickName + " (" + projectReport.Path + ")",
+				ClassName: report.Environment + "." + projectType,
+				Time:      formatSeconds(projectReport.DurationSeconds),
+				SystemOut: "action: " + projectReport.Action + ", retry: " + strconv.FormatBool(projectReport.Retry),
+			}
+			// a blocked project is skipped, as it was not deployed
+			if projectReport.Result == ProjectResultBlocked {
+				testCase.Skipped = &junitSkipped{Message: projectReport.Error}
+				testSuite.Skipped++
+			} else if projectReport.Result != ProjectResultDeployed {
+				testCase.Failure = &junitFailure{
+					Message: projectReport.Error,
+					Type:    projectReport.Result,
+					Content: projectReport.ErrorBody,
+				}
+				if projectReport.HttpStatus != 0 {
+					testCase.Failure.Type = projectReport.Result + " (HTTP " + strconv.Itoa(projectReport.HttpStatus) + ")"
+				}
+				testSuite.Failures++
+			}
+			duration += projectReport.DurationSeconds
+			testSuite.Tests++
+			testSuite.TestCases = append(testSuite.TestCases, testCase)
+		}
+		if testSuite.Tests == 0 {
+			continue
+		}
+		testSuite.Time = formatSeconds(duration)
+		testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+	}
+	content, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+// Formats a duration in seconds as in the JUnit reports
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
@@ -0,0 +1,120 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Records a deployed, a failed, a blocked and a deleted project into a new report
+func recordTestProjectResults() {
+	StartDeploymentReport("dev")
+	createdApi := &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PizzaShack", RelativePath: "PizzaShack"}
+	deploymentReport.plannedActions[createdApi] = ProjectActionCreate
+	recordProjectResult(createdApi, time.Now(), nil)
+
+	failedApi := &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "Petstore", RelativePath: "Petstore",
+		FailedDuringPreviousDeploy: true}
+	recordProjectResult(failedApi, time.Now(), &utils.HttpResponseError{StatusCode: 409, Body: "{\"code\":409}"})
+
+	blockedApiProduct := &params.ProjectParams{Type: utils.ProjectTypeApiProduct, NickName: "Shop", RelativePath: "Shop"}
+	recordProjectResult(blockedApiProduct, time.Now(),
+		&blockedProjectError{failedDependencies: []string{"Petstore-1.0.0"}})
+
+	deletedApp := &params.ProjectParams{Type: utils.ProjectTypeApplication, NickName: "App", RelativePath: "App",
+		Deleted: true}
+	recordProjectResult(deletedApp, time.Now(), errors.New("not found"))
+}
+
+func TestWriteDeploymentReportAsJSON(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-report")
+	defer os.RemoveAll(tmpDir)
+	reportPath := filepath.Join(tmpDir, "report.json")
+
+	recordTestProjectResults()
+	assert.Nil(t, WriteDeploymentReport(reportPath), "err should be nil")
+	assert.Nil(t, deploymentReport, "recording should be stopped once the report is written")
+
+	content, err := ioutil.ReadFile(reportPath)
+	assert.Nil(t, err, "err should be nil")
+	var report DeploymentReport
+	assert.Nil(t, json.Unmarshal(content, &report), "err should be nil")
+	assert.Equal(t, "dev", report.Environment)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 1, report.Blocked)
+
+	assert.Equal(t, ProjectActionCreate, report.Projects[0].Action)
+	assert.Equal(t, ProjectResultDeployed, report.Projects[0].Result)
+
+	assert.Equal(t, ProjectActionUpdate, report.Projects[1].Action)
+	assert.Equal(t, ProjectResultFailed, report.Projects[1].Result)
+	assert.Equal(t, 409, report.Projects[1].HttpStatus)
+	assert.Equal(t, "{\"code\":409}", report.Projects[1].ErrorBody)
+	assert.True(t, report.Projects[1].Retry, "the failed project should be reported as a retry")
+
+	assert.Equal(t, ProjectResultBlocked, report.Projects[2].Result)
+	assert.Equal(t, ProjectActionDelete, report.Projects[3].Action)
+	assert.Equal(t, "not found", report.Projects[3].Error)
+}
+
+func TestWriteDeploymentReportAsJUnit(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-report")
+	defer os.RemoveAll(tmpDir)
+	reportPath := filepath.Join(tmpDir, "junit.xml")
+
+	recordTestProjectResults()
+	assert.Nil(t, WriteDeploymentReport(reportPath), "err should be nil")
+
+	content, err := ioutil.ReadFile(reportPath)
+	assert.Nil(t, err, "err should be nil")
+	var testSuites junitTestSuites
+	assert.Nil(t, xml.Unmarshal(content, &testSuites), "err should be nil")
+	assert.Equal(t, 4, testSuites.Tests)
+	assert.Equal(t, 2, testSuites.Failures)
+	assert.Equal(t, 1, testSuites.Skipped)
+	assert.Equal(t, 3, len(testSuites.TestSuites))
+
+	apiSuite := testSuites.TestSuites[0]
+	assert.Equal(t, "dev."+utils.ProjectTypeApi, apiSuite.Name)
+	assert.Equal(t, 2, apiSuite.Tests)
+	assert.Nil(t, apiSuite.TestCases[0].Failure, "the deployed project should not have a failure")
+	assert.Equal(t, "failed (HTTP 409)", apiSuite.TestCases[1].Failure.Type)
+	assert.Nil(t, testSuites.TestSuites[1].TestCases[0].Failure, "the blocked project should not have a failure")
+	assert.Equal(t, "depends on the failed project(s): Petstore-1.0.0",
+		testSuites.TestSuites[1].TestCases[0].Skipped.Message)
+}
+
+func TestRecordProjectResultWithoutReport(t *testing.T) {
+	deploymentReport = nil
+	recordProjectResult(&params.ProjectParams{Type: utils.ProjectTypeApi}, time.Now(), nil)
+	assert.Nil(t, deploymentReport, "results should not be recorded unless a report is started")
+	assert.Nil(t, WriteDeploymentReport(filepath.Join(os.TempDir(), "not-written.json")), "err should be nil")
+}
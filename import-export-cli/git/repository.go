@@ -0,0 +1,311 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// The repository is read in-process, so that the trees at any revision can be read without checking out the
+//  revision in the working copy of the user.
+
+// Opens the git repository which the current directory belongs to
+func openCurrentRepository() (*gogit.Repository, error) {
+	return gogit.PlainOpenWithOptions(".", &gogit.PlainOpenOptions{DetectDotGit: true})
+}
+
+// Returns the tree of the given revision (commit id, branch, tag or HEAD) of the current repository
+func getTreeAtRevision(repo *gogit.Repository, revision string) (*object.Tree, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// Returns the commit the given ref of the current repository points to, or an empty string if the ref does not exist
+func getLocalRefCommit(refName string) (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+	if err == plumbing.ErrReferenceNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
+
+// Reads the content of a file (relative to the root of the tree, with "/" separators) of the given commit of the
+//  current repository. The commit is not required to be reachable from any branch (ie: a commit of a state ref).
+func readFileOfCommit(commitId, fileName string) (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(commitId))
+	if err != nil {
+		return "", err
+	}
+	file, err := commit.File(fileName)
+	if err != nil {
+		return "", err
+	}
+	return file.Contents()
+}
+
+// Returns the base directory of the working copy of the current repository
+func getRepoBaseDir() (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+// Retrieves the latest commit of the git repository
+func getLatestCommitId() (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+// Returns the files (relative to the repository base directory, with "/" separators) committed to the current
+//  repository at the given revision
+func listFilesAtRevision(revision string) ([]string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := getTreeAtRevision(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	err = tree.Files().ForEach(func(file *object.File) error {
+		files = append(files, file.Name)
+		return nil
+	})
+	return files, err
+}
+
+// Reads the content of a file (relative to the repository base directory, with "/" separators) of the current
+//  repository at the given revision. Returns object.ErrFileNotFound if the file does not exist at the revision.
+func readFileAtRevision(revision, fileName string) (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	tree, err := getTreeAtRevision(repo, revision)
+	if err != nil {
+		return "", err
+	}
+	file, err := tree.File(fileName)
+	if err != nil {
+		return "", err
+	}
+	return file.Contents()
+}
+
+// Returns the files changed between two revisions of the current repository
+// includeDeleted specifies whether the files deleted at toRevision should be included
+func getChangedFilesBetween(fromRevision, toRevision string, includeDeleted bool) ([]string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+	fromTree, err := getTreeAtRevision(repo, fromRevision)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := getTreeAtRevision(repo, toRevision)
+	if err != nil {
+		return nil, err
+	}
+	changedFiles := make(map[string]bool)
+	if err := addChangedFilesOfTrees(fromTree, toTree, includeDeleted, changedFiles); err != nil {
+		return nil, err
+	}
+	return sortedFileNames(changedFiles), nil
+}
+
+// Returns the files changed in the working copy of the current repository compared to the given revision, including
+//  the changes which are not committed yet (same as 'git diff --name-only <revision>')
+// includeDeleted specifies whether the files deleted in the working copy should be included
+func getChangedFilesSince(revision string, includeDeleted bool) ([]string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return nil, err
+	}
+	fromTree, err := getTreeAtRevision(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := getTreeAtRevision(repo, plumbing.HEAD.String())
+	if err != nil {
+		return nil, err
+	}
+	changedFiles := make(map[string]bool)
+	if err := addChangedFilesOfTrees(fromTree, headTree, includeDeleted, changedFiles); err != nil {
+		return nil, err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+	for fileName, fileStatus := range status {
+		// Similar to git diff, the files which are not tracked are not considered as changes
+		if fileStatus.Worktree == gogit.Untracked {
+			continue
+		}
+		deleted := fileStatus.Worktree == gogit.Deleted || fileStatus.Staging == gogit.Deleted
+		if deleted && !includeDeleted {
+			// The file may have been listed as a committed change, but it does not exist in the working copy anymore
+			delete(changedFiles, fileName)
+			continue
+		}
+		changedFiles[fileName] = true
+	}
+	return sortedFileNames(changedFiles), nil
+}
+
+// Adds the names of the files changed between two trees into changedFiles
+func addChangedFilesOfTrees(fromTree, toTree *object.Tree, includeDeleted bool, changedFiles map[string]bool) error {
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return err
+		}
+		if action == merkletrie.Delete {
+			if includeDeleted {
+				changedFiles[change.From.Name] = true
+			}
+			continue
+		}
+		changedFiles[change.To.Name] = true
+	}
+	return nil
+}
+
+// Returns the file names of the given set in the sorted order
+func sortedFileNames(files map[string]bool) []string {
+	var fileNames []string
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	return fileNames
+}
+
+// Writes the files of the current repository at the given revision into a new temporary directory, without touching
+//  the working copy or the branches of the repository
+// Returns string, the temporary directory which should be removed once used
+func extractRevision(revision string) (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	tree, err := getTreeAtRevision(repo, revision)
+	if err != nil {
+		return "", err
+	}
+	workspaceDir, err := ioutil.TempDir("", "apictl-vcs-")
+	if err != nil {
+		return "", err
+	}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		return writeFileOfTree(workspaceDir, file)
+	})
+	if err != nil {
+		_ = os.RemoveAll(workspaceDir)
+		return "", err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Extracted the revision " + revision + " into " + workspaceDir)
+	return workspaceDir, nil
+}
+
+// Writes a single file of a tree into the given directory
+func writeFileOfTree(dir string, file *object.File) error {
+	filePath := filepath.Join(dir, filepath.FromSlash(file.Name))
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	if file.Mode == filemode.Symlink {
+		target, err := file.Contents()
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, filePath)
+	}
+	perm := os.FileMode(0644)
+	if file.Mode == filemode.Executable {
+		perm = 0755
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	output, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(output, reader); err != nil {
+		output.Close()
+		return err
+	}
+	return output.Close()
+}
@@ -0,0 +1,127 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes the given files into the current directory and commits those
+// Returns string, the commit id
+func commitTestFiles(t *testing.T, files map[string]string, message string) string {
+	for fileName, content := range files {
+		assert.Nil(t, os.MkdirAll(filepath.Dir(fileName), os.ModePerm), "err should be nil")
+		assert.Nil(t, ioutil.WriteFile(fileName, []byte(content), 0644), "err should be nil")
+	}
+	_, err := executeGitCommand("add", "-A")
+	assert.Nil(t, err, "err should be nil")
+	_, err = executeGitCommand("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet",
+		"-m", message)
+	assert.Nil(t, err, "err should be nil")
+	commit, err := getLatestCommitId()
+	assert.Nil(t, err, "err should be nil")
+	return commit
+}
+
+func TestReadRepositoryAtRevision(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-repo")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	firstCommit := commitTestFiles(t, map[string]string{"PizzaShack/api.yaml": "v1", "Other/api.yaml": "v1"}, "first")
+	assert.Nil(t, os.Remove(filepath.Join("Other", "api.yaml")), "err should be nil")
+	secondCommit := commitTestFiles(t, map[string]string{"PizzaShack/api.yaml": "v2"}, "second")
+
+	files, err := listFilesAtRevision(firstCommit)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"Other/api.yaml", "PizzaShack/api.yaml"}, files)
+
+	content, err := readFileAtRevision(firstCommit, "PizzaShack/api.yaml")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "v1", content)
+	_, err = readFileAtRevision(secondCommit, "Other/api.yaml")
+	assert.Equal(t, object.ErrFileNotFound, err)
+
+	changedFiles, err := getChangedFilesBetween(firstCommit, secondCommit, true)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"Other/api.yaml", "PizzaShack/api.yaml"}, changedFiles)
+	changedFiles, err = getChangedFilesBetween(firstCommit, secondCommit, false)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"PizzaShack/api.yaml"}, changedFiles)
+
+	// The revision is extracted without changing the working copy or the branches
+	extractedDir, err := extractRevision(firstCommit)
+	assert.Nil(t, err, "err should be nil")
+	defer os.RemoveAll(extractedDir)
+	extractedContent, err := ioutil.ReadFile(filepath.Join(extractedDir, "Other", "api.yaml"))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "v1", string(extractedContent))
+	workingCopyContent, _ := ioutil.ReadFile(filepath.Join("PizzaShack", "api.yaml"))
+	assert.Equal(t, "v2", string(workingCopyContent))
+	branches, err := executeGitCommand("branch", "--list")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, len(strings.Split(strings.TrimSpace(branches), "\n")))
+}
+
+func TestGetChangedFilesSinceIncludesUncommittedChanges(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-repo")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	firstCommit := commitTestFiles(t, map[string]string{"PizzaShack/api.yaml": "v1", "Other/api.yaml": "v1"}, "first")
+	commitTestFiles(t, map[string]string{"Committed/api.yaml": "v1"}, "second")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join("PizzaShack", "api.yaml"), []byte("v2"), 0644), "err should be nil")
+	assert.Nil(t, os.Remove(filepath.Join("Other", "api.yaml")), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile("untracked.yaml", []byte("v1"), 0644), "err should be nil")
+
+	changedFiles, err := getChangedFilesSince(firstCommit, true)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"Committed/api.yaml", "Other/api.yaml", "PizzaShack/api.yaml"}, changedFiles)
+	changedFiles, err = getChangedFilesSince(firstCommit, false)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"Committed/api.yaml", "PizzaShack/api.yaml"}, changedFiles)
+}
+
+func TestRevisionWorkspaceMapPath(t *testing.T) {
+	workspace := &revisionWorkspace{
+		sourceRepoBasePath: filepath.FromSlash("/repos/source"),
+		sourceDir:          filepath.FromSlash("/tmp/apictl-vcs-1"),
+	}
+	assert.Equal(t, filepath.FromSlash("/tmp/apictl-vcs-1/PizzaShack"),
+		workspace.mapPath(filepath.FromSlash("/repos/source/PizzaShack")))
+	assert.Equal(t, filepath.FromSlash("/repos/sourceOther/PizzaShack"),
+		workspace.mapPath(filepath.FromSlash("/repos/sourceOther/PizzaShack")))
+	assert.Equal(t, filepath.FromSlash("/repos/source/PizzaShack"),
+		workspace.unmapSourcePath(filepath.FromSlash("/tmp/apictl-vcs-1/PizzaShack")))
+
+	var workingCopy *revisionWorkspace
+	assert.Equal(t, filepath.FromSlash("/repos/source/PizzaShack"),
+		workingCopy.mapPath(filepath.FromSlash("/repos/source/PizzaShack")))
+}
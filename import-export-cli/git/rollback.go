@@ -0,0 +1,230 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Rolls back the environment to a previously successful revision of the source repository, which is still retained
+//  in the deployment state. The projects changed since that revision (and the projects failed during the last
+//  deployment) are deployed from the revision, and the projects which did not exist at the revision are deleted.
+//  This can be used even if the last deployment was successful.
+// accesstoken is the access token to access the APIM product REST APIs
+// environment is the environment name
+// to is the index of the successful revision (0 is the latest) or the revision (commit id) itself
+// scopeParams is the part of the repository which is deployed
+// parallel is the maximum number of projects of the same type that are deployed at the same time
+func RollbackToRevision(accessToken, environment, to string, scopeParams ScopeParams, parallel int) error {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	changeDirectoryToSourceRepo(mainConfig)
+	repoId := getRepoIdOrExit()
+	envVCSConfig, hasEnv := getVCSEnvironmentDetails(repoId, environment, scopeParams)
+	if !hasEnv || envVCSConfig.LastAttemptedRev == "" {
+		return errors.New("Nothing to rollback as there are no deployments to " + environment)
+	}
+	revision, err := resolveRollbackRevision(envVCSConfig.LastSuccessfulRev, to)
+	if err != nil {
+		return err
+	}
+
+	changedFileList, err := getChangedFilesBetween(revision, envVCSConfig.LastAttemptedRev, true)
+	if err != nil {
+		return err
+	}
+	for i, changedFile := range changedFileList {
+		changedFileList[i] = filepath.FromSlash(changedFile)
+	}
+
+	// The projects are identified from the files of the revision, so that the projects which did not exist at the
+	//  revision are identified as deleted
+	workspace, err := newRevisionWorkspace(mainConfig, revision, "")
+	if err != nil {
+		return err
+	}
+	defer workspace.remove()
+	totalProjectsToRevert, projectsToRevertPerType := getProjectsToRevert(environment, scopeParams, envVCSConfig,
+		workspace, changedFileList)
+
+	var hasDeletedProjects bool
+	for _, projectsToRevert := range projectsToRevertPerType {
+		for _, projectParam := range projectsToRevert {
+			hasDeletedProjects = hasDeletedProjects || projectParam.Deleted
+		}
+	}
+	if totalProjectsToRevert == 0 {
+		return errors.New("Nothing to rollback as " + environment + " is already at the revision " + revision)
+	}
+	if hasDeletedProjects && !mainConfig.Config.VCSDeletionEnabled {
+		return errors.New("there are projects to delete while project deletion is disabled via VCS")
+	}
+
+	printProjectsToRevert(revision, totalProjectsToRevert, projectsToRevertPerType)
+	// Only the source repository is rolled back. The deployment repository is used as it is.
+	hasDeletedProjects, deletedProjectsPerType, failedProjects, blockedProjects, err := deployUpdatedProjects(
+		accessToken, repoId, "", environment, scopeParams, workspace, totalProjectsToRevert, projectsToRevertPerType,
+		parallel)
+	if err != nil {
+		return err
+	}
+
+	if hasDeletedProjects {
+		// The definitions of the projects to delete are only available at the revision which was deployed last
+		lastAttemptedWorkspace, err := newRevisionWorkspace(mainConfig, envVCSConfig.LastAttemptedRev, "")
+		if err != nil {
+			return err
+		}
+		defer lastAttemptedWorkspace.remove()
+		fmt.Println("\nDeleting projects ..")
+		failedProjects = deployProjectDeletions(accessToken, environment, lastAttemptedWorkspace,
+			deletedProjectsPerType, failedProjects)
+		err = updateVCSConfig(repoId, environment, scopeParams, revision, failedProjects, blockedProjects)
+		if err != nil {
+			return err
+		}
+	}
+
+	var failedCount int
+	for _, failedProjectsOfType := range failedProjects {
+		failedCount += len(failedProjectsOfType)
+	}
+	if failedCount > 0 {
+		return errors.New(strconv.Itoa(failedCount) + " project(s) failed to rollback")
+	}
+	return nil
+}
+
+// Identifies the projects to revert to the revision extracted into the workspace
+// environment is the environment name
+// scopeParams is the part of the repository which is deployed
+// envVCSConfig is the environment specific VCS configuration
+// workspace is the workspace which the revision is extracted into
+// changedFileList is the list of files changed between the revision and the last attempted revision
+// Returns int, the total number of projects to revert
+// Returns map[string][]*params.ProjectParams, a map of project type -> projects to revert
+func getProjectsToRevert(environment string, scopeParams ScopeParams, envVCSConfig Environment,
+	workspace *revisionWorkspace, changedFileList []string) (int, map[string][]*params.ProjectParams) {
+	totalProjectsToRevert, projectsToRevertPerType, projectsToRevertPerPath :=
+		getProjectsOfChangedFiles(envVCSConfig, getDeploymentScope(environment, scopeParams), workspace.sourceDir,
+			changedFileList)
+
+	// The projects failed during the last deployment may not be at the state of the revision even if those were not
+	//  changed since the revision
+	pathInfoMap := make(map[string]*params.ProjectParams)
+	for _, failedProjectsOfType := range envVCSConfig.FailedProjects {
+		for _, failedProject := range failedProjectsOfType {
+			projectPath := filepath.Join(workspace.sourceDir, failedProject.RelativePath)
+			if projectsToRevertPerPath[projectPath] != nil {
+				continue
+			}
+			projectParam := checkProjectTypeOfSpecificPath(workspace.sourceDir, projectPath, pathInfoMap)
+			if projectParam.Type == utils.ProjectTypeNone || projectParam.Deleted {
+				continue
+			}
+			projectParam.FailedDuringPreviousDeploy = true
+			projectsToRevertPerPath[projectPath] = projectParam
+			projectsToRevertPerType[projectParam.Type] = append(projectsToRevertPerType[projectParam.Type], projectParam)
+			totalProjectsToRevert++
+		}
+	}
+	resolveApiProductDependencies(workspace.sourceDir, workspace.sourceRevision, projectsToRevertPerType)
+
+	// The projects are tracked using the paths in the repository, as those are kept in the deployment state
+	for _, projectsToRevert := range projectsToRevertPerType {
+		for _, projectParam := range projectsToRevert {
+			projectParam.AbsolutePath = workspace.unmapSourcePath(projectParam.AbsolutePath)
+		}
+	}
+	return totalProjectsToRevert, projectsToRevertPerType
+}
+
+// Prints the projects which are reverted to the revision
+func printProjectsToRevert(revision string, totalProjectsToRevert int,
+	projectsToRevertPerType map[string][]*params.ProjectParams) {
+	fmt.Println("Rolling back to " + revision + ". Projects to revert (" + strconv.Itoa(totalProjectsToRevert) + ")")
+	for _, projectType := range []string{utils.ProjectTypeApi, utils.ProjectTypeApiProduct, utils.ProjectTypeApplication,
+		utils.ProjectTypeMICApp} {
+		projectsToRevert := projectsToRevertPerType[projectType]
+		if len(projectsToRevert) == 0 {
+			continue
+		}
+		fmt.Println("\n" + projectType + "s (" + strconv.Itoa(len(projectsToRevert)) + ") ...")
+		for i, projectParam := range projectsToRevert {
+			action := "[revert]"
+			if projectParam.Deleted {
+				action = "[delete]"
+			}
+			fmt.Println(strconv.Itoa(i+1) + ": " + action + "\t" + projectParam.NickName + ": (" +
+				projectParam.RelativePath + ")")
+		}
+	}
+	fmt.Println()
+}
+
+// Resolves the successful revision to rollback to
+// lastSuccessfulRevs is the list of successful revisions retained in the deployment state (latest first)
+// to is the index of the successful revision (0 is the latest), or the revision itself or a prefix of it
+// Returns string, the resolved revision
+func resolveRollbackRevision(lastSuccessfulRevs []string, to string) (string, error) {
+	if len(lastSuccessfulRevs) == 0 {
+		return "", errors.New("Failed to rollback as there are no previous successful revisions")
+	}
+	to = strings.TrimSpace(to)
+	// A number shorter than an abbreviated commit id is considered as an index
+	if index, err := strconv.Atoi(to); err == nil && len(to) < 4 {
+		if index < 0 || index >= len(lastSuccessfulRevs) {
+			return "", errors.New("invalid revision index " + to + ". The available successful revisions are:\n" +
+				formatRevisionList(lastSuccessfulRevs))
+		}
+		return lastSuccessfulRevs[index], nil
+	}
+
+	var matchingRevs []string
+	if len(to) >= 4 {
+		for _, revision := range lastSuccessfulRevs {
+			if strings.HasPrefix(revision, to) {
+				matchingRevs = append(matchingRevs, revision)
+			}
+		}
+	}
+	if len(matchingRevs) > 1 {
+		return "", errors.New("the revision " + to + " is ambiguous. Use the full commit id")
+	}
+	if len(matchingRevs) == 1 {
+		return matchingRevs[0], nil
+	}
+	return "", errors.New("the revision " + to + " is not among the successful revisions retained. " +
+		"The available successful revisions are:\n" + formatRevisionList(lastSuccessfulRevs))
+}
+
+// Formats the list of revisions along with the index of each
+func formatRevisionList(revisions []string) string {
+	var revisionList []string
+	for i, revision := range revisions {
+		revisionList = append(revisionList, "  "+strconv.Itoa(i)+": "+revision)
+	}
+	return strings.Join(revisionList, "\n")
+}
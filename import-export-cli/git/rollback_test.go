@@ -0,0 +1,61 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var retainedRevisions = []string{
+	"5d8e1c2a9f0b4e3d2c1b0a9f8e7d6c5b4a392817",
+	"1234abcd9f0b4e3d2c1b0a9f8e7d6c5b4a392817",
+	"1234abef9f0b4e3d2c1b0a9f8e7d6c5b4a392817",
+}
+
+func TestResolveRollbackRevisionByIndex(t *testing.T) {
+	revision, err := resolveRollbackRevision(retainedRevisions, "1")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, retainedRevisions[1], revision)
+
+	_, err = resolveRollbackRevision(retainedRevisions, "3")
+	assert.NotNil(t, err, "index out of the retained revisions should fail")
+}
+
+func TestResolveRollbackRevisionByCommitId(t *testing.T) {
+	revision, err := resolveRollbackRevision(retainedRevisions, "5d8e1c2a")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, retainedRevisions[0], revision)
+
+	revision, err = resolveRollbackRevision(retainedRevisions, retainedRevisions[2])
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, retainedRevisions[2], revision)
+
+	_, err = resolveRollbackRevision(retainedRevisions, "1234ab")
+	assert.NotNil(t, err, "ambiguous revision should fail")
+
+	_, err = resolveRollbackRevision(retainedRevisions, "ffffffff")
+	assert.NotNil(t, err, "revision which is not retained should fail")
+}
+
+func TestResolveRollbackRevisionWithoutSuccessfulRevisions(t *testing.T) {
+	_, err := resolveRollbackRevision(nil, "0")
+	assert.NotNil(t, err, "rollback without successful revisions should fail")
+}
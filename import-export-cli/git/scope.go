@@ -0,0 +1,324 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Separates the repository id and the scope id in the key which the deployment state of a scope is kept against
+const stateKeyScopeSeparator = "~"
+
+// Scope names are used in the state keys and the git refs of the deployment state
+var reScopeName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ScopeParams limits the projects considered by the status, deploy, rollback and watch commands to a part of the
+//  repositories
+type ScopeParams struct {
+	// Name is the name of the scope given using --scope. The deployment state of each named scope is kept separately,
+	//  so that the paths of a scope can be changed without losing its state. An empty name is the whole repository.
+	Name string
+	// Paths are the subtrees of the repositories (relative to the repository root) given using --path. An empty list
+	//  considers the whole repository.
+	Paths []string
+}
+
+// ValidateScopeName returns an error if the given scope name cannot be used to keep the deployment state of the scope
+// name is the name of the scope. An empty name is valid, and is the whole repository.
+func ValidateScopeName(name string) error {
+	if name != "" && !reScopeName.MatchString(name) {
+		return errors.New("invalid scope name '" + name + "'. A scope name can only contain letters, digits, '-' " +
+			"and '_'")
+	}
+	return nil
+}
+
+// Returns the scope paths in the slash separated, cleaned and sorted form
+func normalizeScopePaths(paths []string) []string {
+	var normalizedPaths []string
+	for _, scopePath := range paths {
+		scopePath = strings.Trim(path.Clean(filepath.ToSlash(strings.TrimSpace(scopePath))), "/")
+		if scopePath != "" && scopePath != "." {
+			normalizedPaths = append(normalizedPaths, scopePath)
+		}
+	}
+	sort.Strings(normalizedPaths)
+	return normalizedPaths
+}
+
+// deploymentScope is the part of a repository which is deployed to an environment. A project is in the scope if it
+//  matches an include glob (if any) and the --path subtrees (if any), and does not match an exclude glob. The globs
+//  use the syntax of path.Match, and are matched against the path of the project relative to the repository root and
+//  each of its parent directories.
+//  The scope of the deployment repository is mapped from the scope of the source repository instead, as the projects
+//  are kept in the Deployment_<name>-<version> directories there. A project of the deployment repository is in the
+//  scope if its directory is in projectDirs.
+type deploymentScope struct {
+	name        string
+	includes    []string
+	excludes    []string
+	paths       []string
+	projectDirs map[string]bool
+}
+
+// Returns the scope of the current working repository for the given environment, using the include and exclude
+//  globs of the environment in vcs.yaml and the subtrees in scopeParams
+func getDeploymentScope(environment string, scopeParams ScopeParams) deploymentScope {
+	repoInfo, err := getRepoInfo()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the repository info", err)
+	}
+	repoEnvironment := repoInfo.Environments[environment]
+	scope := deploymentScope{
+		name:     scopeParams.Name,
+		includes: normalizeGlobs(repoEnvironment.Include),
+		excludes: normalizeGlobs(repoEnvironment.Exclude),
+		paths:    normalizeScopePaths(scopeParams.Paths),
+	}
+	for _, pattern := range append(append([]string{}, scope.includes...), scope.excludes...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			utils.HandleErrorAndExit("Invalid path glob '"+pattern+"' in "+VCSRepoInfoFileName+" for "+
+				environment, err)
+		}
+	}
+	return scope
+}
+
+// Returns the scope of the deployment repository for the given environment, which contains the deployment directories
+//  (Deployment_<name>-<version>) of the API and API Product projects in the scope of the source repository. The
+//  current directory should be the deployment repository, and is restored after reading the source repository.
+func getDeploymentRepoScope(mainConfig *utils.MainConfig, environment string,
+	scopeParams ScopeParams) deploymentScope {
+	changeDirectoryToSourceRepo(mainConfig)
+	defer changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
+
+	sourceScope := getDeploymentScope(environment, scopeParams)
+	if sourceScope.isEmpty() {
+		return sourceScope
+	}
+	basePath, err := getRepoBaseDir()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting repository base folder location", err)
+	}
+	files, err := listFilesAtRevision("HEAD")
+	if err != nil {
+		utils.HandleErrorAndExit("Error while listing the files of the source repository", err)
+	}
+
+	scope := deploymentScope{projectDirs: make(map[string]bool)}
+	pathInfoMap := make(map[string]*params.ProjectParams)
+	for _, file := range files {
+		projectParam := getProjectInfoFromProjectFile(Environment{}, basePath, filepath.FromSlash(file), pathInfoMap)
+		if projectParam.MetaData == nil || (projectParam.Type != utils.ProjectTypeApi &&
+			projectParam.Type != utils.ProjectTypeApiProduct) || !sourceScope.contains(projectParam.RelativePath) {
+			continue
+		}
+		scope.projectDirs[utils.DeploymentDirPrefix+projectParam.MetaData.Name+"-"+
+			projectParam.MetaData.Version] = true
+	}
+	return scope
+}
+
+// Returns the scope of the current working repository for the given environment, mapping the scope of the source
+//  repository if the current working repository is the deployment repository
+// repoBasePath is the basepath of the current working repository
+func getRepoScope(mainConfig *utils.MainConfig, environment, repoBasePath string,
+	scopeParams ScopeParams) deploymentScope {
+	if isDeploymentRepo(mainConfig, repoBasePath) {
+		return getDeploymentRepoScope(mainConfig, environment, scopeParams)
+	}
+	return getDeploymentScope(environment, scopeParams)
+}
+
+// Returns whether the given path is the root of the deployment repository
+func isDeploymentRepo(mainConfig *utils.MainConfig, repoBasePath string) bool {
+	if mainConfig.Config.VCSDeploymentRepoPath == "" {
+		return false
+	}
+	deploymentRepoInfo, err := os.Stat(mainConfig.Config.VCSDeploymentRepoPath)
+	if err != nil {
+		return false
+	}
+	repoInfo, err := os.Stat(repoBasePath)
+	return err == nil && os.SameFile(deploymentRepoInfo, repoInfo)
+}
+
+// Returns a copy of the scope with the given include and exclude globs and the --path subtrees
+func (scope deploymentScope) withFilters(includes, excludes, paths []string) deploymentScope {
+	scope.includes = normalizeGlobs(includes)
+	scope.excludes = normalizeGlobs(excludes)
+	scope.paths = normalizeScopePaths(paths)
+	return scope
+}
+
+// Returns whether the scopes have the same include and exclude globs and the same --path subtrees
+func (scope deploymentScope) hasSameFilters(other deploymentScope) bool {
+	return strings.Join(scope.includes, "\n") == strings.Join(other.includes, "\n") &&
+		strings.Join(scope.excludes, "\n") == strings.Join(other.excludes, "\n") &&
+		strings.Join(scope.paths, "\n") == strings.Join(other.paths, "\n")
+}
+
+// Returns the globs in the slash separated and sorted form
+func normalizeGlobs(globs []string) []string {
+	var normalizedGlobs []string
+	for _, glob := range globs {
+		glob = strings.Trim(filepath.ToSlash(strings.TrimSpace(glob)), "/")
+		if glob != "" {
+			normalizedGlobs = append(normalizedGlobs, glob)
+		}
+	}
+	sort.Strings(normalizedGlobs)
+	return normalizedGlobs
+}
+
+// Returns whether the scope covers the whole repository
+func (scope deploymentScope) isEmpty() bool {
+	return len(scope.includes) == 0 && len(scope.excludes) == 0 && len(scope.paths) == 0 && scope.projectDirs == nil
+}
+
+// Returns the id of the scope which the deployment state of the scope is kept against. Only the name given using
+//  --scope identifies a scope, so that editing the globs or the --path subtrees keeps the state (and the last
+//  successful revisions) of the scope. The whole repository has an empty id, so that the state of the repositories
+//  without scopes is kept as it was.
+func (scope deploymentScope) id() string {
+	return scope.name
+}
+
+// Returns the id which the state of the scope was kept against before the scopes were named, which was derived from
+//  the --path subtrees. This is used to migrate the state kept against those ids.
+func (scope deploymentScope) pathsId() string {
+	if len(scope.paths) == 0 {
+		return ""
+	}
+	hash := sha1.Sum([]byte("path:" + strings.Join(scope.paths, ",")))
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+// Returns the id which the state of the scope was kept against by the earlier versions, which also included the
+//  globs. This is used to migrate the state kept against those ids.
+func (scope deploymentScope) legacyId() string {
+	if len(scope.includes) == 0 && len(scope.excludes) == 0 && len(scope.paths) == 0 {
+		return ""
+	}
+	hash := sha1.Sum([]byte("include:" + strings.Join(scope.includes, ",") + "\nexclude:" +
+		strings.Join(scope.excludes, ",") + "\npath:" + strings.Join(scope.paths, ",")))
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+// Returns whether the project in the given path is in the scope
+// relativePath is the path of the project relative to the repository root
+func (scope deploymentScope) contains(relativePath string) bool {
+	if scope.isEmpty() {
+		return true
+	}
+	projectPath := strings.Trim(filepath.ToSlash(relativePath), "/")
+	if scope.projectDirs != nil {
+		return scope.projectDirs[strings.SplitN(projectPath, "/", 2)[0]]
+	}
+	if len(scope.includes) > 0 && !matchesAnyGlob(scope.includes, projectPath) {
+		return false
+	}
+	if len(scope.paths) > 0 && !isInAnySubtree(scope.paths, projectPath) {
+		return false
+	}
+	return !matchesAnyGlob(scope.excludes, projectPath)
+}
+
+// Returns whether any of the globs matches the path or any of its parent directories
+func matchesAnyGlob(globs []string, projectPath string) bool {
+	for _, glob := range globs {
+		for candidate := projectPath; candidate != "." && candidate != "/" && candidate != ""; candidate =
+			path.Dir(candidate) {
+			if matched, _ := path.Match(glob, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns whether the path is one of the subtrees or inside any of those
+func isInAnySubtree(subtrees []string, projectPath string) bool {
+	for _, subtree := range subtrees {
+		if projectPath == subtree || strings.HasPrefix(projectPath, subtree+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the key which the deployment state of the current working repository is kept against for the given
+//  environment. The state of each scope of a repository is kept separately, so that the scopes deployed from the same
+//  repository do not share the revisions or the failed projects. The deployment lock is not kept per scope, as the
+//  scopes of a repository are deployed to the same environment.
+// repoId is the id of the git repository (located in vcs.yaml)
+// environment is the environment name
+// scopeParams is the part of the repository which is deployed
+func getStateKey(repoId, environment string, scopeParams ScopeParams) string {
+	return getStateKeyOfScopeId(repoId, getDeploymentScope(environment, scopeParams).id())
+}
+
+// Returns the keys which the deployment state of the current working repository was kept against for the given
+//  environment by the earlier versions, in the order those should be tried. The keys which are the same as the current
+//  key are excluded. A named scope without --path subtrees was the whole repository earlier, hence it does not take
+//  over the state of the whole repository.
+// repoId is the id of the git repository (located in vcs.yaml)
+// environment is the environment name
+// scopeParams is the part of the repository which is deployed
+func getLegacyStateKeys(repoId, environment string, scopeParams ScopeParams) []string {
+	scope := getDeploymentScope(environment, scopeParams)
+	if scope.name != "" && len(scope.paths) == 0 {
+		return nil
+	}
+	var legacyStateKeys []string
+	for _, legacyId := range []string{scope.pathsId(), scope.legacyId()} {
+		if legacyId != "" && legacyId != scope.id() {
+			legacyStateKeys = append(legacyStateKeys, getStateKeyOfScopeId(repoId, legacyId))
+		}
+	}
+	return legacyStateKeys
+}
+
+// Returns the state key of the given scope of the repository
+func getStateKeyOfScopeId(repoId, scopeId string) string {
+	if scopeId == "" {
+		return repoId
+	}
+	return repoId + stateKeyScopeSeparator + scopeId
+}
+
+// Returns the id of the scope included in the given state key, or an empty string if the key is for the whole
+//  repository
+func getScopeIdOfStateKey(stateKey string) string {
+	if i := strings.Index(stateKey, stateKeyScopeSeparator); i >= 0 {
+		return stateKey[i+len(stateKeyScopeSeparator):]
+	}
+	return ""
+}
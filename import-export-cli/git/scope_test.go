@@ -0,0 +1,219 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestDeploymentScopeContains(t *testing.T) {
+	scope := deploymentScope{
+		includes: []string{"teams/payments/*"},
+		excludes: []string{"teams/payments/legacy"},
+	}
+	assert.True(t, scope.contains(filepath.Join("teams", "payments", "PizzaShack")))
+	assert.True(t, scope.contains(filepath.Join("teams", "payments", "apis", "PizzaShack")))
+	assert.False(t, scope.contains(filepath.Join("teams", "payments", "legacy", "PizzaShack")))
+	assert.False(t, scope.contains(filepath.Join("teams", "orders", "PizzaShack")))
+	assert.False(t, scope.contains("PizzaShack"))
+
+	scope.paths = []string{"teams/payments/apis"}
+	assert.True(t, scope.contains(filepath.Join("teams", "payments", "apis", "PizzaShack")))
+	assert.False(t, scope.contains(filepath.Join("teams", "payments", "PizzaShack")))
+	assert.False(t, scope.contains(filepath.Join("teams", "payments", "apisOther", "PizzaShack")))
+
+	assert.True(t, deploymentScope{}.contains("PizzaShack"), "an empty scope should contain all the projects")
+}
+
+func TestDeploymentScopeId(t *testing.T) {
+	assert.Equal(t, "", deploymentScope{}.id())
+	paymentsScope := deploymentScope{name: "payments", paths: []string{"teams/payments"}}
+	assert.Equal(t, "payments", paymentsScope.id())
+
+	// Editing the globs or the paths keeps the id, so that the state of the scope is retained
+	assert.Equal(t, paymentsScope.id(), paymentsScope.withFilters([]string{"teams/payments/*"}, nil,
+		[]string{"teams/payments", "teams/billing"}).id())
+	assert.Equal(t, "", deploymentScope{includes: []string{"teams/payments/*"}}.id())
+
+	// The id derived from the paths earlier does not depend on the name
+	assert.NotEqual(t, "", paymentsScope.pathsId())
+	assert.Equal(t, paymentsScope.pathsId(), deploymentScope{paths: []string{"teams/payments"}}.pathsId())
+	assert.NotEqual(t, paymentsScope.pathsId(), deploymentScope{paths: []string{"teams/orders"}}.pathsId())
+}
+
+func TestValidateScopeName(t *testing.T) {
+	assert.Nil(t, ValidateScopeName(""), "err should be nil")
+	assert.Nil(t, ValidateScopeName("team-payments_1"), "err should be nil")
+	assert.NotNil(t, ValidateScopeName("teams/payments"), "err should not be nil")
+	assert.NotNil(t, ValidateScopeName("payments.lock"), "err should not be nil")
+}
+
+func TestDeploymentRepoScopeContains(t *testing.T) {
+	scope := deploymentScope{projectDirs: map[string]bool{"Deployment_PizzaShack-1.0.0": true}}
+	assert.False(t, scope.isEmpty(), "a mapped scope should not be empty")
+	assert.True(t, scope.contains("Deployment_PizzaShack-1.0.0"))
+	assert.True(t, scope.contains(filepath.Join("Deployment_PizzaShack-1.0.0", "params.yaml")))
+	assert.False(t, scope.contains("Deployment_Petstore-1.0.0"))
+	assert.False(t, deploymentScope{projectDirs: map[string]bool{}}.contains("Deployment_PizzaShack-1.0.0"),
+		"a mapped scope without projects should not contain any project")
+}
+
+func TestDeploymentScopeHasSameFilters(t *testing.T) {
+	scope := deploymentScope{}.withFilters([]string{"teams/payments/*", "teams/orders/*"}, nil, nil)
+	assert.True(t, scope.hasSameFilters(deploymentScope{}.withFilters([]string{"/teams/orders/*", "teams/payments/*"},
+		nil, nil)), "the order and the slashes of the globs should be ignored")
+	assert.False(t, scope.hasSameFilters(deploymentScope{}.withFilters([]string{"teams/payments/*"}, nil, nil)))
+	assert.False(t, scope.hasSameFilters(scope.withFilters(scope.includes, []string{"teams/payments/legacy"}, nil)))
+
+	scope = scope.withFilters(scope.includes, nil, []string{"teams/payments", "teams/orders"})
+	assert.True(t, scope.hasSameFilters(scope.withFilters(scope.includes, nil, []string{"./teams/orders/",
+		"teams/payments"})), "the order and the form of the paths should be ignored")
+	assert.False(t, scope.hasSameFilters(scope.withFilters(scope.includes, nil, []string{"teams/payments"})))
+}
+
+func TestGetStateKey(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-scope")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	vcsYaml := "id: repo-1\nenvironments:\n  dev:\n    include:\n    - teams/payments/*\n"
+	assert.Nil(t, ioutil.WriteFile(VCSRepoInfoFileName, []byte(vcsYaml), 0644), "err should be nil")
+
+	// The globs do not change the key, as the state is kept per environment
+	assert.Equal(t, "repo-1", getStateKey("repo-1", "prod", ScopeParams{}))
+	assert.Equal(t, "repo-1", getStateKey("repo-1", "dev", ScopeParams{}))
+	assert.Equal(t, "refs/apictl/state/prod", getStateRefName("repo-1", "prod"))
+
+	// The state was kept against an id including the globs earlier
+	legacyKeys := getLegacyStateKeys("repo-1", "dev", ScopeParams{})
+	assert.Equal(t, 1, len(legacyKeys))
+	assert.Equal(t, 0, len(getLegacyStateKeys("repo-1", "prod", ScopeParams{})))
+
+	// Each named scope is kept separately, regardless of its paths
+	scopeParams := ScopeParams{Name: "payments", Paths: []string{"./teams/payments/apis/"}}
+	assert.Equal(t, []string{"teams/payments/apis"}, getDeploymentScope("dev", scopeParams).paths)
+	devKey := getStateKey("repo-1", "dev", scopeParams)
+	assert.Equal(t, "repo-1"+stateKeyScopeSeparator+"payments", devKey)
+	assert.Equal(t, devKey, getStateKey("repo-1", "prod", scopeParams))
+	assert.Equal(t, devKey, getStateKey("repo-1", "dev", ScopeParams{Name: "payments",
+		Paths: []string{"teams/payments/apis", "teams/billing"}}))
+	assert.Equal(t, "refs/apictl/scopes/payments/state/dev", getStateRefName(devKey, "dev"))
+
+	// The state of the paths was kept against an id derived from those (and the globs) earlier
+	pathsKey := "repo-1" + stateKeyScopeSeparator + getDeploymentScope("dev", scopeParams).pathsId()
+	assert.Equal(t, pathsKey, getLegacyStateKeys("repo-1", "dev", scopeParams)[0])
+	assert.Equal(t, 2, len(getLegacyStateKeys("repo-1", "dev", scopeParams)))
+	assert.Equal(t, 0, len(getLegacyStateKeys("repo-1", "dev", ScopeParams{Name: "payments"})),
+		"a named scope without paths should not take over the state of the whole repository")
+}
+
+func TestGetVCSEnvironmentDetailsMigratesLegacyState(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	defer func() { stateBackend = nil }()
+	tmpDir, _ := ioutil.TempDir("", "apictl-scope")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	vcsYaml := "id: repo-1\nenvironments:\n  dev:\n    include:\n    - teams/payments/*\n"
+	assert.Nil(t, ioutil.WriteFile(VCSRepoInfoFileName, []byte(vcsYaml), 0644), "err should be nil")
+
+	stateBackend = newGitStateBackend("")
+	assert.Nil(t, stateBackend.Save(getLegacyStateKeys("repo-1", "dev", ScopeParams{})[0], "dev",
+		Environment{LastAttemptedRev: "abc", LastSuccessfulRev: []string{"abc"}}), "err should be nil")
+
+	state, hasEnv := getVCSEnvironmentDetails("repo-1", "dev", ScopeParams{})
+	assert.True(t, hasEnv, "the state kept against the legacy key should be loaded")
+	assert.Equal(t, []string{"abc"}, state.LastSuccessfulRev)
+
+	// The state kept against the current key takes precedence once saved
+	assert.Nil(t, stateBackend.Save("repo-1", "dev", Environment{LastAttemptedRev: "def"}), "err should be nil")
+	state, hasEnv = getVCSEnvironmentDetails("repo-1", "dev", ScopeParams{})
+	assert.True(t, hasEnv, "the state should be available")
+	assert.Equal(t, "def", state.LastAttemptedRev)
+
+	// The state of the paths given before the scopes were named is taken over by the named scope
+	scopeParams := ScopeParams{Name: "payments", Paths: []string{"teams/payments"}}
+	pathsKey := "repo-1" + stateKeyScopeSeparator + getDeploymentScope("dev", scopeParams).pathsId()
+	assert.Nil(t, stateBackend.Save(pathsKey, "dev", Environment{LastAttemptedRev: "ghi"}), "err should be nil")
+	state, hasEnv = getVCSEnvironmentDetails("repo-1", "dev", scopeParams)
+	assert.True(t, hasEnv, "the state kept against the paths should be loaded")
+	assert.Equal(t, "ghi", state.LastAttemptedRev)
+}
+
+func TestGitStateBackendScopes(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-scope")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	backend := newGitStateBackend("")
+	paymentsKey := "repo-1" + stateKeyScopeSeparator + "payments"
+	ordersKey := "repo-1" + stateKeyScopeSeparator + "orders"
+	assert.Nil(t, backend.Save(paymentsKey, "dev", Environment{LastAttemptedRev: "abc"}), "err should be nil")
+	assert.Nil(t, backend.Save(ordersKey, "dev", Environment{LastAttemptedRev: "def"}), "err should be nil")
+
+	state, _, err := newGitStateBackend("").Load(paymentsKey, "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "abc", state.LastAttemptedRev)
+	state, _, err = newGitStateBackend("").Load(ordersKey, "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "def", state.LastAttemptedRev)
+	_, hasEnv, err := newGitStateBackend("").Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, hasEnv, "the state of the scopes should not be shared with the whole repository")
+}
+
+func TestAddProjectsNewInScope(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-scope")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	commitTestFiles(t, map[string]string{
+		"teams/payments/PizzaShack/" + utils.MetaFileAPI: "name: PizzaShack\nversion: 1.0.0\n",
+		"teams/orders/Orders/" + utils.MetaFileAPI:       "name: Orders\nversion: 1.0.0\n",
+		"teams/orders/Shipping/" + utils.MetaFileAPI:     "name: Shipping\nversion: 1.0.0\n",
+	}, "add")
+	basePath, err := getRepoBaseDir()
+	assert.Nil(t, err, "err should be nil")
+
+	previousScope := deploymentScope{}.withFilters([]string{"teams/payments/*"}, nil, nil)
+	scope := deploymentScope{}.withFilters([]string{"teams/payments/*", "teams/orders/*"}, nil, nil)
+	updatedProjectsPerType := make(map[string][]*params.ProjectParams)
+	updatedProjectsPerProjectPath := make(map[string]*params.ProjectParams)
+	shipping := &params.ProjectParams{Type: utils.ProjectTypeApi,
+		AbsolutePath: filepath.Join(basePath, "teams", "orders", "Shipping")}
+	updatedProjectsPerProjectPath[shipping.AbsolutePath] = shipping
+	updatedProjectsPerType[utils.ProjectTypeApi] = []*params.ProjectParams{shipping}
+
+	// Only the projects which were out of the previous scope and not changed already are added
+	assert.Equal(t, 1, addProjectsNewInScope(Environment{}, scope, previousScope, basePath, updatedProjectsPerType,
+		updatedProjectsPerProjectPath))
+	assert.Equal(t, 2, len(updatedProjectsPerType[utils.ProjectTypeApi]))
+	assert.Equal(t, "Orders", updatedProjectsPerType[utils.ProjectTypeApi][1].NickName)
+}
@@ -0,0 +1,520 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// StateBackend keeps the deployment state (last attempted revision, last successful revisions and failed projects) of
+//  each environment of the repositories, along with the deployment locks of those. The repoId given to the methods is
+//  the state key of the repository, which includes the id of the scope if the repository is deployed in scopes.
+type StateBackend interface {
+	// Load returns the deployment state of the given environment of a repository, and whether the state is available
+	Load(repoId, environment string) (Environment, bool, error)
+	// Save persists the deployment state of the given environment of a repository
+	Save(repoId, environment string, envVCSConfig Environment) error
+	// AcquireLock acquires the deployment lock of the given environment of a repository. Returns *lockHeldError if
+	//  the lock is held by another deployment and is not expired yet
+	AcquireLock(repoId, environment string, lock *DeploymentLock) error
+	// ReleaseLock releases the deployment lock of the given environment of a repository if it is still held by lock
+	ReleaseLock(repoId, environment string, lock *DeploymentLock) error
+	// ClearLock releases the deployment lock of the given environment of a repository regardless of its holder, and
+	//  returns the released lock (nil if the environment was not locked)
+	ClearLock(repoId, environment string) (*DeploymentLock, error)
+}
+
+// stateConflictError is returned when the deployment state was changed by another deployment after it was loaded
+type stateConflictError struct {
+	environment string
+}
+
+func (e *stateConflictError) Error() string {
+	return "the deployment state of the environment " + e.environment + " was changed by another deployment " +
+		"after this deployment started. Refusing to overwrite it"
+}
+
+// errRefChanged is returned when a git ref is not updated as it was changed after it was read
+var errRefChanged = errors.New("the ref was changed after it was read")
+
+// the state backend of the current execution. It is created once, so that the optimistic locking of the git state
+//  backend can span through the whole deployment.
+var stateBackend StateBackend
+
+// Returns the state backend configured in the main configuration (vcs_state_backend). Defaults to the file backend.
+func getStateBackend() StateBackend {
+	if stateBackend != nil {
+		return stateBackend
+	}
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	switch mainConfig.Config.VCSStateBackend {
+	case "", utils.VCSStateBackendFile:
+		if mainConfig.Config.VCSConfigFilePath != "" {
+			VCSConfigFilePath = mainConfig.Config.VCSConfigFilePath
+		}
+		stateBackend = &fileStateBackend{filePath: VCSConfigFilePath}
+	case utils.VCSStateBackendGit:
+		stateBackend = newGitStateBackend(mainConfig.Config.VCSStateRemote)
+	default:
+		utils.HandleErrorAndExit("Invalid VCS state backend: "+mainConfig.Config.VCSStateBackend, nil)
+	}
+	return stateBackend
+}
+
+// fileStateBackend keeps the deployment state of all the repositories in the local VCS config file (vcs_config.yaml)
+type fileStateBackend struct {
+	filePath string
+}
+
+func (b *fileStateBackend) Load(repoId, environment string) (Environment, bool, error) {
+	vcsConfig := getVCSConfigFromFileSilently(b.filePath)
+	envVCSConfig, hasEnv := vcsConfig.Repos[repoId].Environments[environment]
+	return envVCSConfig, hasEnv, nil
+}
+
+func (b *fileStateBackend) Save(repoId, environment string, envVCSConfig Environment) error {
+	vcsConfig := getVCSConfigFromFileSilently(b.filePath)
+	setEnvironmentState(vcsConfig, repoId, environment, envVCSConfig)
+	utils.WriteConfigFile(vcsConfig, b.filePath)
+	return nil
+}
+
+// Returns the path of the file which keeps the deployment lock of the given environment of a repository
+func (b *fileStateBackend) getLockFilePath(repoId, environment string) string {
+	return filepath.Join(filepath.Dir(b.filePath), VCSLocksDirName, repoId+"_"+environment+".yaml")
+}
+
+func (b *fileStateBackend) AcquireLock(repoId, environment string, lock *DeploymentLock) error {
+	lockFilePath := b.getLockFilePath(repoId, environment)
+	if err := os.MkdirAll(filepath.Dir(lockFilePath), os.ModePerm); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	// An expired lock is removed and the lock is acquired again
+	for attempt := 0; attempt < 2; attempt++ {
+		// O_EXCL makes the creation fail if the lock file was created by another deployment
+		lockFile, err := os.OpenFile(lockFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = lockFile.Write(content)
+			if closeErr := lockFile.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+		heldLock, err := readLockFile(lockFilePath)
+		if err != nil {
+			return err
+		}
+		if heldLock != nil && !heldLock.isExpired() {
+			return &lockHeldError{environment: environment, lock: heldLock}
+		}
+		if heldLock != nil {
+			utils.Logln(utils.LogPrefixWarning + "Taking over the expired deployment lock of " + heldLock.String())
+		}
+		if err := os.Remove(lockFilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return errors.New("unable to acquire the deployment lock of " + environment)
+}
+
+func (b *fileStateBackend) ReleaseLock(repoId, environment string, lock *DeploymentLock) error {
+	lockFilePath := b.getLockFilePath(repoId, environment)
+	heldLock, err := readLockFile(lockFilePath)
+	if err != nil || heldLock == nil || heldLock.Id != lock.Id {
+		return err
+	}
+	return os.Remove(lockFilePath)
+}
+
+func (b *fileStateBackend) ClearLock(repoId, environment string) (*DeploymentLock, error) {
+	lockFilePath := b.getLockFilePath(repoId, environment)
+	heldLock, err := readLockFile(lockFilePath)
+	if err != nil || heldLock == nil {
+		return nil, err
+	}
+	return heldLock, os.Remove(lockFilePath)
+}
+
+// Reads a lock file. Returns nil if the lock file does not exist.
+func readLockFile(lockFilePath string) (*DeploymentLock, error) {
+	content, err := ioutil.ReadFile(lockFilePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lock DeploymentLock
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, errors.New("unable to parse the deployment lock " + lockFilePath + ": " + err.Error())
+	}
+	return &lock, nil
+}
+
+// gitStateBackend keeps the deployment state of each environment in a dedicated ref (refs/apictl/state/<environment>)
+//  of the repository itself, so that the state is shared wherever the repository is cloned. If a remote is given, the
+//  state ref is fetched from the remote before reading and pushed to the remote after writing.
+//  Concurrent deployments are detected using optimistic locking: the state is only written if the ref still points to
+//  the commit which was read at the beginning of the deployment. A deployment begins when the deployment lock is
+//  acquired, hence the state is read afresh after that (ie: for each run of vcs watch).
+type gitStateBackend struct {
+	remote string
+	// repoId/environment -> commit of the state ref when it was first loaded ("" if the ref did not exist)
+	loadedCommits map[string]string
+}
+
+func newGitStateBackend(remote string) *gitStateBackend {
+	return &gitStateBackend{
+		remote:        remote,
+		loadedCommits: make(map[string]string),
+	}
+}
+
+// Returns the name of the ref which keeps the deployment state of the given environment. The state of a scope of the
+//  repository is kept in a separate ref (refs/apictl/scopes/<scope id>/state/<environment>).
+func getStateRefName(stateKey, environment string) string {
+	return getScopeRefPrefix(stateKey) + "state/" + environment
+}
+
+// Returns the name of the ref which keeps the deployment lock of the given environment
+func getLockRefName(stateKey, environment string) string {
+	return getScopeRefPrefix(stateKey) + "lock/" + environment
+}
+
+// Returns the prefix of the refs which keep the state of the scope of the given state key
+func getScopeRefPrefix(stateKey string) string {
+	if scopeId := getScopeIdOfStateKey(stateKey); scopeId != "" {
+		return "refs/apictl/scopes/" + scopeId + "/"
+	}
+	return "refs/apictl/"
+}
+
+func (b *gitStateBackend) Load(repoId, environment string) (Environment, bool, error) {
+	refName := getStateRefName(repoId, environment)
+	commit, err := b.getRefCommit(refName)
+	if err != nil {
+		return Environment{}, false, err
+	}
+	key := repoId + "/" + environment
+	if _, loaded := b.loadedCommits[key]; !loaded {
+		b.loadedCommits[key] = commit
+	}
+	if commit == "" {
+		return Environment{}, false, nil
+	}
+
+	content, err := readFileOfCommit(commit, VCSConfigFileName)
+	if err != nil {
+		return Environment{}, false, err
+	}
+	var vcsConfig VCSConfig
+	if err := yaml.Unmarshal([]byte(content), &vcsConfig); err != nil {
+		return Environment{}, false, errors.New("unable to parse the deployment state in " + refName + ": " +
+			err.Error())
+	}
+	envVCSConfig, hasEnv := vcsConfig.Repos[repoId].Environments[environment]
+	return envVCSConfig, hasEnv, nil
+}
+
+func (b *gitStateBackend) Save(repoId, environment string, envVCSConfig Environment) error {
+	refName := getStateRefName(repoId, environment)
+	key := repoId + "/" + environment
+	expectedCommit, loaded := b.loadedCommits[key]
+	if !loaded {
+		if _, _, err := b.Load(repoId, environment); err != nil {
+			return err
+		}
+		expectedCommit = b.loadedCommits[key]
+	}
+
+	// The state ref may have been updated by a concurrent deployment which is not pushed to the remote yet
+	currentCommit, err := b.getRefCommit(refName)
+	if err != nil {
+		return err
+	}
+	if currentCommit != expectedCommit {
+		return &stateConflictError{environment: environment}
+	}
+
+	vcsConfig := &VCSConfig{}
+	setEnvironmentState(vcsConfig, repoId, environment, envVCSConfig)
+	content, err := yaml.Marshal(vcsConfig)
+	if err != nil {
+		return err
+	}
+	newCommit, err := createFileCommit(VCSConfigFileName, content, "Update the deployment state of "+environment,
+		expectedCommit)
+	if err != nil {
+		return err
+	}
+	if err := b.updateRef(refName, newCommit, expectedCommit); err != nil {
+		if err == errRefChanged {
+			return &stateConflictError{environment: environment}
+		}
+		return err
+	}
+	b.loadedCommits[key] = newCommit
+	return nil
+}
+
+func (b *gitStateBackend) AcquireLock(repoId, environment string, lock *DeploymentLock) error {
+	refName := getLockRefName(repoId, environment)
+	currentCommit, err := b.getRefCommit(refName)
+	if err != nil {
+		return err
+	}
+	if currentCommit != "" {
+		heldLock, err := readLockCommit(currentCommit)
+		if err != nil {
+			return err
+		}
+		if !heldLock.isExpired() {
+			return &lockHeldError{environment: environment, lock: heldLock}
+		}
+		utils.Logln(utils.LogPrefixWarning + "Taking over the expired deployment lock of " + heldLock.String())
+	}
+
+	content, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	newCommit, err := createFileCommit(VCSLockFileName, content, "Lock "+environment, "")
+	if err != nil {
+		return err
+	}
+	if err := b.updateRef(refName, newCommit, currentCommit); err != nil {
+		if err != errRefChanged {
+			return err
+		}
+		// Another deployment acquired the lock after it was read
+		commit, err := b.getRefCommit(refName)
+		if err != nil || commit == "" {
+			return errors.New("unable to acquire the deployment lock of " + environment)
+		}
+		heldLock, err := readLockCommit(commit)
+		if err != nil {
+			return err
+		}
+		return &lockHeldError{environment: environment, lock: heldLock}
+	}
+	b.forgetLoadedCommits(environment)
+	return nil
+}
+
+// Forgets the commits of the state refs of the given environment loaded so far, so that the state written by the
+//  other deployments since then does not conflict with the deployment which is about to begin
+func (b *gitStateBackend) forgetLoadedCommits(environment string) {
+	for key := range b.loadedCommits {
+		if strings.HasSuffix(key, "/"+environment) {
+			delete(b.loadedCommits, key)
+		}
+	}
+}
+
+func (b *gitStateBackend) ReleaseLock(repoId, environment string, lock *DeploymentLock) error {
+	refName := getLockRefName(repoId, environment)
+	currentCommit, err := b.getRefCommit(refName)
+	if err != nil || currentCommit == "" {
+		return err
+	}
+	heldLock, err := readLockCommit(currentCommit)
+	if err != nil || heldLock.Id != lock.Id {
+		return err
+	}
+	return b.updateRef(refName, "", currentCommit)
+}
+
+func (b *gitStateBackend) ClearLock(repoId, environment string) (*DeploymentLock, error) {
+	refName := getLockRefName(repoId, environment)
+	currentCommit, err := b.getRefCommit(refName)
+	if err != nil || currentCommit == "" {
+		return nil, err
+	}
+	// The lock is cleared even if it cannot be read
+	heldLock, _ := readLockCommit(currentCommit)
+	return heldLock, b.updateRef(refName, "", currentCommit)
+}
+
+// Reads the deployment lock kept in the given commit of a lock ref
+func readLockCommit(commit string) (*DeploymentLock, error) {
+	content, err := readFileOfCommit(commit, VCSLockFileName)
+	if err != nil {
+		return nil, err
+	}
+	var lock DeploymentLock
+	if err := yaml.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, errors.New("unable to parse the deployment lock in " + commit + ": " + err.Error())
+	}
+	return &lock, nil
+}
+
+// The state refs are read and the commits of those are created in-process. The git binary is still used for:
+//  - updating the refs, as "git update-ref" compares and swaps a ref atomically (including creating a ref only if it
+//    does not exist, and deleting a ref only if it is at the expected commit) using the lock files of git. The
+//    deployment locks rely on that, which go-git does not support as it does not lock the refs the same way as git
+//    and does not compare the old value when creating or deleting a ref.
+//  - accessing the remotes, so that the credential helpers, the SSH configuration and the proxy settings of the user
+//    are used as with any other git command.
+
+// Returns the commit the given ref points to, or an empty string if the ref does not exist. If a remote is
+//  configured, the ref is taken from the remote as it is the source of truth for the state.
+func (b *gitStateBackend) getRefCommit(refName string) (string, error) {
+	if b.remote == "" {
+		return getLocalRefCommit(refName)
+	}
+
+	remoteRefs, err := executeGitCommand("ls-remote", b.remote, refName)
+	if err != nil {
+		return "", errors.New("unable to read " + refName + " from the remote " + b.remote + ": " + err.Error())
+	}
+	fields := strings.Fields(remoteRefs)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	if _, err := executeGitCommand("fetch", "--quiet", b.remote, "+"+refName+":"+refName); err != nil {
+		return "", errors.New("unable to fetch " + refName + " from the remote " + b.remote + ": " + err.Error())
+	}
+	return fields[0], nil
+}
+
+// Points the given ref to newCommit only if it still points to expectedCommit (an empty value expects the ref to not
+//  exist). If newCommit is empty, the ref is deleted. If a remote is configured, the ref is updated in the remote first.
+// Returns errRefChanged if the ref does not point to expectedCommit anymore
+func (b *gitStateBackend) updateRef(refName, newCommit, expectedCommit string) error {
+	if b.remote != "" {
+		// --force-with-lease makes the push fail if the remote ref is not at the expected commit
+		var pushErrors bytes.Buffer
+		if _, err := runGitCommand(&pushErrors, "push", "--quiet", b.remote,
+			"--force-with-lease="+refName+":"+expectedCommit, newCommit+":"+refName); err != nil {
+			if isLeaseRejection(pushErrors.String()) {
+				return errRefChanged
+			}
+			return errors.New("unable to push " + refName + " to the remote " + b.remote + ": " + err.Error() + ": " +
+				strings.TrimSpace(pushErrors.String()))
+		}
+		if newCommit == "" {
+			_, err := executeGitCommandSilently("update-ref", "-d", refName)
+			return err
+		}
+		_, err := executeGitCommand("update-ref", refName, newCommit)
+		return err
+	}
+	var err error
+	if newCommit == "" {
+		_, err = executeGitCommandSilently("update-ref", "-d", refName, expectedCommit)
+	} else {
+		_, err = executeGitCommandSilently("update-ref", refName, newCommit, expectedCommit)
+	}
+	if err != nil {
+		// update-ref fails if the ref is not at the expected commit
+		return errRefChanged
+	}
+	return nil
+}
+
+// Returns whether the errors of a push show that a ref was rejected as it was changed in the remote, either because
+//  it is not at the commit expected by --force-with-lease (stale info), or because it was locked by another push
+//  updating it at the same time
+// pushErrors is the error output of the push command
+func isLeaseRejection(pushErrors string) bool {
+	return strings.Contains(pushErrors, "(stale info)") || strings.Contains(pushErrors, "cannot lock ref")
+}
+
+// Creates a commit (without updating any branch) which only contains a single file with the given content
+// parent is the parent commit of the new commit, or an empty string to create a root commit
+// Returns string, the id of the created commit
+func createFileCommit(fileName string, content []byte, message, parent string) (string, error) {
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", err
+	}
+	blob := repo.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	blobHash, err := repo.Storer.SetEncodedObject(blob)
+	if err != nil {
+		return "", err
+	}
+
+	tree := &object.Tree{Entries: []object.TreeEntry{{Name: fileName, Mode: filemode.Regular, Hash: blobHash}}}
+	treeObject := repo.Storer.NewEncodedObject()
+	if err := tree.Encode(treeObject); err != nil {
+		return "", err
+	}
+	treeHash, err := repo.Storer.SetEncodedObject(treeObject)
+	if err != nil {
+		return "", err
+	}
+
+	signature := object.Signature{Name: utils.ProjectName, Email: utils.ProjectName + "@localhost", When: time.Now()}
+	commit := &object.Commit{Author: signature, Committer: signature, Message: message, TreeHash: treeHash}
+	if parent != "" {
+		commit.ParentHashes = []plumbing.Hash{plumbing.NewHash(parent)}
+	}
+	commitObject := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(commitObject); err != nil {
+		return "", err
+	}
+	commitHash, err := repo.Storer.SetEncodedObject(commitObject)
+	if err != nil {
+		return "", err
+	}
+	return commitHash.String(), nil
+}
+
+// Sets the deployment state of the given environment of a repository in the VCS configuration
+func setEnvironmentState(vcsConfig *VCSConfig, repoId, environment string, envVCSConfig Environment) {
+	if vcsConfig.Repos == nil {
+		vcsConfig.Repos = make(map[string]Repo)
+	}
+	if _, hasRepo := vcsConfig.Repos[repoId]; !hasRepo || vcsConfig.Repos[repoId].Environments == nil {
+		vcsConfig.Repos[repoId] = Repo{
+			Environments: map[string]Environment{},
+		}
+	}
+	vcsConfig.Repos[repoId].Environments[environment] = envVCSConfig
+}
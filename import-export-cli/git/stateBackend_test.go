@@ -0,0 +1,189 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates an empty git repository in a temporary directory and changes the current directory into it
+func initStateTestRepo(t *testing.T, dir string) {
+	assert.Nil(t, os.MkdirAll(dir, os.ModePerm), "err should be nil")
+	assert.Nil(t, os.Chdir(dir), "err should be nil")
+	_, err := executeGitCommand("init", "--quiet")
+	assert.Nil(t, err, "err should be nil")
+}
+
+func TestGitStateBackendSaveAndLoad(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-state")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	backend := newGitStateBackend("")
+	_, hasEnv, err := backend.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, hasEnv, "state should not be available before the first deployment")
+
+	state := Environment{LastAttemptedRev: "abc", LastSuccessfulRev: []string{"abc"}}
+	assert.Nil(t, backend.Save("repo-1", "dev", state), "err should be nil")
+	// A second save within the same deployment is allowed
+	state.LastAttemptedRev = "def"
+	assert.Nil(t, backend.Save("repo-1", "dev", state), "err should be nil")
+
+	loadedState, hasEnv, err := newGitStateBackend("").Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, hasEnv, "state should be available after saving")
+	assert.Equal(t, state.LastAttemptedRev, loadedState.LastAttemptedRev)
+	assert.Equal(t, state.LastSuccessfulRev, loadedState.LastSuccessfulRev)
+}
+
+func TestGitStateBackendConcurrentDeployments(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-state")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	firstDeployment := newGitStateBackend("")
+	secondDeployment := newGitStateBackend("")
+	_, _, err := firstDeployment.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	_, _, err = secondDeployment.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Nil(t, firstDeployment.Save("repo-1", "dev", Environment{LastAttemptedRev: "abc"}), "err should be nil")
+	err = secondDeployment.Save("repo-1", "dev", Environment{LastAttemptedRev: "def"})
+	assert.IsType(t, &stateConflictError{}, err)
+
+	// The state of the other environments is not affected
+	assert.Nil(t, secondDeployment.Save("repo-1", "prod", Environment{LastAttemptedRev: "def"}),
+		"err should be nil")
+}
+
+func TestGitStateBackendReloadsStateForEachDeployment(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-state")
+	defer os.RemoveAll(tmpDir)
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+
+	// a long running process (ie: vcs watch) deploys once, then another deployment writes the state
+	watcher := newGitStateBackend("")
+	_, _, err := watcher.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, watcher.Save("repo-1", "dev", Environment{LastAttemptedRev: "abc"}), "err should be nil")
+	assert.Nil(t, newGitStateBackend("").Save("repo-1", "dev", Environment{LastAttemptedRev: "def"}),
+		"err should be nil")
+	assert.IsType(t, &stateConflictError{}, watcher.Save("repo-1", "dev", Environment{LastAttemptedRev: "ghi"}))
+
+	// the next deployment of the process begins by acquiring the lock, after which the state is read afresh
+	lock := newDeploymentLock(time.Minute)
+	assert.Nil(t, watcher.AcquireLock("repo-1", "dev", lock), "err should be nil")
+	defer watcher.ReleaseLock("repo-1", "dev", lock)
+	state, _, err := watcher.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "def", state.LastAttemptedRev)
+	assert.Nil(t, watcher.Save("repo-1", "dev", Environment{LastAttemptedRev: "ghi"}), "err should be nil")
+}
+
+func TestGitStateBackendWithRemote(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-state")
+	defer os.RemoveAll(tmpDir)
+	remotePath := filepath.Join(tmpDir, "remote.git")
+	_, err := executeGitCommand("init", "--quiet", "--bare", remotePath)
+	assert.Nil(t, err, "err should be nil")
+
+	firstClone := filepath.Join(tmpDir, "first")
+	secondClone := filepath.Join(tmpDir, "second")
+	for _, clone := range []string{firstClone, secondClone} {
+		initStateTestRepo(t, clone)
+		_, err = executeGitCommand("remote", "add", "origin", remotePath)
+		assert.Nil(t, err, "err should be nil")
+	}
+
+	// Both runners start deploying before any of those saves the state
+	assert.Nil(t, os.Chdir(firstClone), "err should be nil")
+	firstRunner := newGitStateBackend("origin")
+	_, _, err = firstRunner.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, os.Chdir(secondClone), "err should be nil")
+	secondRunner := newGitStateBackend("origin")
+	_, _, err = secondRunner.Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Nil(t, os.Chdir(firstClone), "err should be nil")
+	assert.Nil(t, firstRunner.Save("repo-1", "dev", Environment{LastAttemptedRev: "abc"}), "err should be nil")
+
+	assert.Nil(t, os.Chdir(secondClone), "err should be nil")
+	err = secondRunner.Save("repo-1", "dev", Environment{LastAttemptedRev: "def"})
+	assert.IsType(t, &stateConflictError{}, err)
+
+	// A new runner reads the state pushed by the first runner
+	state, hasEnv, err := newGitStateBackend("origin").Load("repo-1", "dev")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, hasEnv, "state should be available in the remote")
+	assert.Equal(t, "abc", state.LastAttemptedRev)
+}
+
+func TestGitStateBackendUpdateRefWithRemote(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-state")
+	defer os.RemoveAll(tmpDir)
+	remotePath := filepath.Join(tmpDir, "remote.git")
+	_, err := executeGitCommand("init", "--quiet", "--bare", remotePath)
+	assert.Nil(t, err, "err should be nil")
+	initStateTestRepo(t, filepath.Join(tmpDir, "repo"))
+	_, err = executeGitCommand("remote", "add", "origin", remotePath)
+	assert.Nil(t, err, "err should be nil")
+
+	backend := newGitStateBackend("origin")
+	refName := "refs/apictl/test"
+	firstCommit, err := createFileCommit(VCSConfigFileName, []byte("first"), "first", "")
+	assert.Nil(t, err, "err should be nil")
+	secondCommit, err := createFileCommit(VCSConfigFileName, []byte("second"), "second", "")
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, backend.updateRef(refName, firstCommit, ""), "err should be nil")
+
+	// The remote rejects the push as the ref is not at the expected commit
+	assert.Equal(t, errRefChanged, backend.updateRef(refName, secondCommit, ""))
+
+	// Any other failure of the push is returned as it is, as the ref may not have been changed
+	assert.Nil(t, os.RemoveAll(remotePath), "err should be nil")
+	err = backend.updateRef(refName, secondCommit, firstCommit)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.NotEqual(t, errRefChanged, err)
+	assert.Contains(t, err.Error(), "unable to push "+refName)
+}
+
+func TestIsLeaseRejection(t *testing.T) {
+	assert.True(t, isLeaseRejection(" ! [rejected]        abc -> refs/apictl/test (stale info)"))
+	assert.True(t, isLeaseRejection(" ! [remote rejected] abc -> refs/apictl/test (cannot lock ref 'refs/apictl/test')"))
+	assert.False(t, isLeaseRejection("fatal: unable to access 'https://example.com/repo.git/': Could not resolve host"))
+}
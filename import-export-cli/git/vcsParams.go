@@ -10,6 +10,13 @@ type Environment struct {
     LastAttemptedRev  string                             `yaml:"lastAttemptedRev"`
     LastSuccessfulRev []string                           `yaml:"lastSuccessfulRev"`
     FailedProjects    map[string][]*params.ProjectParams `yaml:"failedProjects"`
+    // projects which were not deployed as the projects those depend on were failed
+    BlockedProjects   map[string][]*params.ProjectParams `yaml:"blockedProjects,omitempty"`
+    // include and exclude globs of the environment (in vcs.yaml) when the environment was deployed last
+    Include           []string                           `yaml:"include,omitempty"`
+    Exclude           []string                           `yaml:"exclude,omitempty"`
+    // --path subtrees of the scope when the environment was deployed last
+    Paths             []string                           `yaml:"paths,omitempty"`
 }
 
 type Repo struct {
@@ -17,5 +24,14 @@ type Repo struct {
 }
 
 type RepoInfo struct {
-    Id string `yaml:"id"`
+    Id           string                         `yaml:"id"`
+    Environments map[string]RepoInfoEnvironment `yaml:"environments,omitempty"`
+}
+
+// RepoInfoEnvironment limits the projects of the repository which are deployed to an environment
+type RepoInfoEnvironment struct {
+    Include []string `yaml:"include,omitempty"`
+    Exclude []string `yaml:"exclude,omitempty"`
+    // Microgateway adapter environments (mgw-clusters) which the API projects are deployed to by default
+    Microgateways []string `yaml:"microgateways,omitempty"`
 }
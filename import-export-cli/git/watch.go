@@ -0,0 +1,328 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Statuses of a run of the watcher
+const (
+	WatchStatusStarting = "starting"
+	WatchStatusUpToDate = "up-to-date"
+	WatchStatusDeployed = "deployed"
+	WatchStatusFailed   = "failed"
+	WatchStatusError    = "error"
+)
+
+// WatchStatus is the status of the last run of the watcher, which is exposed on the health endpoint
+type WatchStatus struct {
+	Environment         string     `json:"environment"`
+	Status              string     `json:"status"`
+	LastRunTime         *time.Time `json:"lastRunTime,omitempty"`
+	LastSuccessTime     *time.Time `json:"lastSuccessTime,omitempty"`
+	NextRunTime         *time.Time `json:"nextRunTime,omitempty"`
+	SourceRevision      string     `json:"sourceRevision,omitempty"`
+	DeploymentRevision  string     `json:"deploymentRevision,omitempty"`
+	FailedProjects      []string   `json:"failedProjects,omitempty"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	Error               string     `json:"error,omitempty"`
+}
+
+// Watcher polls the source and the deployment repositories, and deploys the changed projects to an environment
+//  whenever the HEAD of any of those moves. A failed run is retried with an exponential backoff, and the projects
+//  failed during a run are retried as tracked in the deployment state (FailedProjects).
+type Watcher struct {
+	environment    string
+	scopeParams    ScopeParams
+	interval       time.Duration
+	maxBackoff     time.Duration
+	parallel       int
+	getAccessToken func() (string, error)
+
+	status WatchStatus
+	lock   sync.Mutex
+}
+
+// Returns a watcher of the given environment
+// environment is the environment name
+// scopeParams is the part of the repositories which is deployed
+// interval is the time between two polls of the repositories
+// maxBackoff is the maximum time to wait before retrying a failed run
+// parallel is the maximum number of projects of the same type that are deployed at the same time
+// getAccessToken returns the access token to access the APIM product REST APIs, which is called for every deployment
+//  so that an expired token is renewed
+func NewWatcher(environment string, scopeParams ScopeParams, interval, maxBackoff time.Duration, parallel int,
+	getAccessToken func() (string, error)) *Watcher {
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+	return &Watcher{
+		environment:    environment,
+		scopeParams:    scopeParams,
+		interval:       interval,
+		maxBackoff:     maxBackoff,
+		parallel:       parallel,
+		getAccessToken: getAccessToken,
+		status:         WatchStatus{Environment: environment, Status: WatchStatusStarting},
+	}
+}
+
+// Polls the repositories until the stop channel is closed
+// stop is the channel which is closed to stop watching
+func (w *Watcher) Run(stop <-chan struct{}) {
+	for {
+		deployed, err := w.runOnce()
+		nextRun := w.recordRun(deployed, err)
+		if err != nil {
+			fmt.Println("Error... " + err.Error())
+		}
+		fmt.Println("Next run at " + nextRun.Format(time.RFC3339))
+		select {
+		case <-stop:
+			return
+		case <-time.After(time.Until(nextRun)):
+		}
+	}
+}
+
+// Pulls the repositories and deploys the changed projects if any of the repositories moved since the last successful
+//  run. The errors are returned, so that the run can be retried.
+// Returns bool, true if the changed projects were deployed during the run
+func (w *Watcher) runOnce() (bool, error) {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	defer changeDirectoryToSourceRepo(mainConfig)
+	sourceRevision, err := pullRepository(mainConfig.Config.VCSSourceRepoPath)
+	if err != nil {
+		return false, err
+	}
+	var deploymentRevision string
+	if mainConfig.Config.VCSDeploymentRepoPath != "" {
+		deploymentRevision, err = pullRepository(mainConfig.Config.VCSDeploymentRepoPath)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	status := w.getStatus()
+	if status.ConsecutiveFailures == 0 && len(status.FailedProjects) == 0 &&
+		status.SourceRevision == sourceRevision && status.DeploymentRevision == deploymentRevision {
+		utils.Logln(utils.LogPrefixInfo + "No new revisions in the repositories")
+		return false, nil
+	}
+
+	accessToken, err := w.getAccessToken()
+	if err != nil {
+		return false, errors.New("unable to get an access token for deploying the project(s): " + err.Error())
+	}
+	fmt.Println("\n" + time.Now().Format(time.RFC3339) + ": Deploying " + shortRevision(sourceRevision) +
+		" to " + w.environment)
+	// a protected environment is not deployed by the watcher when there are changes which need an approval
+	if changes, _ := GetChangesRequiringApproval(w.environment, w.scopeParams); len(changes) > 0 {
+		var descriptions []string
+		for _, change := range changes {
+			descriptions = append(descriptions, change.String())
+		}
+		return false, errors.New(strconv.Itoa(len(changes)) + " change(s) need an approval. Deploy those using '" +
+			utils.ProjectName + " vcs deploy': " + strings.Join(descriptions, ", "))
+	}
+	failedProjects, err := w.deploy(accessToken)
+	if err != nil {
+		return true, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.status.SourceRevision = sourceRevision
+	w.status.DeploymentRevision = deploymentRevision
+	w.status.FailedProjects = getFailedProjectNames(failedProjects)
+	if len(w.status.FailedProjects) > 0 {
+		return true, errors.New(strconv.Itoa(len(w.status.FailedProjects)) + " project(s) failed to deploy: " +
+			strings.Join(w.status.FailedProjects, ", "))
+	}
+	return true, nil
+}
+
+// Deploys the changed projects while holding the lock of the environment, and rolls the environment back if the
+//  post-deploy hooks of any of the projects requested it
+// accessToken is the access token to access the APIM product REST APIs
+// Returns map[string][]*params.ProjectParams, a map of project type -> projects failed during the deployment
+func (w *Watcher) deploy(accessToken string) (map[string][]*params.ProjectParams, error) {
+	releaseLock := LockEnvironment(w.environment)
+	defer releaseLock()
+	failedProjects, rollbackRequested, err := DeployChangedFiles(accessToken, w.environment, w.scopeParams,
+		w.parallel)
+	if err != nil {
+		return failedProjects, err
+	}
+	if rollbackRequested {
+		fmt.Println("\nRolling back to the last successful revision as the post-deploy hooks failed..")
+		if err := Rollback(accessToken, w.environment, w.scopeParams, w.parallel); err != nil {
+			return failedProjects, errors.New("unable to rollback: " + err.Error())
+		}
+	}
+	return failedProjects, nil
+}
+
+// Updates the status with the result of a run
+// deployed is whether the changed projects were deployed during the run
+// err is the error occurred during the run, if any
+// Returns time.Time, the time of the next run
+func (w *Watcher) recordRun(deployed bool, err error) time.Time {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	now := time.Now().UTC()
+	w.status.LastRunTime = &now
+	if err != nil {
+		w.status.ConsecutiveFailures++
+		w.status.Error = err.Error()
+		w.status.Status = WatchStatusError
+		if len(w.status.FailedProjects) > 0 {
+			w.status.Status = WatchStatusFailed
+		}
+	} else {
+		w.status.ConsecutiveFailures = 0
+		w.status.Error = ""
+		w.status.LastSuccessTime = &now
+		w.status.Status = WatchStatusUpToDate
+		if deployed {
+			w.status.Status = WatchStatusDeployed
+		}
+	}
+	nextRun := now.Add(getBackoff(w.interval, w.maxBackoff, w.status.ConsecutiveFailures))
+	w.status.NextRunTime = &nextRun
+	return nextRun
+}
+
+// Returns a copy of the status of the last run
+func (w *Watcher) getStatus() WatchStatus {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	status := w.status
+	status.FailedProjects = append([]string{}, w.status.FailedProjects...)
+	return status
+}
+
+// Starts the health endpoint in the background, which responds with the status of the last run. The endpoint responds
+//  with 200 if the last run was successful (or the watcher is starting), and with 503 otherwise.
+// address is the host:port to listen on
+func (w *Watcher) ServeHealth(address string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", w.serveStatus)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			utils.HandleErrorAndContinue("Error while serving the health endpoint", err)
+		}
+	}()
+	return nil
+}
+
+// Responds with the status of the last run
+func (w *Watcher) serveStatus(writer http.ResponseWriter, request *http.Request) {
+	status := w.getStatus()
+	content, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	if status.ConsecutiveFailures > 0 {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = writer.Write(content)
+}
+
+// Returns the time to wait before the next run. The interval is doubled for each consecutive failure up to the maximum
+//  backoff.
+// interval is the time between two polls of the repositories
+// maxBackoff is the maximum time to wait before retrying a failed run
+// consecutiveFailures is the number of runs failed in a row
+func getBackoff(interval, maxBackoff time.Duration, consecutiveFailures int) time.Duration {
+	backoff := interval
+	for i := 0; i < consecutiveFailures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if consecutiveFailures > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// Pulls the changes of the current branch of the repository from its remote (if any), and returns the HEAD revision.
+//  The changes are pulled using the git binary, so that the credential helpers, the SSH configuration and the proxy
+//  settings of the user are used as with any other git command.
+// repoPath is the path of the repository
+func pullRepository(repoPath string) (string, error) {
+	changeDirectory(repoPath)
+	repo, err := openCurrentRepository()
+	if err != nil {
+		return "", errors.New("unable to open the repository " + repoPath + ": " + err.Error())
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return "", errors.New("unable to read the remotes of " + repoPath + ": " + err.Error())
+	}
+	if len(remotes) > 0 {
+		utils.Logln(utils.LogPrefixInfo + "Pulling " + repoPath)
+		if _, err := executeGitCommandSilently("pull", "--ff-only"); err != nil {
+			return "", errors.New("unable to pull " + repoPath + ": " + err.Error())
+		}
+	}
+	revision, err := getLatestCommitId()
+	if err != nil {
+		return "", errors.New("unable to read the HEAD revision of " + repoPath + ": " + err.Error())
+	}
+	return revision, nil
+}
+
+// Returns the names (path relative to the repository) of the failed projects in a sorted order
+func getFailedProjectNames(failedProjects map[string][]*params.ProjectParams) []string {
+	var names []string
+	for _, projects := range failedProjects {
+		for _, projectParam := range projects {
+			names = append(names, projectParam.RelativePath)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the abbreviated form of a revision
+func shortRevision(revision string) string {
+	if len(revision) > 7 {
+		return revision[:7]
+	}
+	return revision
+}
@@ -0,0 +1,89 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, getBackoff(time.Minute, 30*time.Minute, 0))
+	assert.Equal(t, 2*time.Minute, getBackoff(time.Minute, 30*time.Minute, 1))
+	assert.Equal(t, 8*time.Minute, getBackoff(time.Minute, 30*time.Minute, 3))
+	assert.Equal(t, 30*time.Minute, getBackoff(time.Minute, 30*time.Minute, 10))
+	assert.Equal(t, 30*time.Minute, getBackoff(time.Minute, 30*time.Minute, 1000))
+}
+
+func TestWatcherHealthStatus(t *testing.T) {
+	watcher := NewWatcher("dev", ScopeParams{}, time.Minute, 10*time.Minute, 1, nil)
+	getHealth := func() (int, WatchStatus) {
+		recorder := httptest.NewRecorder()
+		watcher.serveStatus(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var status WatchStatus
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status), "err should be nil")
+		return recorder.Code, status
+	}
+
+	code, status := getHealth()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, WatchStatusStarting, status.Status)
+
+	// Failed runs are retried with a backoff and reported as unhealthy
+	nextRun := watcher.recordRun(false, errors.New("connection refused"))
+	nextRun = watcher.recordRun(false, errors.New("connection refused"))
+	assert.True(t, nextRun.After(time.Now().Add(3*time.Minute)), "the second retry should wait for 4 minutes")
+	code, status = getHealth()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, WatchStatusError, status.Status)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", status.Error)
+
+	nextRun = watcher.recordRun(true, nil)
+	assert.True(t, nextRun.Before(time.Now().Add(2*time.Minute)), "the interval should be reset after a success")
+	code, status = getHealth()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, WatchStatusDeployed, status.Status)
+	assert.Equal(t, 0, status.ConsecutiveFailures)
+	assert.NotNil(t, status.LastSuccessTime, "the time of the last success should be recorded")
+}
+
+func TestPullRepositoryWithoutRemote(t *testing.T) {
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	tmpDir, _ := ioutil.TempDir("", "apictl-watch")
+	defer os.RemoveAll(tmpDir)
+	repoPath := filepath.Join(tmpDir, "repo")
+	initStateTestRepo(t, repoPath)
+	headRevision := commitTestFiles(t, map[string]string{"PizzaShack/api_meta.yaml": "name: PizzaShack\n"}, "add")
+
+	// A repository without a remote is not pulled, and only the HEAD revision is read
+	revision, err := pullRepository(repoPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, headRevision, revision)
+}
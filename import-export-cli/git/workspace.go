@@ -0,0 +1,161 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// revisionWorkspace keeps the temporary directories which revisions of the source and the deployment repositories
+//  are extracted into, so that the projects can be deployed (or deleted) as they were at those revisions without
+//  checking out the revisions in the working copies. A nil workspace represents the working copies themselves.
+type revisionWorkspace struct {
+	sourceRevision         string
+	sourceRepoBasePath     string
+	sourceDir              string
+	deploymentRevision     string
+	deploymentRepoBasePath string
+	deploymentDir          string
+}
+
+// Extracts the given revisions of the source repository and the deployment repository (if deploymentRevision is not
+//  empty) into a new workspace. The current directory is changed to the source repository at the end.
+func newRevisionWorkspace(mainConfig *utils.MainConfig, sourceRevision, deploymentRevision string) (
+	*revisionWorkspace, error) {
+	workspace := &revisionWorkspace{
+		sourceRevision:     sourceRevision,
+		deploymentRevision: deploymentRevision,
+	}
+	var err error
+	if deploymentRevision != "" {
+		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
+		if workspace.deploymentRepoBasePath, err = getRepoBaseDir(); err != nil {
+			return nil, err
+		}
+		if workspace.deploymentDir, err = extractRevision(deploymentRevision); err != nil {
+			return nil, err
+		}
+	}
+
+	changeDirectoryToSourceRepo(mainConfig)
+	if workspace.sourceRepoBasePath, err = getRepoBaseDir(); err != nil {
+		workspace.remove()
+		return nil, err
+	}
+	if workspace.sourceDir, err = extractRevision(sourceRevision); err != nil {
+		workspace.remove()
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// Returns the path in the workspace which corresponds to the given path of the source or the deployment repository.
+//  The path is returned as it is if the workspace is nil or if the path does not belong to the repositories.
+func (workspace *revisionWorkspace) mapPath(path string) string {
+	if workspace == nil {
+		return path
+	}
+	if mappedPath, mapped := replaceBasePath(path, workspace.sourceRepoBasePath, workspace.sourceDir); mapped {
+		return mappedPath
+	}
+	if workspace.deploymentDir != "" {
+		if mappedPath, mapped := replaceBasePath(path, workspace.deploymentRepoBasePath,
+			workspace.deploymentDir); mapped {
+			return mappedPath
+		}
+	}
+	return path
+}
+
+// Returns the path of the source repository which corresponds to the given path in the workspace
+func (workspace *revisionWorkspace) unmapSourcePath(path string) string {
+	if workspace == nil {
+		return path
+	}
+	originalPath, _ := replaceBasePath(path, workspace.sourceDir, workspace.sourceRepoBasePath)
+	return originalPath
+}
+
+// Returns a copy of the main configuration which points the source and the deployment repository paths to the
+//  workspace
+func (workspace *revisionWorkspace) mapConfig(mainConfig *utils.MainConfig) *utils.MainConfig {
+	if workspace == nil {
+		return mainConfig
+	}
+	mappedConfig := *mainConfig
+	mappedConfig.Config.VCSSourceRepoPath = workspace.mapPath(mainConfig.Config.VCSSourceRepoPath)
+	if mainConfig.Config.VCSDeploymentRepoPath != "" {
+		mappedConfig.Config.VCSDeploymentRepoPath = workspace.mapPath(mainConfig.Config.VCSDeploymentRepoPath)
+	}
+	return &mappedConfig
+}
+
+// Returns the revision of the source repository which is deployed. An empty string represents the latest revision
+//  of the working copy.
+func (workspace *revisionWorkspace) getSourceRevision() string {
+	if workspace == nil {
+		return ""
+	}
+	return workspace.sourceRevision
+}
+
+// Returns the revision of the deployment repository which is deployed. An empty string represents the latest
+//  revision of the working copy.
+func (workspace *revisionWorkspace) getDeploymentRevision() string {
+	if workspace == nil {
+		return ""
+	}
+	return workspace.deploymentRevision
+}
+
+// Removes the temporary directories of the workspace
+func (workspace *revisionWorkspace) remove() {
+	if workspace == nil {
+		return
+	}
+	for _, dir := range []string{workspace.sourceDir, workspace.deploymentDir} {
+		if dir == "" {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			utils.Logln(utils.LogPrefixWarning+"Unable to remove the temporary directory "+dir, err)
+		}
+	}
+}
+
+// Replaces the base path of the given path with newBasePath if the path is inside the base path
+// Returns bool, whether the path was inside the base path
+func replaceBasePath(path, basePath, newBasePath string) (string, bool) {
+	if basePath == "" {
+		return path, false
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return path, false
+	}
+	relativePath, err := filepath.Rel(basePath, absolutePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return path, false
+	}
+	return filepath.Join(newBasePath, relativePath), true
+}
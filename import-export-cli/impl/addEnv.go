@@ -68,8 +68,14 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 
 	mainConfig := utils.GetMainConfigFromFile(mainConfigFilePath)
 
+	if envEndpoints.MaxChanges < 0 {
+		return errors.New("Maximum number of changes of the environment cannot be negative")
+	}
+
 	var validatedEnvEndpoints = utils.EnvEndpoints{
 		TokenEndpoint: envEndpoints.TokenEndpoint,
+		Protected:     envEndpoints.Protected,
+		MaxChanges:    envEndpoints.MaxChanges,
 	}
 
 	if envEndpoints.ApiManagerEndpoint != "" {
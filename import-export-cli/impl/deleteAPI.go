@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -53,7 +52,7 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 		return nil, err
 	}
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
-		return nil, errors.New(strconv.Itoa(resp.StatusCode()) + ":<" + string(resp.Body()) + ">")
+		return nil, utils.NewHttpResponseError(resp, strconv.Itoa(resp.StatusCode())+":<"+string(resp.Body())+">")
 	}
 	return resp, nil
 }
@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -53,7 +52,7 @@ func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductProvid
 		return nil, err
 	}
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
-		return nil, errors.New(strconv.Itoa(resp.StatusCode()) + ":<" + string(resp.Body()) + ">")
+		return nil, utils.NewHttpResponseError(resp, strconv.Itoa(resp.StatusCode())+":<"+string(resp.Body())+">")
 	}
 	return resp, nil
 }
@@ -0,0 +1,191 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var apiProductListOffset int // from which index of API Product, the API Products will be fetched from APIM server
+var apiProductsCount int32   // size of API Product list to be exported or number of API Products left to be exported
+var apiProductsToExport []utils.APIProduct
+var startingApiProductIndexFromList int
+
+// Prepare resumption of previous-halted export-api-products operation
+func PrepareAPIProductsExportResumption(credential credentials.Credential, exportRelatedFilesPath,
+	cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
+	lastSucceededApiProductId := utils.ReadLastSucceededAPIProductFileData(exportRelatedFilesPath)
+	var migrationAPIProductsExportMetadata utils.MigrationAPIProductsExportMetadata
+	metadataFilePath := filepath.Join(exportRelatedFilesPath, utils.MigrationAPIProductsExportMetadataFileName)
+	if err := migrationAPIProductsExportMetadata.ReadMigrationAPIProductsExportMetadataFile(metadataFilePath); err != nil {
+		utils.HandleErrorAndExit("Error loading metadata for resume from "+metadataFilePath, err)
+	}
+	apiProductsToExport = migrationAPIProductsExportMetadata.ApiProductListToExport
+	apiProductListOffset = migrationAPIProductsExportMetadata.ApiProductListOffset
+	startingApiProductIndexFromList = getLastSucceededApiProductIndex(lastSucceededApiProductId) + 1
+
+	//find count of API Products left to be exported
+	apiProductsCount = int32(len(apiProductsToExport) - startingApiProductIndexFromList)
+
+	if apiProductsCount == 0 {
+		//last iteration had been completed successfully but operation had halted at that point.
+		//So get the next set of API Products for next iteration
+		apiProductListOffset += utils.MaxAPIsToExportOnce
+		startingApiProductIndexFromList = 0
+		apiProductsCount, apiProductsToExport = getAPIProductListToExport(credential, cmdExportEnvironment,
+			cmdResourceTenantDomain)
+		if len(apiProductsToExport) > 0 {
+			utils.WriteMigrationAPIProductsExportMetadataFile(apiProductsToExport, cmdResourceTenantDomain,
+				cmdUsername, exportRelatedFilesPath, apiProductListOffset)
+		} else {
+			fmt.Println("Command: export api-products execution completed !")
+		}
+	}
+}
+
+// Delete directories where the API Products are exported, reset the indexes, get first API Product list and write
+// the migration-api-products-export-metadata.yaml file
+func PrepareAPIProductsExportStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath,
+	cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
+	fmt.Println("Cleaning all the previously exported API Products of the given target tenant, in the given " +
+		"environment if any, and prepare to export API Products from beginning")
+	//cleaning existing old files (if exists) related to exportation
+	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath,
+		utils.ExportedApiProductsDirName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
+	for _, fileName := range []string{utils.MigrationAPIProductsExportMetadataFileName,
+		utils.LastSucceededApiProductFileName} {
+		if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, fileName)); err != nil {
+			utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+				"exportation", err)
+		}
+	}
+
+	apiProductListOffset = 0
+	startingApiProductIndexFromList = 0
+	apiProductsCount, apiProductsToExport = getAPIProductListToExport(credential, cmdExportEnvironment,
+		cmdResourceTenantDomain)
+	//write migration-api-products-export-metadata.yaml file
+	utils.WriteMigrationAPIProductsExportMetadataFile(apiProductsToExport, cmdResourceTenantDomain, cmdUsername,
+		exportRelatedFilesPath, apiProductListOffset)
+}
+
+// get the index of the finally (successfully) exported API Product from the list of API Products listed in
+// migration-api-products-export-metadata.yaml
+func getLastSucceededApiProductIndex(lastSucceededApiProductId string) int {
+	for i := 0; i < len(apiProductsToExport); i++ {
+		if apiProductsToExport[i].ID == lastSucceededApiProductId {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get the list of API Products from the defined offset index, upto the limit of constant value
+// utils.MaxAPIsToExportOnce
+func getAPIProductListToExport(credential credentials.Credential, cmdExportEnvironment,
+	cmdResourceTenantDomain string) (int32, []utils.APIProduct) {
+	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
+	if preCommandErr != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"Error in getting access token for user while getting "+
+			"the list of API Products: ", preCommandErr)
+	}
+	unifiedSearchEndpoint := utils.GetUnifiedSearchEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
+	unifiedSearchEndpoint += "?offset=" + strconv.Itoa(apiProductListOffset)
+	if cmdResourceTenantDomain != "" {
+		unifiedSearchEndpoint += "&tenantDomain=" + cmdResourceTenantDomain
+	}
+	count, apiProducts, err := GetAPIProductList(accessToken, unifiedSearchEndpoint, "",
+		strconv.Itoa(utils.MaxAPIsToExportOnce))
+	if err != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"Getting List of API Products.", utils.GetHttpErrorResponse(err))
+	}
+	return count, apiProducts
+}
+
+// Do the API Product exportation. The working copy of each API Product is exported.
+func ExportAPIProducts(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment,
+	cmdResourceTenantDomain, exportAPIProductsFormat, cmdUsername, apiProductExportDir string) {
+	if apiProductsCount == 0 {
+		fmt.Println("No API Products available to be exported..!")
+		return
+	}
+	var counterSucceededAPIProducts = 0
+	for apiProductsCount > 0 {
+		utils.Logln(utils.LogPrefixInfo+"Found ", apiProductsCount, "of API Products to be exported in the iteration "+
+			"beginning with the offset #"+strconv.Itoa(apiProductListOffset)+". Maximum limit of API Products "+
+			"exported in single iteration is "+strconv.Itoa(utils.MaxAPIsToExportOnce))
+		accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
+		if preCommandErr != nil {
+			utils.HandleErrorAndExit("Error getting OAuth Tokens", preCommandErr)
+		}
+		for i := startingApiProductIndexFromList; i < len(apiProductsToExport); i++ {
+			exportAPIProductAndWriteToZip(apiProductsToExport[i], accessToken, cmdExportEnvironment,
+				apiProductExportDir, exportRelatedFilesPath, exportAPIProductsFormat)
+			counterSucceededAPIProducts++
+		}
+		fmt.Println("Batch of " + strconv.Itoa(int(apiProductsCount)) + " API Products exported successfully..!")
+
+		apiProductListOffset += utils.MaxAPIsToExportOnce
+		apiProductsCount, apiProductsToExport = getAPIProductListToExport(credential, cmdExportEnvironment,
+			cmdResourceTenantDomain)
+		startingApiProductIndexFromList = 0
+		if len(apiProductsToExport) > 0 {
+			utils.WriteMigrationAPIProductsExportMetadataFile(apiProductsToExport, cmdResourceTenantDomain,
+				cmdUsername, exportRelatedFilesPath, apiProductListOffset)
+		}
+	}
+	fmt.Println("\nTotal number of API Products exported: " + strconv.Itoa(counterSucceededAPIProducts))
+	fmt.Println("API Product export path: " + apiProductExportDir)
+	fmt.Println("\nCommand: export api-products execution completed !")
+}
+
+// Export the API Product and archive to zip format
+func exportAPIProductAndWriteToZip(apiProduct utils.APIProduct, accessToken, cmdExportEnvironment,
+	apiProductExportDir, exportRelatedFilesPath, exportAPIProductsFormat string) {
+	resp, err := ExportAPIProductFromEnv(accessToken, apiProduct.Name, utils.DefaultApiProductVersion, "",
+		apiProduct.Provider, exportAPIProductsFormat, cmdExportEnvironment, false)
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting", err)
+	}
+
+	if resp.StatusCode() == http.StatusOK {
+		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+		WriteAPIProductToZip(apiProduct.Name, utils.DefaultApiProductVersion, apiProductExportDir, false, resp)
+		//write on last-succeeded-api-product.log
+		utils.WriteLastSucceededAPIProductFileData(exportRelatedFilesPath, apiProduct)
+	} else {
+		fmt.Println("Error exporting API Product:", apiProduct.Name, "of Provider:", apiProduct.Provider)
+		utils.PrintErrorResponseAndExit(resp)
+	}
+}
+
+// Create the required directory structure to save the exported API Products
+func CreateExportAPIProductsDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment string,
+	cmdForceStartFromBegin bool) string {
+	return createMigrationExportDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment,
+		utils.ExportedApiProductsDirName, cmdForceStartFromBegin)
+}
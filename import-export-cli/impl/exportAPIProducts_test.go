@@ -0,0 +1,46 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPrepareAPIProductsExportResumption(t *testing.T) {
+	exportRelatedFilesPath, _ := ioutil.TempDir("", "apictl-export-api-products")
+	defer os.RemoveAll(exportRelatedFilesPath)
+	apiProducts := []utils.APIProduct{
+		{ID: "1", Name: "LeasingAPIProduct", Provider: "admin"},
+		{ID: "2", Name: "ShoppingAPIProduct", Provider: "admin"},
+	}
+	utils.WriteMigrationAPIProductsExportMetadataFile(apiProducts, "", "admin", exportRelatedFilesPath, 0)
+	utils.WriteLastSucceededAPIProductFileData(exportRelatedFilesPath, apiProducts[0])
+
+	PrepareAPIProductsExportResumption(credentials.Credential{}, exportRelatedFilesPath, "", "admin", "dev")
+	assert.Equal(t, 0, apiProductListOffset)
+	assert.Equal(t, 1, startingApiProductIndexFromList,
+		"the export should be resumed after the last succeeded API Product")
+	assert.Equal(t, int32(1), apiProductsCount)
+	assert.Equal(t, apiProducts, apiProductsToExport)
+}
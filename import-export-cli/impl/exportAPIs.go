@@ -227,6 +227,14 @@ func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExpor
 
 // Create the required directory structure to save the exported APIs
 func CreateExportAPIsDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment string, cmdForceStartFromBegin bool) string {
+	return createMigrationExportDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment,
+		utils.ExportedApisDirName, cmdForceStartFromBegin)
+}
+
+// Create the required directory structure to save the exported artifacts of a type for migration
+// (<artifactExportDirectory>/<environment>/<tenant>/<artifactsDirName>) and return the directory of the artifacts
+func createMigrationExportDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment,
+	artifactsDirName string, cmdForceStartFromBegin bool) string {
 	var resourceTenantDirName = utils.GetMigrationExportTenantDirName(cmdResourceTenantDomain)
 
 	var createDirError error
@@ -234,23 +242,23 @@ func CreateExportAPIsDirStructure(artifactExportDirectory, cmdResourceTenantDoma
 
 	migrationsArtifactsEnvPath := filepath.Join(artifactExportDirectory, cmdExportEnvironment)
 	migrationsArtifactsEnvTenantPath := filepath.Join(migrationsArtifactsEnvPath, resourceTenantDirName)
-	migrationsArtifactsEnvTenantApisPath := filepath.Join(migrationsArtifactsEnvTenantPath, utils.ExportedApisDirName)
+	migrationsArtifactsEnvTenantArtifactsPath := filepath.Join(migrationsArtifactsEnvTenantPath, artifactsDirName)
 
 	createDirError = utils.CreateDirIfNotExist(migrationsArtifactsEnvPath)
 	createDirError = utils.CreateDirIfNotExist(migrationsArtifactsEnvTenantPath)
 
-	if dirExists, _ := utils.IsDirExists(migrationsArtifactsEnvTenantApisPath); dirExists {
+	if dirExists, _ := utils.IsDirExists(migrationsArtifactsEnvTenantArtifactsPath); dirExists {
 		if cmdForceStartFromBegin {
-			utils.RemoveDirectory(migrationsArtifactsEnvTenantApisPath)
-			createDirError = utils.CreateDir(migrationsArtifactsEnvTenantApisPath)
+			utils.RemoveDirectory(migrationsArtifactsEnvTenantArtifactsPath)
+			createDirError = utils.CreateDir(migrationsArtifactsEnvTenantArtifactsPath)
 		}
 	} else {
-		createDirError = utils.CreateDir(migrationsArtifactsEnvTenantApisPath)
+		createDirError = utils.CreateDir(migrationsArtifactsEnvTenantArtifactsPath)
 	}
 
 	if createDirError != nil {
-		utils.HandleErrorAndExit("Error in creating directory structure for the API export for migration .",
-			createDirError)
+		utils.HandleErrorAndExit("Error in creating directory structure for the "+artifactsDirName+
+			" export for migration .", createDirError)
 	}
-	return migrationsArtifactsEnvTenantApisPath
+	return migrationsArtifactsEnvTenantArtifactsPath
 }
@@ -0,0 +1,185 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var appListOffset int // from which index of Application, the Applications will be fetched from APIM server
+var appsCount int32   // size of Application list to be exported or number of Applications left to be exported
+var appsToExport []utils.Application
+var startingAppIndexFromList int
+
+// Prepare resumption of previous-halted export-apps operation
+func PrepareAppsExportResumption(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain,
+	cmdUsername, cmdExportEnvironment string) {
+	lastSucceededAppId := utils.ReadLastSucceededAppFileData(exportRelatedFilesPath)
+	var migrationAppsExportMetadata utils.MigrationAppsExportMetadata
+	metadataFilePath := filepath.Join(exportRelatedFilesPath, utils.MigrationAppsExportMetadataFileName)
+	if err := migrationAppsExportMetadata.ReadMigrationAppsExportMetadataFile(metadataFilePath); err != nil {
+		utils.HandleErrorAndExit("Error loading metadata for resume from "+metadataFilePath, err)
+	}
+	appsToExport = migrationAppsExportMetadata.AppListToExport
+	appListOffset = migrationAppsExportMetadata.AppListOffset
+	startingAppIndexFromList = getLastSucceededAppIndex(lastSucceededAppId) + 1
+
+	//find count of Applications left to be exported
+	appsCount = int32(len(appsToExport) - startingAppIndexFromList)
+
+	if appsCount == 0 {
+		//last iteration had been completed successfully but operation had halted at that point.
+		//So get the next set of Applications for next iteration
+		appListOffset += utils.MaxAPIsToExportOnce
+		startingAppIndexFromList = 0
+		appsCount, appsToExport = getAppListToExport(credential, cmdExportEnvironment, cmdResourceTenantDomain)
+		if len(appsToExport) > 0 {
+			utils.WriteMigrationAppsExportMetadataFile(appsToExport, cmdResourceTenantDomain, cmdUsername,
+				exportRelatedFilesPath, appListOffset)
+		} else {
+			fmt.Println("Command: export apps execution completed !")
+		}
+	}
+}
+
+// Delete directories where the Applications are exported, reset the indexes, get first Application list and write
+// the migration-apps-export-metadata.yaml file
+func PrepareAppsExportStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath,
+	cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
+	fmt.Println("Cleaning all the previously exported Applications of the given target tenant, in the given " +
+		"environment if any, and prepare to export Applications from beginning")
+	//cleaning existing old files (if exists) related to exportation
+	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedAppsDirName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
+	for _, fileName := range []string{utils.MigrationAppsExportMetadataFileName, utils.LastSucceededAppFileName} {
+		if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, fileName)); err != nil {
+			utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+				"exportation", err)
+		}
+	}
+
+	appListOffset = 0
+	startingAppIndexFromList = 0
+	appsCount, appsToExport = getAppListToExport(credential, cmdExportEnvironment, cmdResourceTenantDomain)
+	//write migration-apps-export-metadata.yaml file
+	utils.WriteMigrationAppsExportMetadataFile(appsToExport, cmdResourceTenantDomain, cmdUsername,
+		exportRelatedFilesPath, appListOffset)
+}
+
+// get the index of the finally (successfully) exported Application from the list of Applications listed in
+// migration-apps-export-metadata.yaml
+func getLastSucceededAppIndex(lastSucceededAppId string) int {
+	for i := 0; i < len(appsToExport); i++ {
+		if appsToExport[i].ID == lastSucceededAppId {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get the list of Applications of all the owners from the defined offset index, upto the limit of constant value
+// utils.MaxAPIsToExportOnce
+func getAppListToExport(credential credentials.Credential, cmdExportEnvironment,
+	cmdResourceTenantDomain string) (int32, []utils.Application) {
+	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
+	if preCommandErr != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"Error in getting access token for user while getting "+
+			"the list of Applications: ", preCommandErr)
+	}
+	appListEndpoint := utils.GetAdminApplicationListEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
+	appListEndpoint += "?limit=" + strconv.Itoa(utils.MaxAPIsToExportOnce) + "&offset=" + strconv.Itoa(appListOffset)
+	if cmdResourceTenantDomain != "" {
+		appListEndpoint += "&tenantDomain=" + cmdResourceTenantDomain
+	}
+	count, apps, err := GetApplicationList(accessToken, appListEndpoint, "", "")
+	if err != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"Getting List of Applications.", utils.GetHttpErrorResponse(err))
+	}
+	return count, apps
+}
+
+// Do the Application exportation
+func ExportApps(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment,
+	cmdResourceTenantDomain, exportAppsFormat, cmdUsername, appExportDir string, exportAppsWithKeys bool) {
+	if appsCount == 0 {
+		fmt.Println("No Applications available to be exported..!")
+		return
+	}
+	var counterSucceededApps = 0
+	for appsCount > 0 {
+		utils.Logln(utils.LogPrefixInfo+"Found ", appsCount, "of Applications to be exported in the iteration "+
+			"beginning with the offset #"+strconv.Itoa(appListOffset)+". Maximum limit of Applications exported in "+
+			"single iteration is "+strconv.Itoa(utils.MaxAPIsToExportOnce))
+		accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
+		if preCommandErr != nil {
+			utils.HandleErrorAndExit("Error getting OAuth Tokens", preCommandErr)
+		}
+		for i := startingAppIndexFromList; i < len(appsToExport); i++ {
+			exportAppAndWriteToZip(appsToExport[i], accessToken, cmdExportEnvironment, appExportDir,
+				exportRelatedFilesPath, exportAppsFormat, exportAppsWithKeys)
+			counterSucceededApps++
+		}
+		fmt.Println("Batch of " + strconv.Itoa(int(appsCount)) + " Applications exported successfully..!")
+
+		appListOffset += utils.MaxAPIsToExportOnce
+		appsCount, appsToExport = getAppListToExport(credential, cmdExportEnvironment, cmdResourceTenantDomain)
+		startingAppIndexFromList = 0
+		if len(appsToExport) > 0 {
+			utils.WriteMigrationAppsExportMetadataFile(appsToExport, cmdResourceTenantDomain, cmdUsername,
+				exportRelatedFilesPath, appListOffset)
+		}
+	}
+	fmt.Println("\nTotal number of Applications exported: " + strconv.Itoa(counterSucceededApps))
+	fmt.Println("Application export path: " + appExportDir)
+	fmt.Println("\nCommand: export apps execution completed !")
+}
+
+// Export the Application and archive to zip format
+func exportAppAndWriteToZip(app utils.Application, accessToken, cmdExportEnvironment, appExportDir,
+	exportRelatedFilesPath, exportAppsFormat string, exportAppsWithKeys bool) {
+	resp, err := ExportAppFromEnv(accessToken, app.Name, app.Owner, exportAppsFormat, cmdExportEnvironment,
+		exportAppsWithKeys)
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting", err)
+	}
+
+	if resp.StatusCode() == http.StatusOK {
+		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+		WriteApplicationToZip(app.Name, app.Owner, appExportDir, resp)
+		//write on last-succeeded-app.log
+		utils.WriteLastSucceededAppFileData(exportRelatedFilesPath, app)
+	} else {
+		fmt.Println("Error exporting Application:", app.Name, "of Owner:", app.Owner)
+		utils.PrintErrorResponseAndExit(resp)
+	}
+}
+
+// Create the required directory structure to save the exported Applications
+func CreateExportAppsDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment string,
+	cmdForceStartFromBegin bool) string {
+	return createMigrationExportDirStructure(artifactExportDirectory, cmdResourceTenantDomain, cmdExportEnvironment,
+		utils.ExportedAppsDirName, cmdForceStartFromBegin)
+}
@@ -0,0 +1,48 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPrepareAppsExportResumption(t *testing.T) {
+	exportRelatedFilesPath, _ := ioutil.TempDir("", "apictl-export-apps")
+	defer os.RemoveAll(exportRelatedFilesPath)
+	apps := []utils.Application{
+		{ID: "1", Name: "DefaultApplication", Owner: "admin"},
+		{ID: "2", Name: "Sample App", Owner: "admin"},
+		{ID: "3", Name: "Sample App", Owner: "user"},
+	}
+	utils.WriteMigrationAppsExportMetadataFile(apps, "", "admin", exportRelatedFilesPath, 20)
+	utils.WriteLastSucceededAppFileData(exportRelatedFilesPath, apps[1])
+	assert.Equal(t, "2", utils.ReadLastSucceededAppFileData(exportRelatedFilesPath),
+		"the name of the Application should not affect reading the last succeeded Application")
+
+	PrepareAppsExportResumption(credentials.Credential{}, exportRelatedFilesPath, "", "admin", "dev")
+	assert.Equal(t, 20, appListOffset)
+	assert.Equal(t, 2, startingAppIndexFromList, "the export should be resumed after the last succeeded Application")
+	assert.Equal(t, int32(1), appsCount)
+	assert.Equal(t, apps, appsToExport)
+}
@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// GenerateOASFromProject merges the details of the API in the api.yaml of the API project in projectPath into the
+// Swagger/OpenAPI definition of the project as x-wso2 extensions, so that the definition can be used to initialize
+// the project again with init --oas. The definition is written to outputPath (as JSON if the file name ends with
+// .json), or else to the definition of the project itself. Returns the path of the definition written.
+func GenerateOASFromProject(projectPath, outputPath string) (string, error) {
+	api, _, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return "", err
+	}
+	if apiType := strings.ToUpper(api.Data.Type); apiType != "" && apiType != "HTTP" && apiType != "SOAPTOREST" {
+		return "", errors.New("an OpenAPI definition can only be generated for a REST API, found an API of type " +
+			api.Data.Type)
+	}
+
+	swaggerPath := filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger)
+	utils.Logln(utils.LogPrefixInfo + "Reading " + swaggerPath)
+	content, err := ioutil.ReadFile(swaggerPath)
+	if err != nil {
+		return "", err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return "", err
+	}
+	var definition map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &definition); err != nil {
+		return "", err
+	}
+	if err := v2.AddWSO2Extensions(definition, &api.Data); err != nil {
+		return "", err
+	}
+
+	content, err = json.MarshalIndent(definition, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if outputPath == "" {
+		outputPath = swaggerPath
+	}
+	if !strings.EqualFold(filepath.Ext(outputPath), ".json") {
+		if content, err = utils.JsonToYaml(content); err != nil {
+			return "", err
+		}
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + outputPath)
+	return outputPath, ioutil.WriteFile(outputPath, content, os.ModePerm)
+}
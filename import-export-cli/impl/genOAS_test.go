@@ -0,0 +1,61 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGenerateOASFromProject(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-gen-oas")
+	defer os.RemoveAll(tmpDir)
+	projectPath := filepath.Join(tmpDir, "PizzaShackAPI-1.0.0")
+	assert.Nil(t, utils.CopyDir(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "PizzaShackAPI-1.0.0"),
+		projectPath), "err should be nil")
+
+	outputPath := filepath.Join(tmpDir, "pizzashack.json")
+	writtenPath, err := GenerateOASFromProject(projectPath, outputPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, outputPath, writtenPath, "should write the definition to the output file")
+	definition, err := utils.LoadYamlAsJson(outputPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Contains(t, string(definition), `"x-wso2-basePath":"/pizzashack/{version}"`,
+		"should add the base path of a version which is not the default version")
+
+	writtenPath, err = GenerateOASFromProject(projectPath, "")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger), writtenPath,
+		"should update the definition of the project")
+	issues, err := ValidateAPIProject(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	for _, issue := range issues {
+		assert.NotContains(t, issue, filepath.ToSlash(utils.InitProjectDefinitionsSwagger),
+			"the definition should be valid")
+	}
+}
+
+func TestGenerateOASFromProjectWithoutProject(t *testing.T) {
+	_, err := GenerateOASFromProject(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "NoSuchAPI"), "")
+	assert.NotNil(t, err, "err should not be nil")
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -140,8 +141,9 @@ func replaceEnvVariables(apiFilePath string) error {
 	return nil
 }
 
-// importAPI imports an API to the API manager
-func importAPI(endpoint, filePath, accessToken string, extraParams map[string]string, isOauth bool) error {
+// importAPI imports an API to the API manager and writes the result to out
+func importAPI(out io.Writer, endpoint, filePath, accessToken string, extraParams map[string]string,
+	isOauth bool) error {
 	resp, err := ExecuteNewFileUploadRequest(endpoint, extraParams, "file",
 		filePath, accessToken, isOauth)
 	utils.Logf("Response : %v", resp)
@@ -151,41 +153,87 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API.")
+		fmt.Fprintln(out, "Successfully imported API.")
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return errors.New(resp.Status())
+		fmt.Fprintln(out, "Error importing API.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp)
+		return utils.NewHttpResponseError(resp, resp.Status())
 	}
 }
 
-// ImportAPIToEnv function is used with import-api command
-func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+// ImportAPIToEnv function is used with import-api command. The result of the import is written to out.
+func ImportAPIToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath, apiParamsPath string,
+	importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath, importAPIUpdate,
-		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
+	return ImportAPI(out, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath,
+		importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments)
 }
 
-// ImportAPI function is used with import-api command
-func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
+// ImportAPI function is used with import-api command. The result of the import is written to out.
+func ImportAPI(out io.Writer, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
 	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool) error {
+	apiFilePath, cleanupFunc, err := prepareAPIArchive(importEnvironment, importPath, apiParamsPath,
+		importAPISkipCleanup, importAPISkipDeployments)
+	//cleanup the temporary artifacts once consuming the zip file
+	defer cleanupFunc()
+	if err != nil {
+		return err
+	}
+
+	extraParams := map[string]string{}
+	publisherEndpoint += "/apis/import"
+	if importAPIUpdate {
+		publisherEndpoint += "?overwrite=" + strconv.FormatBool(true) + "&preserveProvider=" +
+			strconv.FormatBool(preserveProvider) + "&rotateRevision=" + strconv.FormatBool(importAPIRotateRevision)
+	} else {
+		publisherEndpoint += "?preserveProvider=" + strconv.FormatBool(preserveProvider) + "&rotateRevision=" +
+			strconv.FormatBool(importAPIRotateRevision)
+	}
+	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
+
+	err = importAPI(out, publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true)
+	return err
+}
+
+// ValidateAPIImport goes through all the steps of importing an API to the given environment, such as substituting
+// environment variables, applying the params and zipping the project, without sending the API to the environment.
+// This is used to find the API projects which would fail to import, before actually importing.
+func ValidateAPIImport(importEnvironment, importPath, apiParamsPath string, importAPISkipDeployments bool) error {
+	_, cleanupFunc, err := prepareAPIArchive(importEnvironment, importPath, apiParamsPath, false,
+		importAPISkipDeployments)
+	cleanupFunc()
+	return err
+}
+
+// prepareAPIArchive creates the archive of an API to be imported to the given environment from a temporary copy of
+// the API, after substituting the environment variables and applying the params of the environment.
+// Returns the path of the archive to import and a function which cleans up the temporary artifacts. The cleanup
+// function should be called even if there is an error.
+func prepareAPIArchive(importEnvironment, importPath, apiParamsPath string, importAPISkipCleanup,
+	importAPISkipDeployments bool) (string, func(), error) {
+	var cleanupFuncs []func()
+	cleanupFunc := func() {
+		for i := len(cleanupFuncs) - 1; i >= 0; i-- {
+			cleanupFuncs[i]()
+		}
+	}
+
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
 	resolvedAPIFilePath, err := resolveImportFilePath(importPath, exportDirectory)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
 	utils.Logln(utils.LogPrefixInfo+"API Location:", resolvedAPIFilePath)
 
 	utils.Logln(utils.LogPrefixInfo + "Creating workspace")
 	tmpPath, err := utils.GetTempCloneFromDirOrZip(resolvedAPIFilePath)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
-	defer func() {
+	cleanupFuncs = append(cleanupFuncs, func() {
 		if importAPISkipCleanup {
 			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
 			return
@@ -195,13 +243,13 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 		if err != nil {
 			utils.Logln(utils.LogPrefixError + err.Error())
 		}
-	}()
+	})
 	apiFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API files...")
 	err = replaceEnvVariables(apiFilePath)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
 
 	if importAPISkipDeployments {
@@ -210,7 +258,7 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 		utils.Logln(utils.LogPrefixInfo + "Removing the deployment environments file from " + loc)
 		err := utils.RemoveFileIfExists(loc)
 		if err != nil {
-			return err
+			return "", cleanupFunc, err
 		}
 	}
 
@@ -218,34 +266,19 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 		//Reading params file of the API and add configurations into temp artifact
 		err := handleCustomizedParameters(apiFilePath, apiParamsPath, importEnvironment)
 		if err != nil {
-			return err
+			return "", cleanupFunc, err
 		}
 	}
 
 	// if apiFilePath contains a directory, zip it. Otherwise, leave it as it is.
-	apiFilePath, err, cleanupFunc := utils.CreateZipFileFromProject(apiFilePath, importAPISkipCleanup)
-	if err != nil {
-		return err
-	}
-
-	//cleanup the temporary artifacts once consuming the zip file
-	if cleanupFunc != nil {
-		defer cleanupFunc()
+	apiFilePath, err, zipCleanupFunc := utils.CreateZipFileFromProject(apiFilePath, importAPISkipCleanup)
+	if zipCleanupFunc != nil {
+		cleanupFuncs = append(cleanupFuncs, zipCleanupFunc)
 	}
-
-	extraParams := map[string]string{}
-	publisherEndpoint += "/apis/import"
-	if importAPIUpdate {
-		publisherEndpoint += "?overwrite=" + strconv.FormatBool(true) + "&preserveProvider=" +
-			strconv.FormatBool(preserveProvider) + "&rotateRevision=" + strconv.FormatBool(importAPIRotateRevision)
-	} else {
-		publisherEndpoint += "?preserveProvider=" + strconv.FormatBool(preserveProvider) + "&rotateRevision=" +
-			strconv.FormatBool(importAPIRotateRevision)
+	if err != nil {
+		return "", cleanupFunc, err
 	}
-	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
-
-	err = importAPI(publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true)
-	return err
+	return apiFilePath, cleanupFunc, nil
 }
 
 // envParamsFileProcess function is used to process the environment parameters when they are provided as a file
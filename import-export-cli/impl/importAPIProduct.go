@@ -19,8 +19,8 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -56,8 +56,8 @@ func resolveImportAPIProductFilePath(file, defaultExportDirectory string) (strin
 	return absPath, nil
 }
 
-// importAPIProduct imports an API Product to the API manager
-func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[string]string) error {
+// importAPIProduct imports an API Product to the API manager and writes the result to out
+func importAPIProduct(out io.Writer, endpoint, filePath, accessToken string, extraParams map[string]string) error {
 	resp, err := ExecuteNewFileUploadRequest(endpoint, extraParams, "file",
 		filePath, accessToken, true)
 	if err != nil {
@@ -66,45 +66,103 @@ func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[st
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API Product.")
+		fmt.Fprintln(out, "Successfully imported API Product.")
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API Product.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return errors.New(resp.Status())
+		fmt.Fprintln(out, "Error importing API Product.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp)
+		return utils.NewHttpResponseError(resp, resp.Status())
 	}
 }
 
-// ImportAPIProductToEnv function is used with import-api-product command
-func ImportAPIProductToEnv(accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
+// ImportAPIProductToEnv function is used with import-api-product command. The result of the import is written to out.
+func ImportAPIProductToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
 	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
 	skipDeployments bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath, importAPIs,
+	return ImportAPIProduct(out, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath, importAPIs,
 		importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
 		skipDeployments)
 }
 
-// ImportAPIProduct function is used with import-api-product command
-func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
+// ImportAPIProduct function is used with import-api-product command. The result of the import is written to out.
+func ImportAPIProduct(out io.Writer, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
 	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup,
 	rotateRevision, skipDeployments bool) error {
+	apiProductFilePath, cleanupFunc, err := prepareAPIProductArchive(importEnvironment, importPath,
+		apiProductParamsPath, importAPIProductSkipCleanup, skipDeployments)
+	//cleanup the temporary artifacts once consuming the zip file
+	defer cleanupFunc()
+	if err != nil {
+		return err
+	}
+
+	extraParams := map[string]string{}
+	publisherEndpoint += "/api-products/import" + "?preserveProvider=" +
+		strconv.FormatBool(importAPIProductPreserveProvider) + "&rotateRevision=" + strconv.FormatBool(rotateRevision)
+
+	// If the user has specified import-apis flag or update-apis flag, importAPIs parameter should be passed as true
+	// because update is also an import task
+	if importAPIs || importAPIsUpdate {
+		publisherEndpoint += "&importAPIs=" + strconv.FormatBool(true)
+	}
+
+	// If the user need to update the APIs and the API Product, overwriteAPIs parameter should be passed as true
+	if importAPIsUpdate {
+		publisherEndpoint += "&overwriteAPIs=" + strconv.FormatBool(true)
+	}
+
+	// If the user need only to update the API Product, overwriteAPIProduct parameter should be passed as true
+	if importAPIsUpdate || importAPIProductUpdate {
+		publisherEndpoint += "&overwriteAPIProduct=" + strconv.FormatBool(true)
+	}
+
+	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
+	err = importAPIProduct(out, publisherEndpoint, apiProductFilePath, accessOAuthToken, extraParams)
+	return err
+}
+
+// ValidateAPIProductImport goes through all the steps of importing an API Product to the given environment, such as
+// substituting environment variables, applying the params and zipping the project, without sending the API Product
+// to the environment. This is used to find the API Product projects which would fail to import, before actually
+// importing.
+func ValidateAPIProductImport(importEnvironment, importPath, apiProductParamsPath string, skipDeployments bool) error {
+	_, cleanupFunc, err := prepareAPIProductArchive(importEnvironment, importPath, apiProductParamsPath, false,
+		skipDeployments)
+	cleanupFunc()
+	return err
+}
+
+// prepareAPIProductArchive creates the archive of an API Product to be imported to the given environment from a
+// temporary copy of the API Product, after substituting the environment variables and applying the params of the
+// environment.
+// Returns the path of the archive to import and a function which cleans up the temporary artifacts. The cleanup
+// function should be called even if there is an error.
+func prepareAPIProductArchive(importEnvironment, importPath, apiProductParamsPath string,
+	importAPIProductSkipCleanup, skipDeployments bool) (string, func(), error) {
+	var cleanupFuncs []func()
+	cleanupFunc := func() {
+		for i := len(cleanupFuncs) - 1; i >= 0; i-- {
+			cleanupFuncs[i]()
+		}
+	}
+
 	var exportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedApiProductsDirName)
 
 	resolvedAPIProductFilePath, err := resolveImportAPIProductFilePath(importPath, exportDirectory)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
 	utils.Logln(utils.LogPrefixInfo+"API Product Location:", resolvedAPIProductFilePath)
 
 	utils.Logln(utils.LogPrefixInfo + "Creating workspace")
 	tmpPath, err := utils.GetTempCloneFromDirOrZip(resolvedAPIProductFilePath)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
-	defer func() {
+	cleanupFuncs = append(cleanupFuncs, func() {
 		if importAPIProductSkipCleanup {
 			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
 			return
@@ -114,26 +172,26 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 		if err != nil {
 			utils.Logln(utils.LogPrefixError + err.Error())
 		}
-	}()
+	})
 	apiProductFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API Product files...")
 	err = replaceEnvVariables(apiProductFilePath)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
 
 	// Replace the environment variables inside the dependent API directories
 	err = replaceEnvVariablesInDependentAPIs(apiProductFilePath)
 	if err != nil {
-		return err
+		return "", cleanupFunc, err
 	}
 
 	if apiProductParamsPath != "" {
 		// Reading params file of the API Product and add configurations into temp artifact
 		err := handleCustomizedParameters(apiProductFilePath, apiProductParamsPath, importEnvironment)
 		if err != nil {
-			return err
+			return "", cleanupFunc, err
 		}
 	}
 
@@ -143,47 +201,20 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 		utils.Logln(utils.LogPrefixInfo + "Removing the deployment environments file from " + loc)
 		err := utils.RemoveFileIfExists(loc)
 		if err != nil {
-			return err
+			return "", cleanupFunc, err
 		}
 	}
 
 	// If apiProductFilePath contains a directory, zip it. Otherwise, leave it as it is.
-	apiProductFilePath, err, cleanupFunc := utils.CreateZipFileFromProject(apiProductFilePath, importAPIProductSkipCleanup)
-	if err != nil {
-		return err
+	apiProductFilePath, err, zipCleanupFunc := utils.CreateZipFileFromProject(apiProductFilePath,
+		importAPIProductSkipCleanup)
+	if zipCleanupFunc != nil {
+		cleanupFuncs = append(cleanupFuncs, zipCleanupFunc)
 	}
-
-	//cleanup the temporary artifacts once consuming the zip file
-	if cleanupFunc != nil {
-		defer cleanupFunc()
-	}
-
 	if err != nil {
-		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
+		return "", cleanupFunc, err
 	}
-	extraParams := map[string]string{}
-	publisherEndpoint += "/api-products/import" + "?preserveProvider=" +
-		strconv.FormatBool(importAPIProductPreserveProvider) + "&rotateRevision=" + strconv.FormatBool(rotateRevision)
-
-	// If the user has specified import-apis flag or update-apis flag, importAPIs parameter should be passed as true
-	// because update is also an import task
-	if importAPIs || importAPIsUpdate {
-		publisherEndpoint += "&importAPIs=" + strconv.FormatBool(true)
-	}
-
-	// If the user need to update the APIs and the API Product, overwriteAPIs parameter should be passed as true
-	if importAPIsUpdate {
-		publisherEndpoint += "&overwriteAPIs=" + strconv.FormatBool(true)
-	}
-
-	// If the user need only to update the API Product, overwriteAPIProduct parameter should be passed as true
-	if importAPIsUpdate || importAPIProductUpdate {
-		publisherEndpoint += "&overwriteAPIProduct=" + strconv.FormatBool(true)
-	}
-
-	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
-	err = importAPIProduct(publisherEndpoint, apiProductFilePath, accessOAuthToken, extraParams)
-	return err
+	return apiProductFilePath, cleanupFunc, nil
 }
 
 // replaceEnvVariablesInDependentAPIs replaces the environment variables inside the dependent APIs
@@ -77,8 +77,11 @@ func TestExtractAPIProductInfoWithMalformedJSON(t *testing.T) {
 func TestGetAPIProductInfoCorrectDirectoryStructure(t *testing.T) {
 	apiProduct, _, err := GetAPIProductDefinition(utils.GetRelativeTestDataPathFromImpl() + "MyProduct-1.0.0")
 	assert.Nil(t, err, "Should return nil error on reading correct directories")
-	assert.Equal(t, v2.APIProductDTODefinition{Provider: "admin", Name: "MyProduct"}, apiProduct.Data,
-		"Should return correct values for ID info")
+	assert.Equal(t, v2.APIProductDTODefinition{Provider: "admin", Name: "MyProduct",
+		APIs: []v2.ProductAPIDTODefinition{
+			{Name: "SwaggerPetstore", Version: "1.0.5"},
+			{Name: "PizzaShackAPI", Version: "1.0.0"},
+		}}, apiProduct.Data, "Should return correct values for ID info")
 }
 
 func TestGetAPIProductInfoMalformedDirectory(t *testing.T) {
@@ -20,7 +20,6 @@ package impl
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -44,14 +43,15 @@ import (
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
-func ImportApplicationToEnv(accessToken, environment, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
+func ImportApplicationToEnv(out io.Writer, accessToken, environment, filename, appOwner string, updateApplication,
+	preserveOwner, skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
 	devportalApplicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	return ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner, updateApplication, preserveOwner,
+	return ImportApplication(out, accessToken, devportalApplicationsEndpoint, filename, appOwner, updateApplication, preserveOwner,
 		skipSubscriptions, skipKeys, skipCleanup)
 }
 
 // ImportApplication function is used with import-app command
+// @param out: Writer to which the result of the import is written
 // @param accessToken: OAuth2.0 access token for the resource being accessed
 // @param devportalApplicationsEndpoint: Dev Portal Applications Endpoint for the environment
 // @param filename: name of the application (zipped file) to be imported
@@ -61,7 +61,7 @@ func ImportApplicationToEnv(accessToken, environment, filename, appOwner string,
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
-func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner string, updateApplication, preserveOwner,
+func ImportApplication(out io.Writer, accessToken, devportalApplicationsEndpoint, filename, appOwner string, updateApplication, preserveOwner,
 	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
 
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
@@ -99,15 +99,32 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported Application.")
+		fmt.Fprintln(out, "Successfully imported Application.")
 		return nil, nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing Application.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return nil, errors.New(resp.Status())
+		fmt.Fprintln(out, "Error importing Application.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp)
+		return nil, utils.NewHttpResponseError(resp, resp.Status())
+	}
+}
+
+// ValidateApplicationImport resolves and zips the given Application project in the same way as importing it, without
+// sending the Application to the environment. This is used to find the Application projects which would fail to
+// import, before actually importing.
+// @param filename: name of the application (zipped file) to be imported
+func ValidateApplicationImport(filename string) error {
+	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
+	applicationFilePath, err := resolveApplicationImportFilePath(filename, exportDirectory)
+	if err != nil {
+		return err
+	}
+	_, err, cleanupFunc := utils.CreateZipFileFromProject(applicationFilePath, false)
+	if cleanupFunc != nil {
+		cleanupFunc()
 	}
+	return err
 }
 
 // resolveApplicationImportFilePath resolves the archive/directory for import
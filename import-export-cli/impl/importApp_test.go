@@ -21,6 +21,7 @@ package impl
 import (
 	"github.com/renstrom/dedent"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -50,12 +51,12 @@ func TestImportApplication1(t *testing.T) {
 	owner := "admin"
 	accessToken := "access-token"
 
-	_, err := ImportApplication(accessToken, server.URL, name, owner, false,true, true, true, false)
+	_, err := ImportApplication(ioutil.Discard, accessToken, server.URL, name, owner, false,true, true, true, false)
 	if err != nil {
 		t.Errorf("Error: %s\n", err.Error())
 	}
 	utils.Insecure = true
-	_, err = ImportApplication(accessToken, server.URL, name, owner, false,true, true, true, false)
+	_, err = ImportApplication(ioutil.Discard, accessToken, server.URL, name, owner, false,true, true, true, false)
 	if err != nil {
 		t.Errorf("Error: %s\n", err.Error())
 	}
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Jeffail/gabs"
 	"github.com/go-openapi/loads"
@@ -51,8 +52,20 @@ var dirs = []string{
 	utils.InitProjectLibs,
 }
 
+// Types of the definitions, from which an API Project can be initialized
+const (
+	DefinitionTypeOAS      = "oas"
+	DefinitionTypeGraphQL  = "graphql"
+	DefinitionTypeAsyncAPI = "asyncapi"
+	DefinitionTypeWSDL     = "wsdl"
+	DefinitionTypePostman  = "postman"
+	DefinitionTypeHAR      = "har"
+)
+
 // InitAPIProject function is used to initlialize an API Project
-func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
+// initCmdDefinitionType is the type of the definition (ie: DefinitionTypeOAS) given by initCmdDefinitionPath
+func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdDefinitionType, initCmdDefinitionPath,
+	initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
 	var dir string
 	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
 
@@ -93,38 +106,27 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	// Use the swagger definition to populate the API definition and save the swagger file separately inside the project
-	if initCmdSwaggerPath != "" {
-		// Load the swagger file from the provided path
-		doc, err := loadSwagger(initCmdSwaggerPath)
-		if err != nil {
-			return err
-		}
-		// We use swagger2 loader. It works fine for now
-		// Since we don't use 3.0 specific details its ok
-		// otherwise please use v2.openAPI3 loaders
-		err = v2.Swagger2Populate(def, doc)
-		if err != nil {
-			return err
-		}
-
-		// Convert and write the swagger definition as yaml
-		yamlSwagger, err := utils.JsonToYaml(doc.Raw())
-		if err != nil {
-			return err
-		}
-		err = ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
-		if err != nil {
-			return err
-		}
-	} else {
+	// Use the definition to populate the API definition and save the definition file separately inside the project
+	switch {
+	case initCmdDefinitionPath == "":
 		// Create an empty swagger definition
 		utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
 		swaggerDoc, _ := box.Get("/init/swagger-default.yaml")
 		err = ioutil.WriteFile(swaggerSavePath, swaggerDoc, os.ModePerm)
-		if err != nil {
-			return err
-		}
+	case initCmdDefinitionType == DefinitionTypeGraphQL:
+		err = initGraphQLDefinition(initCmdOutputDir, filepath.Base(dir), initCmdDefinitionPath, def)
+	case initCmdDefinitionType == DefinitionTypeAsyncAPI:
+		err = initAsyncAPIDefinition(initCmdOutputDir, initCmdDefinitionPath, def)
+	case initCmdDefinitionType == DefinitionTypeWSDL:
+		err = initWSDLDefinition(initCmdOutputDir, initCmdDefinitionPath, def)
+	case initCmdDefinitionType == DefinitionTypePostman, initCmdDefinitionType == DefinitionTypeHAR:
+		err = initInferredSwaggerDefinition(swaggerSavePath, filepath.Base(dir), initCmdDefinitionType,
+			initCmdDefinitionPath, def)
+	default:
+		err = initSwaggerDefinition(swaggerSavePath, initCmdDefinitionPath, def)
+	}
+	if err != nil {
+		return err
 	}
 
 	// Use the API definition if provided
@@ -263,9 +265,154 @@ func createDirectories(name string) error {
 	return nil
 }
 
-// loadSwagger will Load the swagger definition from swaggerDoc
-// Swagger2.0/OpenAPI3.0 specs are supported
-func loadSwagger(swaggerDoc string) (*loads.Document, error) {
-	utils.Logln(utils.LogPrefixInfo + "Loading swagger from " + swaggerDoc)
-	return loads.Spec(swaggerDoc)
+// loadSwagger will Load the swagger definition from swaggerDoc (a file or a URL) as JSON
+// Swagger2.0/OpenAPI3.x specs are supported
+func loadSwagger(swaggerDoc string) (json.RawMessage, error) {
+	content, err := loadDefinition(swaggerDoc)
+	if err != nil {
+		return nil, err
+	}
+	return utils.YamlToJson(content)
+}
+
+// loadDefinition will Load the content of the definition from definitionDoc (a file or a URL) as it is
+func loadDefinition(definitionDoc string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading definition from " + definitionDoc)
+	// JSONDoc reads the content of the file or the URL without parsing it
+	return loads.JSONDoc(definitionDoc)
+}
+
+// initSwaggerDefinition populates the API using the Swagger/OpenAPI definition in swaggerPath, and writes the
+// definition to swaggerSavePath as YAML
+func initSwaggerDefinition(swaggerSavePath, swaggerPath string, def *v2.APIDTODefinition) error {
+	swaggerContent, err := loadSwagger(swaggerPath)
+	if err != nil {
+		return err
+	}
+	return writeSwaggerDefinition(swaggerSavePath, swaggerContent, def)
+}
+
+// initInferredSwaggerDefinition infers an OpenAPI 3.0 definition from the Postman collection or the HAR file (given
+// by definitionType) in recordingPath, and initializes the API using it. The API is named after the project if the
+// recording does not name it.
+func initInferredSwaggerDefinition(swaggerSavePath, projectName, definitionType, recordingPath string,
+	def *v2.APIDTODefinition) error {
+	recording, err := loadDefinition(recordingPath)
+	if err != nil {
+		return err
+	}
+	var swaggerContent []byte
+	if definitionType == DefinitionTypePostman {
+		swaggerContent, err = v2.PostmanToOAI3(recording, projectName)
+	} else {
+		swaggerContent, err = v2.HARToOAI3(recording, projectName)
+	}
+	if err != nil {
+		return errors.New("Error while inferring the OpenAPI definition from " + recordingPath + ": " + err.Error())
+	}
+	return writeSwaggerDefinition(swaggerSavePath, swaggerContent, def)
+}
+
+// writeSwaggerDefinition populates the API using the given Swagger/OpenAPI definition (in JSON), and writes the
+// definition to swaggerSavePath as YAML
+func writeSwaggerDefinition(swaggerSavePath string, swaggerContent json.RawMessage, def *v2.APIDTODefinition) error {
+	var err error
+	// OpenAPI 3.x definitions are populated from the servers, the security schemes and the extensions of
+	// those, without loading those as Swagger 2.0 definitions
+	if v2.IsOAI3Definition(swaggerContent) {
+		err = v2.OAI3Populate(def, swaggerContent)
+	} else {
+		err = populateFromSwagger2(def, swaggerContent)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Convert and write the swagger definition as yaml
+	yamlSwagger, err := utils.JsonToYaml(swaggerContent)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
+	return ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
+}
+
+// initGraphQLDefinition populates the GraphQL API using the schema in schemaPath, and writes the schema to the
+// project. The API is named after the project, as the schema does not name it.
+func initGraphQLDefinition(initCmdOutputDir, projectName, schemaPath string, def *v2.APIDTODefinition) error {
+	schema, err := loadDefinition(schemaPath)
+	if err != nil {
+		return err
+	}
+	if err := v2.GraphQLPopulate(def, schema); err != nil {
+		return err
+	}
+	def.Name = strings.ReplaceAll(projectName, " ", "")
+	def.Context = "/" + def.Name
+
+	schemaSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsGraphQLSchema))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + schemaSavePath)
+	return ioutil.WriteFile(schemaSavePath, schema, os.ModePerm)
+}
+
+// initAsyncAPIDefinition populates the streaming API using the AsyncAPI definition in asyncAPIPath, and writes the
+// definition to the project as YAML
+func initAsyncAPIDefinition(initCmdOutputDir, asyncAPIPath string, def *v2.APIDTODefinition) error {
+	asyncAPIContent, err := loadSwagger(asyncAPIPath)
+	if err != nil {
+		return err
+	}
+	if err := v2.AsyncAPIPopulate(def, asyncAPIContent); err != nil {
+		return err
+	}
+	yamlAsyncAPI, err := utils.JsonToYaml(asyncAPIContent)
+	if err != nil {
+		return err
+	}
+	asyncAPISavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsAsyncAPI))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + asyncAPISavePath)
+	return ioutil.WriteFile(asyncAPISavePath, yamlAsyncAPI, os.ModePerm)
+}
+
+// initWSDLDefinition populates the SOAP API using the WSDL in wsdlPath, and writes the WSDL to the WSDL directory of
+// the project along with the swagger definition of the resource to which the SOAP requests are passed through
+func initWSDLDefinition(initCmdOutputDir, wsdlPath string, def *v2.APIDTODefinition) error {
+	wsdl, err := loadDefinition(wsdlPath)
+	if err != nil {
+		return err
+	}
+	if err := v2.WSDLPopulate(def, wsdl); err != nil {
+		return err
+	}
+
+	wsdlDir := filepath.Join(initCmdOutputDir, utils.InitProjectWSDL)
+	if err := os.MkdirAll(wsdlDir, os.ModePerm); err != nil {
+		return err
+	}
+	wsdlSavePath := filepath.Join(wsdlDir, def.Name+"-"+def.Version+".wsdl")
+	utils.Logln(utils.LogPrefixInfo + "Writing " + wsdlSavePath)
+	if err := ioutil.WriteFile(wsdlSavePath, wsdl, os.ModePerm); err != nil {
+		return err
+	}
+
+	swaggerContent, err := json.Marshal(v2.WSDLSwagger(def))
+	if err != nil {
+		return err
+	}
+	yamlSwagger, err := utils.JsonToYaml(swaggerContent)
+	if err != nil {
+		return err
+	}
+	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
+	return ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
+}
+
+// populateFromSwagger2 populates the API definition using the given Swagger 2.0 definition
+func populateFromSwagger2(def *v2.APIDTODefinition, swaggerContent json.RawMessage) error {
+	doc, err := loads.Analyzed(swaggerContent, "")
+	if err != nil {
+		return err
+	}
+	return v2.Swagger2Populate(def, doc)
 }
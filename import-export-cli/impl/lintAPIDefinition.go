@@ -0,0 +1,149 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// HTTP verbs of the operations in a Swagger/OpenAPI definition
+var swaggerOperationVerbs = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// Matches the path parameters of a resource path (ie: {petId} of /pets/{petId})
+var swaggerPathParamRegex = regexp.MustCompile(`{([^{}]+)}`)
+
+// LintAPIDefinition checks the Swagger/OpenAPI definition of an API or an API Product project for the issues which
+//  would make the project fail to import, or the API fail to invoke: the version of the definition, the info, the
+//  paths and the responses, the uniqueness of the operation ids and the declaration of the path parameters.
+// projectPath is the path of the API or the API Product project
+// Returns []string, the issues found in the definition, or an error if the definition cannot be read
+func LintAPIDefinition(projectPath string) ([]string, error) {
+	swaggerPath := filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger)
+	if _, err := os.Stat(swaggerPath); os.IsNotExist(err) {
+		// the definition can be in the JSON format as well
+		swaggerPath = strings.TrimSuffix(swaggerPath, filepath.Ext(swaggerPath)) + ".json"
+	}
+	content, err := ioutil.ReadFile(swaggerPath)
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, errors.New("unable to parse " + swaggerPath + ": " + err.Error())
+	}
+	var definition map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &definition); err != nil {
+		return nil, errors.New("unable to parse " + swaggerPath + ": " + err.Error())
+	}
+	return lintSwaggerDefinition(definition), nil
+}
+
+// Returns the issues found in the given Swagger/OpenAPI definition
+func lintSwaggerDefinition(definition map[string]interface{}) []string {
+	var issues []string
+	swaggerVersion, _ := definition["swagger"].(string)
+	openAPIVersion, _ := definition["openapi"].(string)
+	if swaggerVersion != "2.0" && !strings.HasPrefix(openAPIVersion, "3.") {
+		issues = append(issues, "the definition should be either Swagger 2.0 or OpenAPI 3.x")
+	}
+
+	info, _ := definition["info"].(map[string]interface{})
+	if title, _ := info["title"].(string); strings.TrimSpace(title) == "" {
+		issues = append(issues, "info.title should not be empty")
+	}
+	if version, _ := info["version"].(string); strings.TrimSpace(version) == "" {
+		issues = append(issues, "info.version should not be empty")
+	}
+
+	paths, _ := definition["paths"].(map[string]interface{})
+	if len(paths) == 0 {
+		issues = append(issues, "the definition should have at least one path")
+	}
+	var resourcePaths []string
+	for resourcePath := range paths {
+		resourcePaths = append(resourcePaths, resourcePath)
+	}
+	sort.Strings(resourcePaths)
+
+	operationIds := make(map[string]string)
+	for _, resourcePath := range resourcePaths {
+		if !strings.HasPrefix(resourcePath, "/") {
+			issues = append(issues, "path "+resourcePath+" should start with /")
+		}
+		pathItem, _ := paths[resourcePath].(map[string]interface{})
+		pathParams, pathHasRefs := getDeclaredPathParams(pathItem["parameters"])
+		for _, verb := range swaggerOperationVerbs {
+			operation, ok := pathItem[verb].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			resource := strings.ToUpper(verb) + " " + resourcePath
+			if responses, _ := operation["responses"].(map[string]interface{}); len(responses) == 0 {
+				issues = append(issues, resource+" should have at least one response")
+			}
+			if operationId, _ := operation["operationId"].(string); operationId != "" {
+				if otherResource, exists := operationIds[operationId]; exists {
+					issues = append(issues, resource+" has the operationId "+operationId+" of "+otherResource)
+				} else {
+					operationIds[operationId] = resource
+				}
+			}
+			operationParams, operationHasRefs := getDeclaredPathParams(operation["parameters"])
+			if pathHasRefs || operationHasRefs {
+				// the parameters referred using $ref are not resolved, hence those are not checked
+				continue
+			}
+			for _, match := range swaggerPathParamRegex.FindAllStringSubmatch(resourcePath, -1) {
+				if !pathParams[match[1]] && !operationParams[match[1]] {
+					issues = append(issues, resource+" does not declare the path parameter "+match[1])
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// Returns the names of the path parameters in the given list of parameters, and whether any of the parameters is
+//  referred using $ref
+func getDeclaredPathParams(parameters interface{}) (map[string]bool, bool) {
+	pathParams := make(map[string]bool)
+	var hasRefs bool
+	parameterList, _ := parameters.([]interface{})
+	for _, parameter := range parameterList {
+		parameterMap, _ := parameter.(map[string]interface{})
+		if _, isRef := parameterMap["$ref"]; isRef {
+			hasRefs = true
+		}
+		if in, _ := parameterMap["in"].(string); in == "path" {
+			if name, _ := parameterMap["name"].(string); name != "" {
+				pathParams[name] = true
+			}
+		}
+	}
+	return pathParams, hasRefs
+}
@@ -0,0 +1,100 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestLintAPIDefinitionWithValidSwagger(t *testing.T) {
+	projectPath, _ := ioutil.TempDir("", "apictl-lint")
+	defer os.RemoveAll(projectPath)
+	swagger := `swagger: "2.0"
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+paths:
+  /order/{orderId}:
+    parameters:
+    - name: orderId
+      in: path
+      required: true
+      type: string
+    get:
+      operationId: getOrder
+      responses:
+        "200":
+          description: OK
+`
+	assert.Nil(t, os.MkdirAll(filepath.Join(projectPath, utils.InitProjectDefinitions), os.ModePerm),
+		"err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger), []byte(swagger),
+		0644), "err should be nil")
+
+	issues, err := LintAPIDefinition(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Empty(t, issues, "a valid swagger should not have issues")
+}
+
+func TestLintAPIDefinitionWithInvalidSwagger(t *testing.T) {
+	swagger := `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+paths:
+  menu:
+    get:
+      operationId: getMenu
+      responses:
+        "200":
+          description: OK
+  /order/{orderId}:
+    get:
+      operationId: getMenu
+      responses: {}
+`
+	projectPath, _ := ioutil.TempDir("", "apictl-lint")
+	defer os.RemoveAll(projectPath)
+	assert.Nil(t, os.MkdirAll(filepath.Join(projectPath, utils.InitProjectDefinitions), os.ModePerm),
+		"err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger), []byte(swagger),
+		0644), "err should be nil")
+
+	issues, err := LintAPIDefinition(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{
+		"info.version should not be empty",
+		"GET /order/{orderId} should have at least one response",
+		"GET /order/{orderId} does not declare the path parameter orderId",
+		"path menu should start with /",
+		"GET menu has the operationId getMenu of GET /order/{orderId}",
+	}, issues)
+}
+
+func TestLintAPIDefinitionWithoutSwagger(t *testing.T) {
+	projectPath, _ := ioutil.TempDir("", "apictl-lint")
+	defer os.RemoveAll(projectPath)
+	_, err := LintAPIDefinition(projectPath)
+	assert.NotNil(t, err, "err should not be nil when the definition is missing")
+}
@@ -19,22 +19,24 @@
 package mg
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-//DeployAPI creats or updates an API in the microgateway depending on the override param
-func DeployAPI(env, filePath string, extraParams map[string]string,
-	importAPISkipCleanup bool, override bool) {
+//DeployAPI creats or updates an API in the microgateway depending on the override param and writes the result to out
+func DeployAPI(out io.Writer, env, filePath string, extraParams map[string]string,
+	importAPISkipCleanup bool, override bool) error {
 	//TODO: (VirajSalaka) support substituting parameters with params file. At the moment it is in hold on state, as the decision to use environments is
 	//not finalized yet.
 	// if apiFilePath contains a directory, zip it. Otherwise, leave it as it is.
 	filePath, err, cleanupFunc := utils.CreateZipFileFromProject(filePath, importAPISkipCleanup)
 	if err != nil {
-		utils.HandleErrorAndExit("Error adding API to microgateway", err)
+		return errors.New("Error adding API to microgateway. " + err.Error())
 	}
 	//cleanup the temporary artifacts once consuming the zip file
 	if cleanupFunc != nil {
@@ -42,7 +44,7 @@ func DeployAPI(env, filePath string, extraParams map[string]string,
 	}
 	mgwAdapterInfo, err := GetMgwAdapterInfo(env)
 	if err != nil {
-		utils.HandleErrorAndExit("Error retriving stored url and access token to microgateway", err)
+		return errors.New("Error retriving stored url and access token to microgateway. " + err.Error())
 	}
 	endpoint := mgwAdapterInfo.Endpoint + apisResourcePath
 
@@ -52,42 +54,41 @@ func DeployAPI(env, filePath string, extraParams map[string]string,
 	headers[utils.HeaderConnection] = utils.HeaderValueKeepAlive
 
 	if override {
-		UpdateAPI(endpoint, extraParams, headers, "file", filePath)
-	} else {
-		AddAPI(endpoint, extraParams, headers, "file", filePath)
+		return UpdateAPI(out, endpoint, extraParams, headers, "file", filePath)
 	}
+	return AddAPI(out, endpoint, extraParams, headers, "file", filePath)
 }
 
 //AddAPI creats an API in the microgateway
-func AddAPI(endpoint string, extraParams, headers map[string]string,
-	fileParamName string, filePath string) {
+func AddAPI(out io.Writer, endpoint string, extraParams, headers map[string]string,
+	fileParamName string, filePath string) error {
 	resp, err := utils.InvokePOSTRequestWithFileAndQueryParams(extraParams, endpoint, headers,
 		"file", filePath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error deploying API.", err)
+		return errors.New("Error deploying API. " + err.Error())
 	}
 	if resp.StatusCode() == http.StatusOK {
-		fmt.Println("Successfully deployed API to microgateway.")
+		fmt.Fprintln(out, "Successfully deployed API to microgateway.")
+		return nil
 	} else if resp.StatusCode() == http.StatusConflict {
-		fmt.Println("Unable to deploy API. API already exists. Status: " + resp.Status())
-	} else {
-		fmt.Println("Unable to deploy API. Error Status: " + resp.Status())
+		return utils.NewHttpResponseError(resp, "Unable to deploy API. API already exists. Status: "+resp.Status())
 	}
+	return utils.NewHttpResponseError(resp, "Unable to deploy API. Error Status: "+resp.Status())
 }
 
 //UpdateAPI updates an API in the microgateway
-func UpdateAPI(endpoint string, extraParams, headers map[string]string,
-	fileParamName string, filePath string) {
+func UpdateAPI(out io.Writer, endpoint string, extraParams, headers map[string]string,
+	fileParamName string, filePath string) error {
 
 	endpoint += "?override=" + strconv.FormatBool(true)
 	resp, err := utils.InvokePOSTRequestWithFileAndQueryParams(extraParams, endpoint, headers,
 		"file", filePath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error updating API.", err)
+		return errors.New("Error updating API. " + err.Error())
 	}
 	if resp.StatusCode() == http.StatusOK {
-		fmt.Println("Successfully deployed/updated the API in microgateway.")
-	} else {
-		fmt.Println("Unable to update API. Error Status: " + resp.Status())
+		fmt.Fprintln(out, "Successfully deployed/updated the API in microgateway.")
+		return nil
 	}
+	return utils.NewHttpResponseError(resp, "Unable to update API. Error Status: "+resp.Status())
 }
@@ -19,7 +19,6 @@
 package mg
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -45,7 +44,7 @@ func UndeployAPI(env string, queryParam map[string]string) (err error) {
 	if resp.StatusCode() == http.StatusOK {
 		return nil
 	} else if resp.StatusCode() == http.StatusNotFound {
-		return errors.New("the API does not exist")
+		return utils.NewHttpResponseError(resp, "the API does not exist")
 	}
-	return errors.New(string(resp.Body()))
+	return utils.NewHttpResponseError(resp, string(resp.Body()))
 }
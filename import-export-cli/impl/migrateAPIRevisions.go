@@ -0,0 +1,212 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// deploymentEnvironmentsFile is the deployment_environments.yaml of an API archive, having the gateway environments
+// the API is deployed in
+type deploymentEnvironmentsFile struct {
+	Data []struct {
+		DisplayOnDevportal    bool   `yaml:"displayOnDevportal"`
+		DeploymentEnvironment string `yaml:"deploymentEnvironment"`
+		DeploymentVhost       string `yaml:"deploymentVhost"`
+	} `yaml:"data"`
+}
+
+// CreateMigratedAPIRevision creates a revision of an API from its working copy, after the revision archive of the API
+// is imported (without the deployments) in a tenant migration. The revision is deployed later using
+// DeployMigratedAPIRevision, to the gateway environments in the deployment_environments.yaml of the archive.
+// If rotateRevision is true and the API has reached the maximum number of revisions, the earliest undeployed revision
+// of the API is deleted and the revision is created again.
+// Returns *utils.MigratedAPIRevision, the revision created
+// Returns string, the ID of the revision deleted to rotate the revisions, or empty if none was deleted
+func CreateMigratedAPIRevision(accessToken, environment, archivePath string,
+	rotateRevision bool) (*utils.MigratedAPIRevision, string, error) {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(archivePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+	api, _, err := GetAPIDefinition(tmpPath)
+	if err != nil {
+		return nil, "", err
+	}
+	deployments, err := getArchiveDeployments(tmpPath)
+	if err != nil {
+		return nil, "", err
+	}
+	apiId, err := GetAPIId(accessToken, environment, api.Data.Name, api.Data.Version, api.Data.Provider)
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	description := "Migrated from " + filepath.Base(archivePath)
+	revisionId, err := createAPIRevision(accessToken, apiListEndpoint, apiId, description)
+	var rotatedRevisionId string
+	if err != nil && rotateRevision {
+		utils.Logln(utils.LogPrefixWarning + "Rotating the revisions of " + api.Data.Name + " as the revision " +
+			"cannot be created: " + err.Error())
+		rotatedRevisionId, err = deleteEarliestUndeployedAPIRevision(accessToken, apiListEndpoint, apiId)
+		if err != nil {
+			return nil, "", err
+		}
+		revisionId, err = createAPIRevision(accessToken, apiListEndpoint, apiId, description)
+	}
+	if err != nil {
+		return nil, rotatedRevisionId, err
+	}
+	fmt.Println("Created the revision of " + api.Data.Name + " " + api.Data.Version + " from " +
+		filepath.Base(archivePath))
+	return &utils.MigratedAPIRevision{
+		Archive:     filepath.Base(archivePath),
+		APIId:       apiId,
+		RevisionId:  revisionId,
+		Deployments: deployments,
+	}, rotatedRevisionId, nil
+}
+
+// DeployMigratedAPIRevision deploys a revision created using CreateMigratedAPIRevision to its gateway environments
+func DeployMigratedAPIRevision(accessToken, environment string, revision *utils.MigratedAPIRevision) error {
+	if len(revision.Deployments) == 0 {
+		fmt.Println("The revision of " + revision.Archive + " was not deployed in the source environment")
+		return nil
+	}
+	deployRevisionEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + revision.APIId + "/deploy-revision?revisionId=" + revision.RevisionId
+	utils.Logln(utils.LogPrefixInfo+"Deploy URL:", deployRevisionEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	body, err := json.Marshal(revision.Deployments)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePOSTRequest(deployRevisionEndpoint, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpResponseError(resp, "Error deploying the revision of "+revision.Archive+". Status: "+
+			resp.Status())
+	}
+	var gateways []string
+	for _, deployment := range revision.Deployments {
+		gateways = append(gateways, deployment.Name)
+	}
+	fmt.Println("Deployed the revision of " + revision.Archive + " to " + strings.Join(gateways, ", "))
+	return nil
+}
+
+// Returns the gateway environments in the deployment_environments.yaml of an API archive, or nil if the archive does
+// not have the file
+func getArchiveDeployments(apiPath string) ([]utils.Deployment, error) {
+	deploymentEnvFilePath := filepath.Join(apiPath, utils.DeploymentEnvFile)
+	if !utils.IsFileExist(deploymentEnvFilePath) {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(deploymentEnvFilePath)
+	if err != nil {
+		return nil, err
+	}
+	var deploymentEnvironments deploymentEnvironmentsFile
+	if err := yaml.Unmarshal(content, &deploymentEnvironments); err != nil {
+		return nil, err
+	}
+	var deployments []utils.Deployment
+	for _, deploymentEnvironment := range deploymentEnvironments.Data {
+		deployments = append(deployments, utils.Deployment{
+			Name:               deploymentEnvironment.DeploymentEnvironment,
+			Vhost:              deploymentEnvironment.DeploymentVhost,
+			DisplayOnDevportal: deploymentEnvironment.DisplayOnDevportal,
+		})
+	}
+	return deployments, nil
+}
+
+// Creates a revision of an API from its working copy
+// Returns string, the ID of the revision
+func createAPIRevision(accessToken, apiListEndpoint, apiId, description string) (string, error) {
+	revisionsEndpoint := utils.AppendSlashToString(apiListEndpoint) + apiId + "/revisions"
+	utils.Logln(utils.LogPrefixInfo+"Create revision URL:", revisionsEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	body, err := json.Marshal(map[string]string{"description": description})
+	if err != nil {
+		return "", err
+	}
+	resp, err := utils.InvokePOSTRequest(revisionsEndpoint, headers, string(body))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return "", utils.NewHttpResponseError(resp, "Error creating the revision. Status: "+resp.Status())
+	}
+	var revision utils.Revisions
+	if err := json.Unmarshal(resp.Body(), &revision); err != nil {
+		return "", err
+	}
+	return revision.ID, nil
+}
+
+// Deletes the earliest revision of an API which is not deployed in any gateway environment, in order to create a new
+// revision when the API has reached the maximum number of revisions
+// Returns string, the ID of the revision deleted
+func deleteEarliestUndeployedAPIRevision(accessToken, apiListEndpoint, apiId string) (string, error) {
+	revisionsEndpoint := utils.AppendSlashToString(apiListEndpoint) + apiId + "/revisions"
+	_, revisions, err := GetRevisionsList(accessToken, revisionsEndpoint)
+	if err != nil {
+		return "", err
+	}
+	// the revisions are listed in the order those were created
+	for _, revision := range revisions {
+		if len(revision.Deployments) > 0 {
+			continue
+		}
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		resp, err := utils.InvokeDELETERequest(revisionsEndpoint+"/"+revision.ID, headers)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+			return "", utils.NewHttpResponseError(resp, "Error deleting the revision "+revision.RevisionNumber+
+				". Status: "+resp.Status())
+		}
+		fmt.Println("Deleted the " + revision.RevisionNumber + " to rotate the revisions")
+		return revision.ID, nil
+	}
+	return "", errors.New("all the revisions of the API are deployed, hence cannot rotate the revisions")
+}
@@ -0,0 +1,53 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetArchiveDeployments(t *testing.T) {
+	apiPath, err := ioutil.TempDir("", "apictl-migrate-revisions")
+	assert.Nil(t, err, "err should be nil")
+	defer os.RemoveAll(apiPath)
+
+	deployments, err := getArchiveDeployments(apiPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Empty(t, deployments, "an archive without the deployment environments should not be deployed")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(apiPath, utils.DeploymentEnvFile), []byte(`type: deployment_environments
+version: v4.0.0
+data:
+ - displayOnDevportal: true
+   deploymentEnvironment: Default
+   deploymentVhost: localhost
+ - displayOnDevportal: false
+   deploymentEnvironment: Internal
+`), 0644), "err should be nil")
+	deployments, err = getArchiveDeployments(apiPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []utils.Deployment{{Name: "Default", Vhost: "localhost", DisplayOnDevportal: true},
+		{Name: "Internal"}}, deployments)
+}
@@ -0,0 +1,187 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Steps of migrating a tenant, in the order those are run. The APIs are imported before the API Products and the
+// Applications, since the API Products consist of the APIs and the Applications subscribe to the APIs and the API
+// Products. The revisions of the APIs created in the import-apis step are deployed in the deploy-revisions step.
+const (
+	TenantMigrationStepExportAPIs        = "export-apis"
+	TenantMigrationStepExportAPIProducts = "export-api-products"
+	TenantMigrationStepExportApps        = "export-apps"
+	TenantMigrationStepImportAPIs        = "import-apis"
+	TenantMigrationStepDeployRevisions   = "deploy-revisions"
+	TenantMigrationStepImportAPIProducts = "import-api-products"
+	TenantMigrationStepImportApps        = "import-apps"
+)
+
+// TenantMigrationSteps are the steps of migrating a tenant in the order those are run
+var TenantMigrationSteps = []string{
+	TenantMigrationStepExportAPIs,
+	TenantMigrationStepExportAPIProducts,
+	TenantMigrationStepExportApps,
+	TenantMigrationStepImportAPIs,
+	TenantMigrationStepDeployRevisions,
+	TenantMigrationStepImportAPIProducts,
+	TenantMigrationStepImportApps,
+}
+
+// Prepare the migration of a tenant. The previous migration of the same tenant between the same environments is
+// resumed from the step it was halted at, unless startFromBeginning is true.
+// migrationRelatedFilesPath is the directory to keep the state of the migration
+// Returns *utils.MigrationTenantMetadata, the state of the migration
+func PrepareTenantMigration(migrationRelatedFilesPath, from, to, tenant, cmdUsername string,
+	startFromBeginning bool) *utils.MigrationTenantMetadata {
+	metadataFilePath := filepath.Join(migrationRelatedFilesPath, utils.MigrationTenantMetadataFileName)
+	if !startFromBeginning && utils.IsFileExist(metadataFilePath) {
+		var migrationMetadata utils.MigrationTenantMetadata
+		if err := migrationMetadata.ReadMigrationTenantMetadataFile(metadataFilePath); err != nil {
+			utils.HandleErrorAndExit("Error loading metadata for resume from "+metadataFilePath, err)
+		}
+		if migrationMetadata.From == from && migrationMetadata.To == to {
+			if len(migrationMetadata.CompletedSteps) > 0 {
+				fmt.Println("Resuming the previous migration after the completed steps: " +
+					strings.Join(migrationMetadata.CompletedSteps, ", "))
+			}
+			if migrationMetadata.FailedImports == nil {
+				migrationMetadata.FailedImports = make(map[string]int)
+			}
+			return &migrationMetadata
+		}
+		fmt.Println("The previous migration was from " + migrationMetadata.From + " to " + migrationMetadata.To +
+			", hence migrating from " + from + " to " + to + " from beginning")
+	}
+
+	migrationMetadata := &utils.MigrationTenantMetadata{
+		From:          from,
+		To:            to,
+		Tenant:        tenant,
+		User:          cmdUsername,
+		FailedImports: make(map[string]int),
+	}
+	migrationMetadata.WriteMigrationTenantMetadataFile(metadataFilePath)
+	return migrationMetadata
+}
+
+// IsTenantMigrationStepCompleted returns whether the given step of the migration has been completed
+func IsTenantMigrationStepCompleted(migrationMetadata *utils.MigrationTenantMetadata, step string) bool {
+	for _, completedStep := range migrationMetadata.CompletedSteps {
+		if completedStep == step {
+			return true
+		}
+	}
+	return false
+}
+
+// StartTenantMigrationStep records the given step as the step in progress of the migration
+// Returns bool, whether the step should be started from beginning. A step is resumed only if the migration was halted
+// in the middle of the same step
+func StartTenantMigrationStep(migrationRelatedFilesPath string, migrationMetadata *utils.MigrationTenantMetadata,
+	step string) bool {
+	if migrationMetadata.CurrentStep == step {
+		return false
+	}
+	migrationMetadata.CurrentStep = step
+	migrationMetadata.WriteMigrationTenantMetadataFile(filepath.Join(migrationRelatedFilesPath,
+		utils.MigrationTenantMetadataFileName))
+	return true
+}
+
+// CompleteTenantMigrationStep records the given step as completed along with the number of artifacts failed to import
+// in the step, if any. The deploy-revisions step with failures is not recorded as completed, so that the revisions
+// failed to deploy are deployed again with the next run of the migration.
+func CompleteTenantMigrationStep(migrationRelatedFilesPath string, migrationMetadata *utils.MigrationTenantMetadata,
+	step string, failedCount int) {
+	if failedCount > 0 {
+		migrationMetadata.FailedImports[step] = failedCount
+	} else {
+		delete(migrationMetadata.FailedImports, step)
+	}
+	if !IsTenantMigrationStepCompleted(migrationMetadata, step) &&
+		(step != TenantMigrationStepDeployRevisions || failedCount == 0) {
+		migrationMetadata.CompletedSteps = append(migrationMetadata.CompletedSteps, step)
+	}
+	migrationMetadata.CurrentStep = ""
+	migrationMetadata.WriteMigrationTenantMetadataFile(filepath.Join(migrationRelatedFilesPath,
+		utils.MigrationTenantMetadataFileName))
+}
+
+// RecordMigratedAPIRevision records a revision created in the import-apis step of the migration, to be deployed in the
+// deploy-revisions step. The revision recorded earlier for the same archive, if the archive is imported again when
+// resuming, and the revision deleted to rotate the revisions, if any, are not deployed.
+func RecordMigratedAPIRevision(migrationRelatedFilesPath string, migrationMetadata *utils.MigrationTenantMetadata,
+	revision *utils.MigratedAPIRevision, rotatedRevisionId string) {
+	var revisions []utils.MigratedAPIRevision
+	for _, recordedRevision := range migrationMetadata.APIRevisions {
+		if recordedRevision.Archive == revision.Archive {
+			continue
+		}
+		if rotatedRevisionId != "" && recordedRevision.RevisionId == rotatedRevisionId {
+			fmt.Println("The revision of " + recordedRevision.Archive + " is deleted to rotate the revisions, " +
+				"hence it will not be deployed")
+			continue
+		}
+		revisions = append(revisions, recordedRevision)
+	}
+	migrationMetadata.APIRevisions = append(revisions, *revision)
+	migrationMetadata.WriteMigrationTenantMetadataFile(filepath.Join(migrationRelatedFilesPath,
+		utils.MigrationTenantMetadataFileName))
+}
+
+// DeployMigratedAPIRevisions deploys the revisions created in the import-apis step of the migration to the gateway
+// environments those were deployed in the source environment, in the order those were created. The revisions
+// deployed already are skipped, so that a halted deploy-revisions step is resumed.
+// Returns int, the number of revisions failed to deploy
+func DeployMigratedAPIRevisions(credential credentials.Credential, migrationRelatedFilesPath, environment string,
+	migrationMetadata *utils.MigrationTenantMetadata) int {
+	metadataFilePath := filepath.Join(migrationRelatedFilesPath, utils.MigrationTenantMetadataFileName)
+	accessToken, err := credentials.GetOAuthAccessToken(credential, environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting an access token for deploying the revisions. Run the "+
+			"command again to resume the migration", err)
+	}
+	total := len(migrationMetadata.APIRevisions)
+	failedCount := 0
+	for i := range migrationMetadata.APIRevisions {
+		revision := &migrationMetadata.APIRevisions[i]
+		if revision.Deployed {
+			continue
+		}
+		fmt.Println("\n[" + strconv.Itoa(i+1) + "/" + strconv.Itoa(total) + "] Deploying the revision of " +
+			revision.Archive)
+		if err := DeployMigratedAPIRevision(accessToken, environment, revision); err != nil {
+			fmt.Println("Error deploying the revision of " + revision.Archive + ": " + strings.TrimSpace(err.Error()))
+			failedCount++
+			continue
+		}
+		revision.Deployed = true
+		migrationMetadata.WriteMigrationTenantMetadataFile(metadataFilePath)
+	}
+	fmt.Println("\nTotal number of revisions failed to deploy: " + strconv.Itoa(failedCount))
+	return failedCount
+}
@@ -0,0 +1,87 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestTenantMigrationResumes(t *testing.T) {
+	migrationRelatedFilesPath, _ := ioutil.TempDir("", "apictl-migrate-tenant")
+	defer os.RemoveAll(migrationRelatedFilesPath)
+
+	migrationMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "prod-new", "wso2.com", "admin",
+		false)
+	assert.True(t, StartTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, TenantMigrationStepExportAPIs),
+		"a new step should be started from beginning")
+	CompleteTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, TenantMigrationStepExportAPIs, 0)
+	StartTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, TenantMigrationStepImportAPIs)
+
+	resumedMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "prod-new", "wso2.com", "admin", false)
+	assert.True(t, IsTenantMigrationStepCompleted(resumedMetadata, TenantMigrationStepExportAPIs))
+	assert.False(t, IsTenantMigrationStepCompleted(resumedMetadata, TenantMigrationStepImportAPIs))
+	assert.False(t, StartTenantMigrationStep(migrationRelatedFilesPath, resumedMetadata, TenantMigrationStepImportAPIs),
+		"the step halted at should be resumed")
+	CompleteTenantMigrationStep(migrationRelatedFilesPath, resumedMetadata, TenantMigrationStepImportAPIs, 2)
+	assert.Equal(t, map[string]int{TenantMigrationStepImportAPIs: 2}, resumedMetadata.FailedImports)
+
+	otherMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "dev", "wso2.com", "admin", false)
+	assert.Empty(t, otherMetadata.CompletedSteps, "the migration to another environment should start from beginning")
+	forcedMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "dev", "wso2.com", "admin", true)
+	assert.Empty(t, forcedMetadata.CompletedSteps, "the migration should start from beginning with force")
+}
+
+func TestRecordMigratedAPIRevision(t *testing.T) {
+	migrationRelatedFilesPath, _ := ioutil.TempDir("", "apictl-migrate-tenant")
+	defer os.RemoveAll(migrationRelatedFilesPath)
+
+	migrationMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "prod-new", "wso2.com", "admin",
+		false)
+	RecordMigratedAPIRevision(migrationRelatedFilesPath, migrationMetadata, &utils.MigratedAPIRevision{
+		Archive: "PizzaShackAPI_1.0.0_Revision-1.zip", APIId: "api", RevisionId: "revision-1"}, "")
+	RecordMigratedAPIRevision(migrationRelatedFilesPath, migrationMetadata, &utils.MigratedAPIRevision{
+		Archive: "PizzaShackAPI_1.0.0_Revision-2.zip", APIId: "api", RevisionId: "revision-2"}, "")
+	RecordMigratedAPIRevision(migrationRelatedFilesPath, migrationMetadata, &utils.MigratedAPIRevision{
+		Archive: "PizzaShackAPI_1.0.0_Revision-2.zip", APIId: "api", RevisionId: "revision-3"}, "revision-1")
+
+	resumedMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "prod-new", "wso2.com", "admin",
+		false)
+	assert.Equal(t, []utils.MigratedAPIRevision{{Archive: "PizzaShackAPI_1.0.0_Revision-2.zip", APIId: "api",
+		RevisionId: "revision-3"}}, resumedMetadata.APIRevisions,
+		"the revisions imported again and deleted to rotate the revisions should not be deployed")
+}
+
+func TestDeployRevisionsStepWithFailuresIsNotCompleted(t *testing.T) {
+	migrationRelatedFilesPath, _ := ioutil.TempDir("", "apictl-migrate-tenant")
+	defer os.RemoveAll(migrationRelatedFilesPath)
+
+	migrationMetadata := PrepareTenantMigration(migrationRelatedFilesPath, "prod", "prod-new", "wso2.com", "admin",
+		false)
+	CompleteTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, TenantMigrationStepDeployRevisions, 1)
+	assert.False(t, IsTenantMigrationStepCompleted(migrationMetadata, TenantMigrationStepDeployRevisions),
+		"the revisions failed to deploy should be deployed with the next run")
+	assert.Equal(t, map[string]int{TenantMigrationStepDeployRevisions: 1}, migrationMetadata.FailedImports)
+	CompleteTenantMigrationStep(migrationRelatedFilesPath, migrationMetadata, TenantMigrationStepDeployRevisions, 0)
+	assert.True(t, IsTenantMigrationStepCompleted(migrationMetadata, TenantMigrationStepDeployRevisions))
+	assert.Empty(t, migrationMetadata.FailedImports)
+}
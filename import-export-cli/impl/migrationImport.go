@@ -0,0 +1,317 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Matches the revision suffix of the archives written by "export apis --all" (e.g. MyAPI_1.0.0_Revision-1)
+var archiveRevisionSuffixRegex = regexp.MustCompile(`^(.*)_Revision-(\d+)$`)
+
+// MigrationImport is a bulk import of the archives of a type of artifacts in a directory, such as the artifacts
+// exported using "export apis", "export api-products" or "export apps"
+type MigrationImport struct {
+	// Type of the artifacts to be shown in the messages (e.g. API)
+	ArtifactType string
+	// Names of the files which keep the state of the import, in order to resume the import
+	MetadataFileName     string
+	LastImportedFileName string
+	// Directory having the params of each artifact (see getArchiveParamsPath). If empty, no params are used
+	ParamsDir string
+	// Imports a single archive to the environment. update is true if an archive of the same artifact (e.g. a revision
+	// of the API) is imported before the archive, hence the archive should update the artifact
+	ImportArchive func(accessToken, archivePath, paramsPath string, update bool) error
+}
+
+// NewAPIsMigrationImport returns the bulk import of API archives
+func NewAPIsMigrationImport(paramsDir string,
+	importArchive func(accessToken, archivePath, paramsPath string, update bool) error) *MigrationImport {
+	return &MigrationImport{
+		ArtifactType:         "API",
+		MetadataFileName:     utils.MigrationAPIsImportMetadataFileName,
+		LastImportedFileName: utils.LastImportedApiFileName,
+		ParamsDir:            paramsDir,
+		ImportArchive:        importArchive,
+	}
+}
+
+// NewAPIProductsMigrationImport returns the bulk import of API Product archives
+func NewAPIProductsMigrationImport(paramsDir string,
+	importArchive func(accessToken, archivePath, paramsPath string, update bool) error) *MigrationImport {
+	return &MigrationImport{
+		ArtifactType:         "API Product",
+		MetadataFileName:     utils.MigrationAPIProductsImportMetadataFileName,
+		LastImportedFileName: utils.LastImportedApiProductFileName,
+		ParamsDir:            paramsDir,
+		ImportArchive:        importArchive,
+	}
+}
+
+// NewAppsMigrationImport returns the bulk import of Application archives
+func NewAppsMigrationImport(importArchive func(accessToken, archivePath, paramsPath string, update bool) error) *MigrationImport {
+	return &MigrationImport{
+		ArtifactType:         "Application",
+		MetadataFileName:     utils.MigrationAppsImportMetadataFileName,
+		LastImportedFileName: utils.LastImportedAppFileName,
+		ImportArchive:        importArchive,
+	}
+}
+
+// Prepare the import of the archives in the source directory. The previous import of the same source directory to
+// the same environment is resumed from the archive after the one in the last imported file, unless
+// startFromBeginning is true.
+// Returns *utils.MigrationImportMetadata, the state of the import
+// Returns int, the index of the archive to start importing from
+func (migrationImport *MigrationImport) Prepare(importRelatedFilesPath, source, importEnvironment, cmdUsername string,
+	startFromBeginning bool) (*utils.MigrationImportMetadata, int) {
+	metadataFilePath := filepath.Join(importRelatedFilesPath, migrationImport.MetadataFileName)
+	lastImportedFilePath := filepath.Join(importRelatedFilesPath, migrationImport.LastImportedFileName)
+	if !startFromBeginning && utils.IsFileExist(metadataFilePath) && utils.IsFileExist(lastImportedFilePath) {
+		var importMetadata utils.MigrationImportMetadata
+		if err := importMetadata.ReadMigrationImportMetadataFile(metadataFilePath); err != nil {
+			utils.HandleErrorAndExit("Error loading metadata for resume from "+metadataFilePath, err)
+		}
+		if importMetadata.Source == source && importMetadata.Environment == importEnvironment {
+			lastImportedArchive := utils.ReadLastImportedFileData(lastImportedFilePath)
+			fmt.Println("Resuming the previous import of " + source + " after " + lastImportedArchive)
+			if importMetadata.FailedArchives == nil {
+				importMetadata.FailedArchives = make(map[string]string)
+			}
+			return &importMetadata, getArchiveIndex(importMetadata.ArchivesToImport, lastImportedArchive) + 1
+		}
+		fmt.Println("The previous import was of " + importMetadata.Source + " to " + importMetadata.Environment +
+			", hence importing " + source + " to " + importEnvironment + " from beginning")
+	}
+
+	//cleaning existing old files (if exists) related to the import
+	if err := utils.RemoveFileIfExists(metadataFilePath); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to import", err)
+	}
+	if err := utils.RemoveFileIfExists(lastImportedFilePath); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to import", err)
+	}
+	archives, err := getArchivesToImport(source)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while reading the archives in "+source, err)
+	}
+	importMetadata := &utils.MigrationImportMetadata{
+		Source:           source,
+		Environment:      importEnvironment,
+		User:             cmdUsername,
+		ArchivesToImport: archives,
+		FailedArchives:   make(map[string]string),
+	}
+	importMetadata.WriteMigrationImportMetadataFile(metadataFilePath)
+	return importMetadata, 0
+}
+
+// Import the archives of the import state one by one starting from the given index. An archive failed to import is
+// recorded in the import state, and the import continues with the next archive. The archives failed before resuming
+// are retried first.
+// Returns int, the number of archives failed to import, including the ones failed before resuming
+func (migrationImport *MigrationImport) Import(credential credentials.Credential, importRelatedFilesPath,
+	importEnvironment string, importMetadata *utils.MigrationImportMetadata, startingIndex int) int {
+	metadataFilePath := filepath.Join(importRelatedFilesPath, migrationImport.MetadataFileName)
+	lastImportedFilePath := filepath.Join(importRelatedFilesPath, migrationImport.LastImportedFileName)
+	total := len(importMetadata.ArchivesToImport)
+	archiveIndexes := getArchiveIndexesToImport(importMetadata, startingIndex)
+	if len(archiveIndexes) == 0 {
+		fmt.Println("No " + migrationImport.ArtifactType + "s available to be imported..!")
+	}
+	if len(importMetadata.FailedArchives) > 0 && startingIndex > 0 {
+		fmt.Println("Retrying the " + strconv.Itoa(len(importMetadata.FailedArchives)) + " " +
+			migrationImport.ArtifactType + "(s) failed before resuming")
+	}
+	var accessToken string
+	for n, i := range archiveIndexes {
+		// a new access token is taken for each batch of archives, so that the token does not expire in the middle
+		if n%utils.MaxArchivesToImportOnce == 0 {
+			var err error
+			accessToken, err = credentials.GetOAuthAccessToken(credential, importEnvironment)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while getting an access token for importing the "+
+					migrationImport.ArtifactType+"s. Run the command again to resume the import", err)
+			}
+		}
+
+		archive := importMetadata.ArchivesToImport[i]
+		fmt.Println("\n[" + strconv.Itoa(i+1) + "/" + strconv.Itoa(total) + "] Importing " + archive)
+		paramsPath := getArchiveParamsPath(migrationImport.ParamsDir, archive)
+		if paramsPath != "" {
+			utils.Logln(utils.LogPrefixInfo + "Using the params at " + paramsPath)
+		}
+		update := isUpdateArchive(importMetadata.ArchivesToImport, i)
+		err := migrationImport.ImportArchive(accessToken, filepath.Join(importMetadata.Source, archive), paramsPath,
+			update)
+		recordArchiveImportResult(importMetadata, archive, err)
+		importMetadata.WriteMigrationImportMetadataFile(metadataFilePath)
+		// the last imported file keeps the progress through the archives, which the retried archives are behind
+		if i >= startingIndex {
+			utils.WriteLastImportedFileData(lastImportedFilePath, archive)
+		}
+	}
+	migrationImport.printSummary(importMetadata)
+	return len(importMetadata.FailedArchives)
+}
+
+// Returns the indexes of the archives to import, which are the archives failed before the starting index (in the
+// order to import) followed by the archives from the starting index
+func getArchiveIndexesToImport(importMetadata *utils.MigrationImportMetadata, startingIndex int) []int {
+	var archiveIndexes []int
+	for i, archive := range importMetadata.ArchivesToImport {
+		if _, failed := importMetadata.FailedArchives[archive]; i >= startingIndex || failed {
+			archiveIndexes = append(archiveIndexes, i)
+		}
+	}
+	return archiveIndexes
+}
+
+// Prints the number of archives imported and failed, along with the error of each failed archive
+func (migrationImport *MigrationImport) printSummary(importMetadata *utils.MigrationImportMetadata) {
+	fmt.Println("\nTotal number of " + migrationImport.ArtifactType + "s imported: " +
+		strconv.Itoa(len(importMetadata.ImportedArchives)))
+	fmt.Println("Total number of " + migrationImport.ArtifactType + "s failed: " +
+		strconv.Itoa(len(importMetadata.FailedArchives)))
+	for _, archive := range importMetadata.ArchivesToImport {
+		if importErr, failed := importMetadata.FailedArchives[archive]; failed {
+			fmt.Println("  " + archive + ": " + importErr)
+		}
+	}
+}
+
+// Records the result of importing an archive in the import state
+// err is the error occurred while importing the archive, or nil if imported successfully
+func recordArchiveImportResult(importMetadata *utils.MigrationImportMetadata, archive string, err error) {
+	if err != nil {
+		fmt.Println("Error importing " + archive + ": " + strings.TrimSpace(err.Error()))
+		importMetadata.FailedArchives[archive] = strings.TrimSpace(err.Error())
+		return
+	}
+	delete(importMetadata.FailedArchives, archive)
+	for _, importedArchive := range importMetadata.ImportedArchives {
+		if importedArchive == archive {
+			return
+		}
+	}
+	importMetadata.ImportedArchives = append(importMetadata.ImportedArchives, archive)
+}
+
+// Returns the names of the archives (zip files) in the source directory in the order to import. The revisions of an
+// API exported using "export apis --all" are ordered by the revision number before its working copy, so that the
+// working copy imported last is left as the working copy of the API.
+func getArchivesToImport(source string) ([]string, error) {
+	files, err := ioutil.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	var archives []string
+	for _, file := range files {
+		if !file.IsDir() && strings.EqualFold(filepath.Ext(file.Name()), ".zip") {
+			archives = append(archives, file.Name())
+		}
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		firstName, firstRevision := splitArchiveRevision(archives[i])
+		secondName, secondRevision := splitArchiveRevision(archives[j])
+		if firstName != secondName {
+			return firstName < secondName
+		}
+		// the working copy (0) is ordered after the revisions
+		if firstRevision == 0 || secondRevision == 0 {
+			return secondRevision == 0 && firstRevision != 0
+		}
+		return firstRevision < secondRevision
+	})
+	return archives, nil
+}
+
+// Returns whether the archive at the given index is of the same artifact as the archive before it, such as a revision
+// or the working copy of an API imported after an earlier revision of the API
+func isUpdateArchive(archives []string, index int) bool {
+	if index == 0 {
+		return false
+	}
+	previousName, _ := splitArchiveRevision(archives[index-1])
+	name, _ := splitArchiveRevision(archives[index])
+	return previousName == name
+}
+
+// Returns the name of an archive without the extension and the revision suffix, along with the revision number (0 for
+// the working copy)
+func splitArchiveRevision(archive string) (string, int) {
+	name := strings.TrimSuffix(archive, filepath.Ext(archive))
+	if matches := archiveRevisionSuffixRegex.FindStringSubmatch(name); matches != nil {
+		revision, _ := strconv.Atoi(matches[2])
+		return matches[1], revision
+	}
+	return name, 0
+}
+
+// IsRevisionArchive returns whether the archive is of a revision of an API, such as the revisions exported using
+// "export apis --all", rather than the working copy of the API
+func IsRevisionArchive(archivePath string) bool {
+	_, revision := splitArchiveRevision(filepath.Base(archivePath))
+	return revision > 0
+}
+
+// Returns the params of an archive in the params directory. The params of MyAPI_1.0.0_Revision-1.zip are looked up
+// as a deployment directory (generated using "gen deployment-dir") or a params file in the order of
+// <paramsDir>/MyAPI_1.0.0_Revision-1, <paramsDir>/MyAPI_1.0.0_Revision-1.yaml, <paramsDir>/MyAPI_1.0.0 and
+// <paramsDir>/MyAPI_1.0.0.yaml
+// Returns string, the path of the params or empty if the archive does not have params
+func getArchiveParamsPath(paramsDir, archive string) string {
+	if paramsDir == "" {
+		return ""
+	}
+	archiveName := strings.TrimSuffix(archive, filepath.Ext(archive))
+	name, _ := splitArchiveRevision(archive)
+	for _, paramsName := range []string{archiveName, name} {
+		paramsPath := filepath.Join(paramsDir, paramsName)
+		if info, err := os.Stat(paramsPath); err == nil && info.IsDir() {
+			return paramsPath
+		}
+		for _, extension := range []string{".yaml", ".yml"} {
+			if utils.IsFileExist(paramsPath + extension) {
+				return paramsPath + extension
+			}
+		}
+	}
+	return ""
+}
+
+// get the index of the archive processed last from the list of archives in the import metadata file
+func getArchiveIndex(archives []string, lastImportedArchive string) int {
+	for i, archive := range archives {
+		if archive == lastImportedArchive {
+			return i
+		}
+	}
+	return -1
+}
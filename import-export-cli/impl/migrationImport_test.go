@@ -0,0 +1,131 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Creates a source directory having the given archives
+func createArchivesSource(t *testing.T, archives ...string) string {
+	source, err := ioutil.TempDir("", "apictl-migration-import")
+	assert.Nil(t, err, "err should be nil")
+	for _, archive := range archives {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(source, archive), []byte("zip"), 0644), "err should be nil")
+	}
+	return source
+}
+
+func TestGetArchivesToImport(t *testing.T) {
+	source := createArchivesSource(t, "PizzaShackAPI_1.0.0_Revision-10.zip", "PizzaShackAPI_1.0.0_Revision-2.zip",
+		"PizzaShackAPI_1.0.0.zip", "Aardvark_2.0.0.zip", "notes.txt")
+	defer os.RemoveAll(source)
+	assert.Nil(t, os.Mkdir(filepath.Join(source, "Directory.zip"), os.ModePerm), "err should be nil")
+
+	archives, err := getArchivesToImport(source)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"Aardvark_2.0.0.zip", "PizzaShackAPI_1.0.0_Revision-2.zip",
+		"PizzaShackAPI_1.0.0_Revision-10.zip", "PizzaShackAPI_1.0.0.zip"}, archives,
+		"revisions should be ordered by the revision number before the working copy")
+}
+
+func TestIsUpdateArchive(t *testing.T) {
+	archives := []string{"Aardvark_2.0.0.zip", "PizzaShackAPI_1.0.0_Revision-2.zip",
+		"PizzaShackAPI_1.0.0_Revision-10.zip", "PizzaShackAPI_1.0.0.zip"}
+	assert.False(t, isUpdateArchive(archives, 0), "the first archive should create the artifact")
+	assert.False(t, isUpdateArchive(archives, 1), "the earliest revision should create the API")
+	assert.True(t, isUpdateArchive(archives, 2), "a later revision should update the API")
+	assert.True(t, isUpdateArchive(archives, 3), "the working copy should update the API")
+}
+
+func TestIsRevisionArchive(t *testing.T) {
+	assert.True(t, IsRevisionArchive(filepath.Join("apis", "PizzaShackAPI_1.0.0_Revision-2.zip")))
+	assert.False(t, IsRevisionArchive(filepath.Join("apis", "PizzaShackAPI_1.0.0.zip")))
+	assert.False(t, IsRevisionArchive("admin_Revision-App.zip"))
+}
+
+func TestGetArchiveParamsPath(t *testing.T) {
+	paramsDir, _ := ioutil.TempDir("", "apictl-migration-import-params")
+	defer os.RemoveAll(paramsDir)
+	assert.Nil(t, os.Mkdir(filepath.Join(paramsDir, "PizzaShackAPI_1.0.0"), os.ModePerm), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(paramsDir, "PizzaShackAPI_1.0.0_Revision-2.yaml"), []byte(""),
+		0644), "err should be nil")
+
+	assert.Equal(t, "", getArchiveParamsPath("", "PizzaShackAPI_1.0.0.zip"))
+	assert.Equal(t, filepath.Join(paramsDir, "PizzaShackAPI_1.0.0"),
+		getArchiveParamsPath(paramsDir, "PizzaShackAPI_1.0.0.zip"))
+	assert.Equal(t, filepath.Join(paramsDir, "PizzaShackAPI_1.0.0_Revision-2.yaml"),
+		getArchiveParamsPath(paramsDir, "PizzaShackAPI_1.0.0_Revision-2.zip"), "params of the revision should be used")
+	assert.Equal(t, filepath.Join(paramsDir, "PizzaShackAPI_1.0.0"),
+		getArchiveParamsPath(paramsDir, "PizzaShackAPI_1.0.0_Revision-3.zip"), "params of the API should be used")
+	assert.Equal(t, "", getArchiveParamsPath(paramsDir, "Other_1.0.0.zip"))
+}
+
+func TestPrepareMigrationImportResumes(t *testing.T) {
+	source := createArchivesSource(t, "A_1.0.0.zip", "B_1.0.0.zip", "C_1.0.0.zip")
+	defer os.RemoveAll(source)
+	importRelatedFilesPath, _ := ioutil.TempDir("", "apictl-migration-import-state")
+	defer os.RemoveAll(importRelatedFilesPath)
+	migrationImport := NewAPIProductsMigrationImport("", nil)
+
+	importMetadata, startingIndex := migrationImport.Prepare(importRelatedFilesPath, source, "dev", "admin", false)
+	assert.Equal(t, 0, startingIndex)
+	assert.Equal(t, []string{"A_1.0.0.zip", "B_1.0.0.zip", "C_1.0.0.zip"}, importMetadata.ArchivesToImport)
+
+	recordArchiveImportResult(importMetadata, "A_1.0.0.zip", nil)
+	recordArchiveImportResult(importMetadata, "B_1.0.0.zip", errors.New("409 Conflict"))
+	importMetadata.WriteMigrationImportMetadataFile(filepath.Join(importRelatedFilesPath,
+		migrationImport.MetadataFileName))
+	utils.WriteLastImportedFileData(filepath.Join(importRelatedFilesPath, migrationImport.LastImportedFileName),
+		"B_1.0.0.zip")
+
+	_, startingIndex = NewAppsMigrationImport(nil).Prepare(importRelatedFilesPath, source, "dev", "admin", false)
+	assert.Equal(t, 0, startingIndex, "the state of another type of artifacts should not be used")
+
+	resumedMetadata, startingIndex := migrationImport.Prepare(importRelatedFilesPath, source, "dev", "admin", false)
+	assert.Equal(t, 2, startingIndex, "the import should be resumed after the archive imported last")
+	assert.Equal(t, []string{"A_1.0.0.zip"}, resumedMetadata.ImportedArchives)
+	assert.Equal(t, map[string]string{"B_1.0.0.zip": "409 Conflict"}, resumedMetadata.FailedArchives)
+	assert.Equal(t, []int{1, 2}, getArchiveIndexesToImport(resumedMetadata, startingIndex),
+		"the failed archive should be retried before the remaining archives")
+	assert.Equal(t, []int{1}, getArchiveIndexesToImport(resumedMetadata, 3),
+		"only the failed archive should be retried once all the archives were imported")
+
+	_, startingIndex = migrationImport.Prepare(importRelatedFilesPath, source, "prod", "admin", false)
+	assert.Equal(t, 0, startingIndex, "the import to another environment should start from beginning")
+	_, startingIndex = migrationImport.Prepare(importRelatedFilesPath, source, "prod", "admin", true)
+	assert.Equal(t, 0, startingIndex, "the import should start from beginning with force")
+}
+
+func TestRecordArchiveImportResult(t *testing.T) {
+	importMetadata := &utils.MigrationImportMetadata{FailedArchives: make(map[string]string)}
+	recordArchiveImportResult(importMetadata, "A_1.0.0.zip", errors.New("500 Internal Server Error"))
+	assert.Equal(t, 1, len(importMetadata.FailedArchives))
+	recordArchiveImportResult(importMetadata, "A_1.0.0.zip", nil)
+	recordArchiveImportResult(importMetadata, "A_1.0.0.zip", nil)
+	assert.Empty(t, importMetadata.FailedArchives, "a retried archive should not be failed anymore")
+	assert.Equal(t, []string{"A_1.0.0.zip"}, importMetadata.ImportedArchives)
+}
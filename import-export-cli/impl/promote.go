@@ -0,0 +1,120 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// PromoteAPI exports an API from an environment and imports it to another environment, applying the params of the
+// target environment if given. The exported archive is kept only in a temporary workspace.
+// @param fromAccessToken : Access Token for the environment from which the API is exported
+// @param toAccessToken : Access Token for the environment to which the API is imported
+// @param revisionNum : Revision number of the API to be promoted. The working copy is promoted if empty
+// @param apiParamsPath : Params file or deployment directory of the API. Not used if empty
+func PromoteAPI(fromAccessToken, toAccessToken, name, version, provider, revisionNum, fromEnvironment, toEnvironment,
+	apiParamsPath string, importAPIUpdate, preserveProvider, skipCleanup, rotateRevision, skipDeployments bool) error {
+	utils.Logln(utils.LogPrefixInfo + "Exporting the API " + name + " " + version + " from " + fromEnvironment)
+	resp, err := ExportAPIFromEnv(fromAccessToken, name, version, revisionNum, provider, "", fromEnvironment, true,
+		false)
+	archivePath, cleanupFunc, err := writeExportedArchiveToTempZip(name+"_"+version+".zip", resp, err)
+	defer cleanupFunc()
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Importing the API to " + toEnvironment)
+	return ImportAPIToEnv(os.Stdout, toAccessToken, toEnvironment, archivePath, apiParamsPath, importAPIUpdate,
+		preserveProvider, skipCleanup, rotateRevision, skipDeployments)
+}
+
+// PromoteAPIProduct exports an API Product along with its dependent APIs from an environment and imports it to another
+// environment, applying the params of the target environment if given. The exported archive is kept only in a
+// temporary workspace.
+// @param fromAccessToken : Access Token for the environment from which the API Product is exported
+// @param toAccessToken : Access Token for the environment to which the API Product is imported
+// @param revisionNum : Revision number of the API Product to be promoted. The working copy is promoted if empty
+// @param apiProductParamsPath : Params file or deployment directory of the API Product. Not used if empty
+func PromoteAPIProduct(fromAccessToken, toAccessToken, name, provider, revisionNum, fromEnvironment, toEnvironment,
+	apiProductParamsPath string, importAPIs, importAPIsUpdate, importAPIProductUpdate, preserveProvider, skipCleanup,
+	rotateRevision, skipDeployments bool) error {
+	utils.Logln(utils.LogPrefixInfo + "Exporting the API Product " + name + " from " + fromEnvironment)
+	resp, err := ExportAPIProductFromEnv(fromAccessToken, name, utils.DefaultApiProductVersion, revisionNum, provider,
+		"", fromEnvironment, false)
+	archivePath, cleanupFunc, err := writeExportedArchiveToTempZip(name+"_"+utils.DefaultApiProductVersion+".zip",
+		resp, err)
+	defer cleanupFunc()
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Importing the API Product to " + toEnvironment)
+	return ImportAPIProductToEnv(os.Stdout, toAccessToken, toEnvironment, archivePath, apiProductParamsPath, importAPIs,
+		importAPIsUpdate, importAPIProductUpdate, preserveProvider, skipCleanup, rotateRevision, skipDeployments)
+}
+
+// PromoteApp exports an Application from an environment and imports it to another environment. The exported archive
+// is kept only in a temporary workspace.
+// @param fromAccessToken : Access Token for the environment from which the Application is exported
+// @param toAccessToken : Access Token for the environment to which the Application is imported
+// @param appOwner : Owner of the Application in the environment from which the Application is exported
+// @param targetAppOwner : Owner of the Application after importing. The importing user is the owner if empty
+func PromoteApp(fromAccessToken, toAccessToken, name, appOwner, targetAppOwner, fromEnvironment, toEnvironment string,
+	withKeys, updateApplication, preserveOwner, skipSubscriptions, skipCleanup bool) error {
+	utils.Logln(utils.LogPrefixInfo + "Exporting the Application " + name + " of " + appOwner + " from " +
+		fromEnvironment)
+	resp, err := ExportAppFromEnv(fromAccessToken, name, appOwner, "", fromEnvironment, withKeys)
+	archivePath, cleanupFunc, err := writeExportedArchiveToTempZip(replaceUserStoreDomainDelimiter(appOwner)+"_"+
+		name+".zip", resp, err)
+	defer cleanupFunc()
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Importing the Application to " + toEnvironment)
+	_, err = ImportApplicationToEnv(os.Stdout, toAccessToken, toEnvironment, archivePath, targetAppOwner,
+		updateApplication, preserveOwner, skipSubscriptions, !withKeys, skipCleanup)
+	return err
+}
+
+// Writes the archive in the response of an export request to a temporary zip file
+// Returns the path of the zip file and a function which deletes it. The function should be called even if there is
+// an error.
+func writeExportedArchiveToTempZip(zipFileName string, resp *resty.Response, err error) (string, func(), error) {
+	cleanupFunc := func() {}
+	if err != nil {
+		return "", cleanupFunc, err
+	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	if resp.StatusCode() != http.StatusOK {
+		return "", cleanupFunc, utils.NewHttpResponseError(resp, "Error exporting: "+resp.Status()+" "+
+			string(resp.Body()))
+	}
+	tempZipFile, err := utils.WriteResponseToTempZip(zipFileName, resp)
+	if tempZipFile != "" {
+		cleanupFunc = func() {
+			utils.Logln(utils.LogPrefixInfo+"Deleting", filepath.Dir(tempZipFile))
+			if err := os.RemoveAll(filepath.Dir(tempZipFile)); err != nil {
+				utils.Logln(utils.LogPrefixError + err.Error())
+			}
+		}
+	}
+	return tempZipFile, cleanupFunc, err
+}
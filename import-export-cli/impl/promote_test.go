@@ -0,0 +1,56 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestWriteExportedArchiveToTempZip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("API not found"))
+			return
+		}
+		_, _ = w.Write([]byte("zip"))
+	}))
+	defer server.Close()
+
+	resp, err := utils.InvokeGETRequest(server.URL, map[string]string{})
+	archivePath, cleanupFunc, err := writeExportedArchiveToTempZip("PizzaShackAPI_1.0.0.zip", resp, err)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PizzaShackAPI_1.0.0.zip", filepath.Base(archivePath))
+	content, _ := ioutil.ReadFile(archivePath)
+	assert.Equal(t, "zip", string(content))
+	cleanupFunc()
+	assert.False(t, utils.IsFileExist(archivePath), "the temporary archive should be deleted")
+
+	resp, err = utils.InvokeGETRequest(server.URL+"/missing", map[string]string{})
+	_, cleanupFunc, err = writeExportedArchiveToTempZip("Missing_1.0.0.zip", resp, err)
+	cleanupFunc()
+	assert.NotNil(t, err, "an error response should not be written as an archive")
+	assert.Equal(t, http.StatusNotFound, err.(*utils.HttpResponseError).StatusCode)
+}
@@ -0,0 +1,500 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/loads"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Endpoint types which can be given as the endpoint_type of the endpointConfig of an API
+var validEndpointTypes = map[string]bool{
+	v2.EpHttp:        true,
+	v2.EpLoadbalance: true,
+	v2.EpFailover:    true,
+	"ws":             true,
+	"address":        true,
+	"default":        true,
+	"awslambda":      true,
+}
+
+// Names of the certificate files of the projects, listing the certificates in the certificate directories
+const (
+	endpointCertificatesFileName = "endpoint_certificates"
+	clientCertificatesFileName   = "client_certificates"
+	documentFileName             = "document"
+)
+
+// ValidateAPIProject checks an API project (or an archive of it) offline for the issues which would make it fail to
+// import: the required files, the api.yaml and the definition, the operations against the definition, the characters
+// of the name, context and version, the endpoint configuration and the sequences, certificates and documents referred.
+// projectPath is the path of the API project directory or the archive
+// Returns []string, the issues found in the project, or an error if the project cannot be read
+func ValidateAPIProject(projectPath string) ([]string, error) {
+	projectDir, cleanup, err := getProjectDirToValidate(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	return validateAPIProjectDir(projectDir, false), nil
+}
+
+// ValidateAPIProductProject checks an API Product project (or an archive of it) offline: the required files, the
+// api_product.yaml and the definition, and each of the dependent APIs in the APIs directory as an API project.
+// projectPath is the path of the API Product project directory or the archive
+// Returns []string, the issues found in the project, or an error if the project cannot be read
+func ValidateAPIProductProject(projectPath string) ([]string, error) {
+	projectDir, cleanup, err := getProjectDirToValidate(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	issues := checkRequiredFiles(projectDir, "api_product", utils.MetaFileAPIProduct)
+	issues = append(issues, validateSwaggerDefinition(projectDir, nil)...)
+	apiProduct, _, err := GetAPIProductDefinition(projectDir)
+	if err != nil {
+		if hasDefinitionFile(projectDir, "api_product") {
+			issues = append(issues, "unable to parse the api_product definition: "+err.Error())
+		}
+		return issues, nil
+	}
+	if apiProduct.Type != "" && apiProduct.Type != "api_product" {
+		issues = append(issues, "the type of the api_product definition should be api_product, found "+apiProduct.Type)
+	}
+	if apiProduct.Data.Name == "" {
+		issues = append(issues, "the name of the API Product should not be empty")
+	} else if reAPIName.MatchString(apiProduct.Data.Name) {
+		issues = append(issues, "the name of the API Product "+apiProduct.Data.Name+" has invalid characters")
+	}
+
+	// the dependent APIs are in the APIs directory when the API Product is exported with those
+	apisDir := filepath.Join(projectDir, "APIs")
+	if isDir, _ := utils.IsDirExists(apisDir); !isDir {
+		return issues, nil
+	}
+	for _, api := range apiProduct.Data.APIs {
+		apiDirName := api.Name + "-" + api.Version
+		apiDir := filepath.Join(apisDir, apiDirName)
+		if isDir, _ := utils.IsDirExists(apiDir); !isDir {
+			issues = append(issues, "the API "+apiDirName+" of the API Product is not in the APIs directory")
+			continue
+		}
+		for _, issue := range validateAPIProjectDir(apiDir, true) {
+			issues = append(issues, "APIs/"+apiDirName+": "+issue)
+		}
+	}
+	return issues, nil
+}
+
+// ValidateAppProject checks an Application project (or an archive of it) offline: the required files and the
+// application.yaml.
+// projectPath is the path of the Application project directory or the archive
+// Returns []string, the issues found in the project, or an error if the project cannot be read
+func ValidateAppProject(projectPath string) ([]string, error) {
+	projectDir, cleanup, err := getProjectDirToValidate(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	issues := checkRequiredFiles(projectDir, "application", utils.MetaFileApplication)
+	app, _, err := GetApplicationDefinition(projectDir)
+	if err != nil {
+		if hasDefinitionFile(projectDir, "application") {
+			issues = append(issues, "unable to parse the application definition: "+err.Error())
+		}
+		return issues, nil
+	}
+	if app.Type != "" && app.Type != "application" {
+		issues = append(issues, "the type of the application definition should be application, found "+app.Type)
+	}
+	if strings.TrimSpace(app.Data.Applicationinfo.Name) == "" {
+		issues = append(issues, "the name of the Application should not be empty")
+	}
+	if strings.TrimSpace(app.Data.Applicationinfo.Owner) == "" {
+		issues = append(issues, "the owner of the Application should not be empty")
+	}
+	return issues, nil
+}
+
+// Returns the directory of the project to validate, extracting it to a temporary directory if it is an archive, and
+// the function to clean up the temporary directory
+func getProjectDirToValidate(projectPath string) (string, func(), error) {
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if info.IsDir() {
+		return projectPath, func() {}, nil
+	}
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return "", nil, err
+	}
+	// the archive is extracted to a temporary directory, where the project is the directory in its root
+	tmpDir := filepath.Dir(tmpPath)
+	cleanup := func() {
+		utils.Logln(utils.LogPrefixInfo+"Deleting", tmpDir)
+		if err := os.RemoveAll(tmpDir); err != nil {
+			utils.Logln(utils.LogPrefixError + err.Error())
+		}
+	}
+	if info, err := os.Stat(tmpPath); err != nil || !info.IsDir() {
+		cleanup()
+		return "", nil, fmt.Errorf("%s does not have a project directory in its root", projectPath)
+	}
+	return tmpPath, cleanup, nil
+}
+
+// Returns the issues of the definition file (given without the extension, as it can be either YAML or JSON) and the
+// meta file missing in the project
+func checkRequiredFiles(projectDir, definitionFileName, metaFileName string) []string {
+	var issues []string
+	if !hasDefinitionFile(projectDir, definitionFileName) {
+		issues = append(issues, definitionFileName+".yaml (or "+definitionFileName+".json) is missing")
+	}
+	if !utils.IsFileExist(filepath.Join(projectDir, metaFileName)) {
+		issues = append(issues, metaFileName+" is missing")
+	}
+	return issues
+}
+
+// Returns whether the project has the definition file (given without the extension) either as YAML or JSON
+func hasDefinitionFile(projectDir, definitionFileName string) bool {
+	return utils.IsFileExist(filepath.Join(projectDir, definitionFileName+".yaml")) ||
+		utils.IsFileExist(filepath.Join(projectDir, definitionFileName+".json"))
+}
+
+// Returns the issues found in the API project in the given directory. The dependent APIs of an API Product do not
+// have the api_meta.yaml, hence it is not required for those.
+func validateAPIProjectDir(projectDir string, isDependentAPI bool) []string {
+	var issues []string
+	if isDependentAPI {
+		if !hasDefinitionFile(projectDir, "api") {
+			issues = append(issues, "api.yaml (or api.json) is missing")
+		}
+	} else {
+		issues = checkRequiredFiles(projectDir, "api", utils.MetaFileAPI)
+	}
+	api, _, err := GetAPIDefinition(projectDir)
+	if err != nil {
+		if hasDefinitionFile(projectDir, "api") {
+			issues = append(issues, "unable to parse the api definition: "+err.Error())
+		}
+		// the definition of the API is checked regardless, assuming the API is a REST API
+		return append(issues, validateSwaggerDefinition(projectDir, nil)...)
+	}
+	if api.Type != "" && api.Type != "api" {
+		issues = append(issues, "the type of the api definition should be api, found "+api.Type)
+	}
+
+	switch strings.ToUpper(api.Data.Type) {
+	case "GRAPHQL":
+		if !utils.IsFileExist(filepath.Join(projectDir, utils.InitProjectDefinitionsGraphQLSchema)) {
+			issues = append(issues, filepath.ToSlash(utils.InitProjectDefinitionsGraphQLSchema)+" is missing")
+		}
+	case "WS", "WEBSUB", "SSE", "ASYNC":
+		if !utils.IsFileExist(filepath.Join(projectDir, utils.InitProjectDefinitionsAsyncAPI)) {
+			issues = append(issues, filepath.ToSlash(utils.InitProjectDefinitionsAsyncAPI)+" is missing")
+		}
+	default:
+		issues = append(issues, validateSwaggerDefinition(projectDir, api.Data.Operations)...)
+	}
+
+	issues = append(issues, validateAPINameContextAndVersion(&api.Data)...)
+	issues = append(issues, validateEndpointConfig(api.Data.EndpointConfig)...)
+	issues = append(issues, validateMediationPolicies(projectDir, api.Data.MediationPolicies)...)
+	issues = append(issues, validateCertificates(projectDir, utils.InitProjectEndpointCertificates,
+		endpointCertificatesFileName)...)
+	issues = append(issues, validateCertificates(projectDir, utils.InitProjectClientCertificates,
+		clientCertificatesFileName)...)
+	issues = append(issues, validateDocuments(projectDir)...)
+	return issues
+}
+
+// Returns the issues of the Swagger/OpenAPI definition of the project: whether it exists and parses as a Swagger 2.0
+// or an OpenAPI 3.x definition, the issues found by the lint and, if operations are given, whether those match the
+// resources of the definition
+func validateSwaggerDefinition(projectDir string, operations []interface{}) []string {
+	swaggerPath := filepath.Join(projectDir, utils.InitProjectDefinitionsSwagger)
+	definitionName := filepath.ToSlash(utils.InitProjectDefinitionsSwagger)
+	content, err := ioutil.ReadFile(swaggerPath)
+	if err != nil {
+		return []string{definitionName + " is missing"}
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return []string{"unable to parse " + definitionName + ": " + err.Error()}
+	}
+	var definition map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &definition); err != nil {
+		return []string{"unable to parse " + definitionName + ": " + err.Error()}
+	}
+
+	var issues []string
+	if _, isSwagger2 := definition["swagger"]; isSwagger2 {
+		if _, err := loads.Analyzed(jsonContent, ""); err != nil {
+			issues = append(issues, definitionName+" is not a valid Swagger 2.0 definition: "+err.Error())
+		}
+	} else if openAPIVersion, _ := definition["openapi"].(string); strings.HasPrefix(openAPIVersion, "3.0") {
+		// the openapi3 loader supports only OpenAPI 3.0, hence OpenAPI 3.1 definitions are checked only by the lint
+		if _, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(jsonContent); err != nil {
+			issues = append(issues, definitionName+" is not a valid OpenAPI 3.0 definition: "+err.Error())
+		}
+	}
+	for _, issue := range lintSwaggerDefinition(definition) {
+		issues = append(issues, definitionName+": "+issue)
+	}
+	if len(operations) > 0 {
+		paths, _ := definition["paths"].(map[string]interface{})
+		issues = append(issues, validateOperations(paths, operations)...)
+	}
+	return issues
+}
+
+// Returns the issues of the operations of an API which are not in the given paths of the definition and the
+// resources of the definition which are not in the operations
+func validateOperations(paths map[string]interface{}, operations []interface{}) []string {
+	var issues []string
+	resources := make(map[string]bool)
+	for resourcePath, pathItem := range paths {
+		pathItemMap, _ := pathItem.(map[string]interface{})
+		for _, verb := range swaggerOperationVerbs {
+			if _, ok := pathItemMap[verb]; ok {
+				resources[strings.ToUpper(verb)+" "+resourcePath] = true
+			}
+		}
+	}
+
+	operationResources := make(map[string]bool)
+	for _, operation := range operations {
+		operationMap, _ := operation.(map[string]interface{})
+		target, _ := operationMap["target"].(string)
+		verb, _ := operationMap["verb"].(string)
+		resource := strings.ToUpper(verb) + " " + target
+		operationResources[resource] = true
+		if !resources[resource] {
+			issues = append(issues, "the operation "+resource+" is not in the definition")
+		}
+	}
+	var missingResources []string
+	for resource := range resources {
+		if !operationResources[resource] {
+			missingResources = append(missingResources, resource)
+		}
+	}
+	sort.Strings(missingResources)
+	for _, resource := range missingResources {
+		issues = append(issues, "the resource "+resource+" of the definition is not in the operations")
+	}
+	return issues
+}
+
+// Returns the issues of the characters of the name, the context and the version of an API
+func validateAPINameContextAndVersion(api *v2.APIDTODefinition) []string {
+	var issues []string
+	if api.Name == "" {
+		issues = append(issues, "the name of the API should not be empty")
+	} else if reAPIName.MatchString(api.Name) {
+		issues = append(issues, "the name of the API "+api.Name+" has invalid characters")
+	}
+	if api.Version == "" {
+		issues = append(issues, "the version of the API should not be empty")
+	} else if reAPIName.MatchString(api.Version) {
+		issues = append(issues, "the version of the API "+api.Version+" has invalid characters")
+	}
+	if api.Context == "" {
+		issues = append(issues, "the context of the API should not be empty")
+		return issues
+	}
+	// the context may have the version as a template (ie: /pizzashack/{version}), and consists of the segments
+	// separated by '/', each of which should not have the invalid characters
+	for _, segment := range strings.Split(strings.Replace(api.Context, "{version}", "", -1), "/") {
+		if reAPIName.MatchString(segment) {
+			issues = append(issues, "the context of the API "+api.Context+" has invalid characters")
+			break
+		}
+	}
+	return issues
+}
+
+// Returns the issues of the shape of the endpoint configuration of an API. The configuration is not checked if the
+// API does not have one (ie: an advertise only API)
+func validateEndpointConfig(endpointConfig interface{}) []string {
+	if endpointConfig == nil {
+		return nil
+	}
+	config, ok := endpointConfig.(map[string]interface{})
+	if !ok {
+		return []string{"the endpointConfig of the API should be an object"}
+	}
+	endpointType, _ := config["endpoint_type"].(string)
+	if !validEndpointTypes[endpointType] {
+		return []string{fmt.Sprintf("the endpoint_type %q of the endpointConfig is not valid", endpointType)}
+	}
+	if endpointType == "default" || endpointType == "awslambda" {
+		// the endpoints are not configured for the dynamic and the AWS Lambda endpoints
+		return nil
+	}
+
+	var issues []string
+	_, hasProduction := config["production_endpoints"]
+	_, hasSandbox := config["sandbox_endpoints"]
+	if !hasProduction && !hasSandbox {
+		issues = append(issues, "the endpointConfig of the API should have production_endpoints or sandbox_endpoints")
+	}
+	for _, key := range []string{"production_endpoints", "sandbox_endpoints", "production_failovers",
+		"sandbox_failovers"} {
+		endpoints, exists := config[key]
+		if !exists {
+			continue
+		}
+		// the endpoints are lists for the load balanced endpoints and the failovers, and objects otherwise
+		isList := endpointType == v2.EpLoadbalance || strings.HasSuffix(key, "_failovers")
+		for _, issue := range validateEndpoints(endpoints, isList) {
+			issues = append(issues, "the "+key+" of the endpointConfig "+issue)
+		}
+	}
+	return issues
+}
+
+// Returns the issue of the given endpoints, which should be either a list of endpoints or a single endpoint, each
+// having a url
+func validateEndpoints(endpoints interface{}, isList bool) []string {
+	var endpointList []interface{}
+	if isList {
+		list, ok := endpoints.([]interface{})
+		if !ok {
+			return []string{"should be a list of endpoints"}
+		}
+		endpointList = list
+	} else {
+		endpointList = []interface{}{endpoints}
+	}
+	for _, endpoint := range endpointList {
+		endpointMap, ok := endpoint.(map[string]interface{})
+		if !ok {
+			return []string{"should have endpoints with a url"}
+		}
+		if url, _ := endpointMap["url"].(string); strings.TrimSpace(url) == "" {
+			return []string{"should have endpoints with a url"}
+		}
+	}
+	return nil
+}
+
+// Returns the issues of the mediation policies of an API which are not in the Sequences directory. The shared
+// mediation policies are in the server, hence are not checked.
+func validateMediationPolicies(projectDir string, mediationPolicies []interface{}) []string {
+	var issues []string
+	for _, mediationPolicy := range mediationPolicies {
+		policy, _ := mediationPolicy.(map[string]interface{})
+		if shared, _ := policy["shared"].(bool); shared {
+			continue
+		}
+		name, _ := policy["name"].(string)
+		policyType, _ := policy["type"].(string)
+		sequencePath := filepath.Join(utils.InitProjectSequences, strings.ToLower(policyType)+"-sequence",
+			name+".xml")
+		if !utils.IsFileExist(filepath.Join(projectDir, sequencePath)) {
+			issues = append(issues, "the mediation policy "+name+" is not in "+filepath.ToSlash(sequencePath))
+		}
+	}
+	return issues
+}
+
+// Returns the issues of the certificates listed in the certificates file (ie: endpoint_certificates.yaml) of the
+// given certificates directory, which are not in the directory
+func validateCertificates(projectDir, certificatesDirName, certificatesFileName string) []string {
+	certificatesDir := filepath.Join(projectDir, certificatesDirName)
+	if isDir, _ := utils.IsDirExists(certificatesDir); !isDir {
+		return nil
+	}
+	_, content, err := resolveYamlOrJSON(filepath.Join(certificatesDir, certificatesFileName))
+	if err != nil {
+		// the certificates of the older projects are not listed in a file
+		return nil
+	}
+	var certificatesFile struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(content, &certificatesFile); err != nil {
+		return []string{"unable to parse " + certificatesDirName + "/" + certificatesFileName + ": " + err.Error()}
+	}
+	var issues []string
+	for _, certificate := range certificatesFile.Data {
+		certificateFileName, _ := certificate["certificate"].(string)
+		if certificateFileName == "" {
+			continue
+		}
+		if !utils.IsFileExist(filepath.Join(certificatesDir, certificateFileName)) {
+			alias, _ := certificate["alias"].(string)
+			issues = append(issues, "the certificate "+certificateFileName+" of "+alias+" is not in "+
+				certificatesDirName)
+		}
+	}
+	return issues
+}
+
+// Returns the issues of the documents in the Docs directory: each document directory should have the document.yaml
+// (or document.json) and the content of the document if it is not a URL
+func validateDocuments(projectDir string) []string {
+	docsDir := filepath.Join(projectDir, utils.InitProjectDocs)
+	docDirs, err := ioutil.ReadDir(docsDir)
+	if err != nil {
+		return nil
+	}
+	var issues []string
+	for _, docDir := range docDirs {
+		if !docDir.IsDir() {
+			// the documents of the older projects are listed in the Docs/docs.json
+			continue
+		}
+		docDirPath := filepath.Join(docsDir, docDir.Name())
+		_, content, err := resolveYamlOrJSON(filepath.Join(docDirPath, documentFileName))
+		if err != nil {
+			issues = append(issues, "the document "+docDir.Name()+" does not have a "+documentFileName+".yaml")
+			continue
+		}
+		var document v2.Document
+		if err := json.Unmarshal(content, &document); err != nil {
+			issues = append(issues, "unable to parse the document "+docDir.Name()+": "+err.Error())
+			continue
+		}
+		if document.Data.SourceType == "" || strings.EqualFold(document.Data.SourceType, "URL") {
+			continue
+		}
+		files, _ := ioutil.ReadDir(docDirPath)
+		if len(files) < 2 {
+			issues = append(issues, "the content of the "+strings.ToLower(document.Data.SourceType)+
+				" document "+docDir.Name()+" is not in "+utils.InitProjectDocs+"/"+docDir.Name())
+		}
+	}
+	return issues
+}
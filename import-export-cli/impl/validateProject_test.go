@@ -0,0 +1,165 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Copies the given project in the test data to a temporary directory, adding the given meta file
+func copyProjectToValidate(t *testing.T, projectName, metaFileName string) (string, func()) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-validate")
+	projectPath := filepath.Join(tmpDir, projectName)
+	assert.Nil(t, utils.CopyDir(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), projectName), projectPath),
+		"err should be nil")
+	if metaFileName != "" {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, metaFileName), []byte("name: "+projectName),
+			0644), "err should be nil")
+	}
+	return projectPath, func() { os.RemoveAll(tmpDir) }
+}
+
+func TestValidateAPIProjectWithValidProject(t *testing.T) {
+	projectPath, cleanup := copyProjectToValidate(t, "PizzaShackAPI-1.0.0", utils.MetaFileAPI)
+	defer cleanup()
+
+	issues, err := ValidateAPIProject(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Empty(t, issues, "a valid API project should not have issues")
+}
+
+func TestValidateAPIProjectWithInvalidProject(t *testing.T) {
+	projectPath, cleanup := copyProjectToValidate(t, "PizzaShackAPI-1.0.0", "")
+	defer cleanup()
+	apiYamlPath := filepath.Join(projectPath, "api.yaml")
+	content, _ := ioutil.ReadFile(apiYamlPath)
+	apiYaml := strings.Replace(string(content), "context: /pizzashack", "context: /pizza&shack", 1)
+	apiYaml = strings.Replace(apiYaml, "target: /menu", "target: /menus", 1)
+	apiYaml = strings.Replace(apiYaml, "endpoint_type: http", "endpoint_type: https", 1)
+	apiYaml = strings.Replace(apiYaml, "mediationPolicies: []",
+		"mediationPolicies:\n   - name: log_in_message\n     type: IN\n     shared: false", 1)
+	assert.Nil(t, ioutil.WriteFile(apiYamlPath, []byte(apiYaml), 0644), "err should be nil")
+
+	issues, err := ValidateAPIProject(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{
+		utils.MetaFileAPI + " is missing",
+		"the operation GET /menus is not in the definition",
+		"the resource GET /menu of the definition is not in the operations",
+		"the context of the API /pizza&shack has invalid characters",
+		"the endpoint_type \"https\" of the endpointConfig is not valid",
+		"the mediation policy log_in_message is not in Sequences/in-sequence/log_in_message.xml",
+	}, issues, "the issues of the API project should be reported")
+}
+
+func TestValidateAPIProjectWithMissingFiles(t *testing.T) {
+	issues, err := ValidateAPIProject(filepath.Join(utils.GetRelativeTestDataPathFromImpl(),
+		"PizzaShackAPI-1.0.0-malformed"))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{
+		"api.yaml (or api.json) is missing",
+		utils.MetaFileAPI + " is missing",
+		"Definitions/swagger.yaml is missing",
+	}, issues, "the missing files of the API project should be reported")
+
+	_, err = ValidateAPIProject(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "NonExistingAPI-1.0.0"))
+	assert.NotNil(t, err, "err should not be nil for a non existing project")
+}
+
+func TestValidateSwaggerDefinitionOfOpenAPI31(t *testing.T) {
+	projectPath, _ := ioutil.TempDir("", "apictl-validate")
+	defer os.RemoveAll(projectPath)
+	content, err := ioutil.ReadFile(filepath.Join("..", "specs", "v2", "testdata", "petstore_oas31.yaml"))
+	assert.Nil(t, err, "err should be nil")
+	swaggerPath := filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(swaggerPath), os.ModePerm), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile(swaggerPath, content, 0644), "err should be nil")
+
+	assert.Empty(t, validateSwaggerDefinition(projectPath, nil), "a valid OpenAPI 3.1 definition should not have issues")
+}
+
+func TestValidateEndpointConfig(t *testing.T) {
+	loadBalanced := map[string]interface{}{
+		"endpoint_type":        "load_balance",
+		"production_endpoints": []interface{}{map[string]interface{}{"url": "https://localhost:9443/pizzashack"}},
+	}
+	assert.Empty(t, validateEndpointConfig(loadBalanced), "a valid endpoint config should not have issues")
+
+	loadBalanced["production_endpoints"] = map[string]interface{}{"url": "https://localhost:9443/pizzashack"}
+	assert.Equal(t, []string{"the production_endpoints of the endpointConfig should be a list of endpoints"},
+		validateEndpointConfig(loadBalanced), "the load balanced endpoints should be a list")
+
+	failover := map[string]interface{}{
+		"endpoint_type":        "failover",
+		"production_endpoints": map[string]interface{}{"url": "https://localhost:9443/pizzashack"},
+		"production_failovers": []interface{}{map[string]interface{}{"url": ""}},
+	}
+	assert.Equal(t, []string{"the production_failovers of the endpointConfig should have endpoints with a url"},
+		validateEndpointConfig(failover), "the failovers should have urls")
+
+	assert.Empty(t, validateEndpointConfig(nil), "an API without an endpoint config should not have issues")
+}
+
+func TestValidateAPIProductProject(t *testing.T) {
+	projectPath, cleanup := copyProjectToValidate(t, "MyProduct-1.0.0", "")
+	defer cleanup()
+
+	issues, err := ValidateAPIProductProject(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Empty(t, issues, "a valid API Product project should not have issues")
+
+	assert.Nil(t, os.RemoveAll(filepath.Join(projectPath, "APIs", "SwaggerPetstore-1.0.5")), "err should be nil")
+	issues, err = ValidateAPIProductProject(projectPath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"the API SwaggerPetstore-1.0.5 of the API Product is not in the APIs directory"}, issues,
+		"the missing APIs of the API Product should be reported")
+
+	issues, err = ValidateAPIProductProject(filepath.Join(utils.GetRelativeTestDataPathFromImpl(),
+		"MyProduct-1.0.0-malformed"))
+	assert.Nil(t, err, "err should be nil")
+	assert.Contains(t, issues, "api_product.yaml (or api_product.json) is missing",
+		"the missing definition of the API Product should be reported")
+}
+
+func TestValidateAppProject(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "apictl-validate")
+	defer os.RemoveAll(tmpDir)
+	projectPath := filepath.Join(tmpDir, "SampleApp")
+	assert.Nil(t, os.MkdirAll(projectPath, os.ModePerm), "err should be nil")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "application.yaml"),
+		[]byte("type: application\ndata:\n  applicationInfo:\n    name: SampleApp\n"), 0644), "err should be nil")
+	archivePath := filepath.Join(tmpDir, "SampleApp.zip")
+	assert.Nil(t, utils.Zip(projectPath, archivePath), "err should be nil")
+
+	issues, err := ValidateAppProject(archivePath)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{
+		utils.MetaFileApplication + " is missing",
+		"the owner of the Application should not be empty",
+	}, issues, "the issues of the Application project should be reported")
+
+	_, err = ValidateAppProject(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "sampleApp.zip"))
+	assert.NotNil(t, err, "err should not be nil for an archive without a project directory")
+}
@@ -157,6 +157,15 @@ func invokePOSTRequestWithRetry(env, url string, body interface{}) (*resty.Respo
 	})
 }
 
+func invokePOSTRequestWithFileAndRetry(env, url, fileParamName, filePath string) (*resty.Response, error) {
+	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+		return utils.InvokePOSTRequestWithFileAndQueryParams(nil, url, headers, fileParamName, filePath)
+	})
+}
+
 func invokeDELETERequestWithRetry(url string, env string) (*resty.Response, error) {
 	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
 		headers := make(map[string]string)
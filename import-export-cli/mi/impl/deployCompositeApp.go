@@ -0,0 +1,63 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// DeployCompositeApp deploys a composite app (CApp) file to the micro integrator in a given environment. If a composite
+//  app with the same name is already deployed, it is replaced by the micro integrator. The result is written to out.
+func DeployCompositeApp(out io.Writer, env, cAppFilePath string) error {
+	resourceUrl := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env,
+		utils.MainConfigFilePath)
+	resp, err := invokePOSTRequestWithFileAndRetry(env, resourceUrl, "file", cAppFilePath)
+	if err != nil {
+		return err
+	}
+	return handleCompositeAppResponse(out, resp, "Successfully deployed composite app.")
+}
+
+// UndeployCompositeApp removes a composite app from the micro integrator in a given environment
+func UndeployCompositeApp(env, appName string) error {
+	resourceUrl := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env,
+		utils.MainConfigFilePath) + "/" + url.PathEscape(appName)
+	resp, err := invokeDELETERequestWithRetry(resourceUrl, env)
+	if err != nil {
+		return err
+	}
+	return handleCompositeAppResponse(os.Stdout, resp, "Successfully undeployed composite app.")
+}
+
+func handleCompositeAppResponse(out io.Writer, resp *resty.Response, message string) error {
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated ||
+		resp.StatusCode() == http.StatusAccepted || resp.StatusCode() == http.StatusNoContent {
+		fmt.Fprintln(out, message)
+		return nil
+	}
+	return utils.NewHttpResponseError(resp, resp.Status())
+}
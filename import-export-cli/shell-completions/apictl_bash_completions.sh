@@ -383,10 +383,16 @@ _apictl_add_env()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--max-changes=")
+    two_word_flags+=("--max-changes")
+    local_nonpersistent_flags+=("--max-changes")
+    local_nonpersistent_flags+=("--max-changes=")
     flags+=("--mi=")
     two_word_flags+=("--mi")
     local_nonpersistent_flags+=("--mi")
     local_nonpersistent_flags+=("--mi=")
+    flags+=("--protected")
+    local_nonpersistent_flags+=("--protected")
     flags+=("--publisher=")
     two_word_flags+=("--publisher")
     local_nonpersistent_flags+=("--publisher")
@@ -1058,6 +1064,46 @@ _apictl_export_api-product()
     noun_aliases=()
 }
 
+_apictl_export_api-products()
+{
+    last_command="apictl_export_api-products"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--force")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_export_apis()
 {
     last_command="apictl_export_apis"
@@ -1159,6 +1205,48 @@ _apictl_export_app()
     noun_aliases=()
 }
 
+_apictl_export_apps()
+{
+    last_command="apictl_export_apps"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--force")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--with-keys")
+    local_nonpersistent_flags+=("--with-keys")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_export_help()
 {
     last_command="apictl_export_help"
@@ -1192,8 +1280,10 @@ _apictl_export()
     commands=()
     commands+=("api")
     commands+=("api-product")
+    commands+=("api-products")
     commands+=("apis")
     commands+=("app")
+    commands+=("apps")
     commands+=("help")
 
     flags=()
@@ -1280,6 +1370,47 @@ _apictl_gen_help()
     noun_aliases=()
 }
 
+_apictl_gen_oas()
+{
+    last_command="apictl_gen_oas"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--output")
+    local_nonpersistent_flags+=("--output=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_gen()
 {
     last_command="apictl_gen"
@@ -1289,6 +1420,7 @@ _apictl_gen()
     commands=()
     commands+=("deployment-dir")
     commands+=("help")
+    commands+=("oas")
 
     flags=()
     two_word_flags=()
@@ -1881,9 +2013,9 @@ _apictl_import_api-product()
     noun_aliases=()
 }
 
-_apictl_import_app()
+_apictl_import_api-products()
 {
-    last_command="apictl_import_app"
+    last_command="apictl_import_api-products"
 
     command_aliases=()
 
@@ -1901,34 +2033,34 @@ _apictl_import_app()
     local_nonpersistent_flags+=("--environment")
     local_nonpersistent_flags+=("--environment=")
     local_nonpersistent_flags+=("-e")
-    flags+=("--file=")
-    two_word_flags+=("--file")
-    two_word_flags+=("-f")
-    local_nonpersistent_flags+=("--file")
-    local_nonpersistent_flags+=("--file=")
-    local_nonpersistent_flags+=("-f")
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--owner=")
-    two_word_flags+=("--owner")
-    two_word_flags+=("-o")
-    local_nonpersistent_flags+=("--owner")
-    local_nonpersistent_flags+=("--owner=")
-    local_nonpersistent_flags+=("-o")
-    flags+=("--preserve-owner")
-    local_nonpersistent_flags+=("--preserve-owner")
+    flags+=("--import-apis")
+    local_nonpersistent_flags+=("--import-apis")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--preserve-provider")
+    local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
     flags+=("--skip-cleanup")
     local_nonpersistent_flags+=("--skip-cleanup")
-    flags+=("--skip-keys")
-    local_nonpersistent_flags+=("--skip-keys")
-    flags+=("--skip-subscriptions")
-    flags+=("-s")
-    local_nonpersistent_flags+=("--skip-subscriptions")
-    local_nonpersistent_flags+=("-s")
-    flags+=("--update")
-    local_nonpersistent_flags+=("--update")
+    flags+=("--skip-deployments")
+    local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--source=")
+    two_word_flags+=("--source")
+    local_nonpersistent_flags+=("--source")
+    local_nonpersistent_flags+=("--source=")
+    flags+=("--update-api-product")
+    local_nonpersistent_flags+=("--update-api-product")
+    flags+=("--update-apis")
+    local_nonpersistent_flags+=("--update-apis")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -1936,15 +2068,14 @@ _apictl_import_app()
     must_have_one_flag=()
     must_have_one_flag+=("--environment=")
     must_have_one_flag+=("-e")
-    must_have_one_flag+=("--file=")
-    must_have_one_flag+=("-f")
+    must_have_one_flag+=("--source=")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_import_help()
+_apictl_import_apis()
 {
-    last_command="apictl_import_help"
+    last_command="apictl_import_apis"
 
     command_aliases=()
 
@@ -1956,27 +2087,55 @@ _apictl_import_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--preserve-provider")
+    local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-deployments")
+    local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--source=")
+    two_word_flags+=("--source")
+    local_nonpersistent_flags+=("--source")
+    local_nonpersistent_flags+=("--source=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--source=")
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_import()
+_apictl_import_app()
 {
-    last_command="apictl_import"
+    last_command="apictl_import_app"
 
     command_aliases=()
 
     commands=()
-    commands+=("api")
-    commands+=("api-product")
-    commands+=("app")
-    commands+=("help")
 
     flags=()
     two_word_flags=()
@@ -1984,22 +2143,56 @@ _apictl_import()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--owner=")
+    two_word_flags+=("--owner")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--preserve-owner")
+    local_nonpersistent_flags+=("--preserve-owner")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-keys")
+    local_nonpersistent_flags+=("--skip-keys")
+    flags+=("--skip-subscriptions")
+    flags+=("-s")
+    local_nonpersistent_flags+=("--skip-subscriptions")
+    local_nonpersistent_flags+=("-s")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_init()
+_apictl_import_apps()
 {
-    last_command="apictl_init"
+    last_command="apictl_import_apps"
 
     command_aliases=()
 
@@ -2011,16 +2204,150 @@ _apictl_init()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--definition=")
-    two_word_flags+=("--definition")
-    two_word_flags+=("-d")
-    local_nonpersistent_flags+=("--definition")
-    local_nonpersistent_flags+=("--definition=")
-    local_nonpersistent_flags+=("-d")
-    flags+=("--force")
-    flags+=("-f")
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--owner=")
+    two_word_flags+=("--owner")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--preserve-owner")
+    local_nonpersistent_flags+=("--preserve-owner")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-keys")
+    local_nonpersistent_flags+=("--skip-keys")
+    flags+=("--skip-subscriptions")
+    flags+=("-s")
+    local_nonpersistent_flags+=("--skip-subscriptions")
+    local_nonpersistent_flags+=("-s")
+    flags+=("--source=")
+    two_word_flags+=("--source")
+    local_nonpersistent_flags+=("--source")
+    local_nonpersistent_flags+=("--source=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--source=")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_import_help()
+{
+    last_command="apictl_import_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_import()
+{
+    last_command="apictl_import"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("api-product")
+    commands+=("api-products")
+    commands+=("apis")
+    commands+=("app")
+    commands+=("apps")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_init()
+{
+    last_command="apictl_init"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--asyncapi=")
+    two_word_flags+=("--asyncapi")
+    local_nonpersistent_flags+=("--asyncapi")
+    local_nonpersistent_flags+=("--asyncapi=")
+    flags+=("--definition=")
+    two_word_flags+=("--definition")
+    two_word_flags+=("-d")
+    local_nonpersistent_flags+=("--definition")
+    local_nonpersistent_flags+=("--definition=")
+    local_nonpersistent_flags+=("-d")
+    flags+=("--force")
+    flags+=("-f")
     local_nonpersistent_flags+=("--force")
     local_nonpersistent_flags+=("-f")
+    flags+=("--from-har=")
+    two_word_flags+=("--from-har")
+    local_nonpersistent_flags+=("--from-har")
+    local_nonpersistent_flags+=("--from-har=")
+    flags+=("--from-postman=")
+    two_word_flags+=("--from-postman")
+    local_nonpersistent_flags+=("--from-postman")
+    local_nonpersistent_flags+=("--from-postman=")
+    flags+=("--graphql=")
+    two_word_flags+=("--graphql")
+    local_nonpersistent_flags+=("--graphql")
+    local_nonpersistent_flags+=("--graphql=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
@@ -2033,6 +2360,10 @@ _apictl_init()
     two_word_flags+=("--oas")
     local_nonpersistent_flags+=("--oas")
     local_nonpersistent_flags+=("--oas=")
+    flags+=("--wsdl=")
+    two_word_flags+=("--wsdl")
+    local_nonpersistent_flags+=("--wsdl")
+    local_nonpersistent_flags+=("--wsdl=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -4737,9 +5068,9 @@ _apictl_mi()
     noun_aliases=()
 }
 
-_apictl_remove_env()
+_apictl_migrate_help()
 {
-    last_command="apictl_remove_env"
+    last_command="apictl_migrate_help"
 
     command_aliases=()
 
@@ -4751,22 +5082,19 @@ _apictl_remove_env()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--help")
-    flags+=("-h")
-    local_nonpersistent_flags+=("--help")
-    local_nonpersistent_flags+=("-h")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
     must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_remove_help()
+_apictl_migrate_tenant()
 {
-    last_command="apictl_remove_help"
+    last_command="apictl_migrate_tenant"
 
     command_aliases=()
 
@@ -4778,25 +5106,52 @@ _apictl_remove_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--force")
+    local_nonpersistent_flags+=("--force")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--from=")
+    two_word_flags+=("--from")
+    local_nonpersistent_flags+=("--from")
+    local_nonpersistent_flags+=("--from=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
+    flags+=("--tenant=")
+    two_word_flags+=("--tenant")
+    local_nonpersistent_flags+=("--tenant")
+    local_nonpersistent_flags+=("--tenant=")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--with-keys")
+    local_nonpersistent_flags+=("--with-keys")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--from=")
+    must_have_one_flag+=("--to=")
     must_have_one_noun=()
-    has_completion_function=1
     noun_aliases=()
 }
 
-_apictl_remove()
+_apictl_migrate()
 {
-    last_command="apictl_remove"
+    last_command="apictl_migrate"
 
     command_aliases=()
 
     commands=()
-    commands+=("env")
     commands+=("help")
+    commands+=("tenant")
 
     flags=()
     two_word_flags=()
@@ -4817,9 +5172,9 @@ _apictl_remove()
     noun_aliases=()
 }
 
-_apictl_secret_create()
+_apictl_promote_api()
 {
-    last_command="apictl_secret_create"
+    last_command="apictl_promote_api"
 
     command_aliases=()
 
@@ -4831,40 +5186,72 @@ _apictl_secret_create()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--cipher=")
-    two_word_flags+=("--cipher")
-    two_word_flags+=("-c")
-    local_nonpersistent_flags+=("--cipher")
-    local_nonpersistent_flags+=("--cipher=")
-    local_nonpersistent_flags+=("-c")
-    flags+=("--from-file=")
-    two_word_flags+=("--from-file")
-    two_word_flags+=("-f")
-    local_nonpersistent_flags+=("--from-file")
-    local_nonpersistent_flags+=("--from-file=")
-    local_nonpersistent_flags+=("-f")
+    flags+=("--from=")
+    two_word_flags+=("--from")
+    local_nonpersistent_flags+=("--from")
+    local_nonpersistent_flags+=("--from=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
-    flags+=("--output=")
-    two_word_flags+=("--output")
-    two_word_flags+=("-o")
-    local_nonpersistent_flags+=("--output")
-    local_nonpersistent_flags+=("--output=")
-    local_nonpersistent_flags+=("-o")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--preserve-provider")
+    local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--provider=")
+    two_word_flags+=("--provider")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--provider")
+    local_nonpersistent_flags+=("--provider=")
+    local_nonpersistent_flags+=("-r")
+    flags+=("--rev=")
+    two_word_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev=")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-deployments")
+    local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
+    flags+=("--version=")
+    two_word_flags+=("--version")
+    two_word_flags+=("-v")
+    local_nonpersistent_flags+=("--version")
+    local_nonpersistent_flags+=("--version=")
+    local_nonpersistent_flags+=("-v")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--from=")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--to=")
+    must_have_one_flag+=("--version=")
+    must_have_one_flag+=("-v")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_secret_help()
+_apictl_promote_api-product()
 {
-    last_command="apictl_secret_help"
+    last_command="apictl_promote_api-product"
 
     command_aliases=()
 
@@ -4876,13 +5263,335 @@ _apictl_secret_help()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--from=")
+    two_word_flags+=("--from")
+    local_nonpersistent_flags+=("--from")
+    local_nonpersistent_flags+=("--from=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--import-apis")
+    local_nonpersistent_flags+=("--import-apis")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--params=")
+    two_word_flags+=("--params")
+    local_nonpersistent_flags+=("--params")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--preserve-provider")
+    local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--provider=")
+    two_word_flags+=("--provider")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--provider")
+    local_nonpersistent_flags+=("--provider=")
+    local_nonpersistent_flags+=("-r")
+    flags+=("--rev=")
+    two_word_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev")
+    local_nonpersistent_flags+=("--rev=")
+    flags+=("--rotate-revision")
+    local_nonpersistent_flags+=("--rotate-revision")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-deployments")
+    local_nonpersistent_flags+=("--skip-deployments")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--update-api-product")
+    local_nonpersistent_flags+=("--update-api-product")
+    flags+=("--update-apis")
+    local_nonpersistent_flags+=("--update-apis")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--from=")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--to=")
     must_have_one_noun=()
-    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_promote_app()
+{
+    last_command="apictl_promote_app"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--from=")
+    two_word_flags+=("--from")
+    local_nonpersistent_flags+=("--from")
+    local_nonpersistent_flags+=("--from=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--name=")
+    two_word_flags+=("--name")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name")
+    local_nonpersistent_flags+=("--name=")
+    local_nonpersistent_flags+=("-n")
+    flags+=("--owner=")
+    two_word_flags+=("--owner")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--owner")
+    local_nonpersistent_flags+=("--owner=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--preserve-owner")
+    local_nonpersistent_flags+=("--preserve-owner")
+    flags+=("--skip-cleanup")
+    local_nonpersistent_flags+=("--skip-cleanup")
+    flags+=("--skip-subscriptions")
+    flags+=("-s")
+    local_nonpersistent_flags+=("--skip-subscriptions")
+    local_nonpersistent_flags+=("-s")
+    flags+=("--target-owner=")
+    two_word_flags+=("--target-owner")
+    local_nonpersistent_flags+=("--target-owner")
+    local_nonpersistent_flags+=("--target-owner=")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
+    flags+=("--with-keys")
+    local_nonpersistent_flags+=("--with-keys")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--from=")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--owner=")
+    must_have_one_flag+=("-o")
+    must_have_one_flag+=("--to=")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_promote_help()
+{
+    last_command="apictl_promote_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_promote()
+{
+    last_command="apictl_promote"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("api-product")
+    commands+=("app")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_remove_env()
+{
+    last_command="apictl_remove_env"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_remove_help()
+{
+    last_command="apictl_remove_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_remove()
+{
+    last_command="apictl_remove"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("env")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_secret_create()
+{
+    last_command="apictl_secret_create"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--cipher=")
+    two_word_flags+=("--cipher")
+    two_word_flags+=("-c")
+    local_nonpersistent_flags+=("--cipher")
+    local_nonpersistent_flags+=("--cipher=")
+    local_nonpersistent_flags+=("-c")
+    flags+=("--from-file=")
+    two_word_flags+=("--from-file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--from-file")
+    local_nonpersistent_flags+=("--from-file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--output=")
+    two_word_flags+=("--output")
+    two_word_flags+=("-o")
+    local_nonpersistent_flags+=("--output")
+    local_nonpersistent_flags+=("--output=")
+    local_nonpersistent_flags+=("-o")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_secret_help()
+{
+    last_command="apictl_secret_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
     noun_aliases=()
 }
 
@@ -4983,10 +5692,22 @@ _apictl_set()
     two_word_flags+=("--vcs-deployment-repo-path")
     local_nonpersistent_flags+=("--vcs-deployment-repo-path")
     local_nonpersistent_flags+=("--vcs-deployment-repo-path=")
+    flags+=("--vcs-lock-expiry=")
+    two_word_flags+=("--vcs-lock-expiry")
+    local_nonpersistent_flags+=("--vcs-lock-expiry")
+    local_nonpersistent_flags+=("--vcs-lock-expiry=")
     flags+=("--vcs-source-repo-path=")
     two_word_flags+=("--vcs-source-repo-path")
     local_nonpersistent_flags+=("--vcs-source-repo-path")
     local_nonpersistent_flags+=("--vcs-source-repo-path=")
+    flags+=("--vcs-state-backend=")
+    two_word_flags+=("--vcs-state-backend")
+    local_nonpersistent_flags+=("--vcs-state-backend")
+    local_nonpersistent_flags+=("--vcs-state-backend=")
+    flags+=("--vcs-state-remote=")
+    two_word_flags+=("--vcs-state-remote")
+    local_nonpersistent_flags+=("--vcs-state-remote")
+    local_nonpersistent_flags+=("--vcs-state-remote=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -5178,9 +5899,9 @@ _apictl_undeploy()
     noun_aliases=()
 }
 
-_apictl_vcs_deploy()
+_apictl_validate_api()
 {
-    last_command="apictl_vcs_deploy"
+    last_command="apictl_validate_api"
 
     command_aliases=()
 
@@ -5192,7 +5913,173 @@ _apictl_vcs_deploy()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--environment=")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_validate_api-product()
+{
+    last_command="apictl_validate_api-product"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_validate_app()
+{
+    last_command="apictl_validate_app"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_validate_help()
+{
+    last_command="apictl_validate_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    has_completion_function=1
+    noun_aliases=()
+}
+
+_apictl_validate()
+{
+    last_command="apictl_validate"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+    commands+=("api-product")
+    commands+=("app")
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_vcs_deploy()
+{
+    last_command="apictl_vcs_deploy"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--approve=")
+    two_word_flags+=("--approve")
+    local_nonpersistent_flags+=("--approve")
+    local_nonpersistent_flags+=("--approve=")
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
+    flags+=("--environment=")
     two_word_flags+=("--environment")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment")
@@ -5202,6 +6089,22 @@ _apictl_vcs_deploy()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--parallel=")
+    two_word_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel=")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    flags+=("--report=")
+    two_word_flags+=("--report")
+    local_nonpersistent_flags+=("--report")
+    local_nonpersistent_flags+=("--report=")
+    flags+=("--scope=")
+    two_word_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope=")
     flags+=("--skip-rollback")
     local_nonpersistent_flags+=("--skip-rollback")
     flags+=("--insecure")
@@ -5215,6 +6118,45 @@ _apictl_vcs_deploy()
     noun_aliases=()
 }
 
+_apictl_vcs_diff()
+{
+    last_command="apictl_vcs_diff"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_vcs_help()
 {
     last_command="apictl_vcs_help"
@@ -5270,6 +6212,58 @@ _apictl_vcs_init()
     noun_aliases=()
 }
 
+_apictl_vcs_rollback()
+{
+    last_command="apictl_vcs_rollback"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--parallel=")
+    two_word_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel=")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    flags+=("--scope=")
+    two_word_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope=")
+    flags+=("--to=")
+    two_word_flags+=("--to")
+    local_nonpersistent_flags+=("--to")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--to=")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_vcs_status()
 {
     last_command="apictl_vcs_status"
@@ -5298,6 +6292,108 @@ _apictl_vcs_status()
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     local_nonpersistent_flags+=("-h")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    flags+=("--scope=")
+    two_word_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_vcs_unlock()
+{
+    last_command="apictl_vcs_unlock"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_vcs_watch()
+{
+    last_command="apictl_vcs_watch"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("--environment")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment")
+    local_nonpersistent_flags+=("--environment=")
+    local_nonpersistent_flags+=("-e")
+    flags+=("--health-address=")
+    two_word_flags+=("--health-address")
+    local_nonpersistent_flags+=("--health-address")
+    local_nonpersistent_flags+=("--health-address=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    local_nonpersistent_flags+=("-h")
+    flags+=("--interval=")
+    two_word_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval")
+    local_nonpersistent_flags+=("--interval=")
+    flags+=("--max-backoff=")
+    two_word_flags+=("--max-backoff")
+    local_nonpersistent_flags+=("--max-backoff")
+    local_nonpersistent_flags+=("--max-backoff=")
+    flags+=("--parallel=")
+    two_word_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel")
+    local_nonpersistent_flags+=("--parallel=")
+    flags+=("--path=")
+    two_word_flags+=("--path")
+    local_nonpersistent_flags+=("--path")
+    local_nonpersistent_flags+=("--path=")
+    flags+=("--scope=")
+    two_word_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope")
+    local_nonpersistent_flags+=("--scope=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -5317,9 +6413,13 @@ _apictl_vcs()
 
     commands=()
     commands+=("deploy")
+    commands+=("diff")
     commands+=("help")
     commands+=("init")
+    commands+=("rollback")
     commands+=("status")
+    commands+=("unlock")
+    commands+=("watch")
 
     flags=()
     two_word_flags=()
@@ -5390,10 +6490,13 @@ _apictl_root_command()
     commands+=("logout")
     commands+=("mg")
     commands+=("mi")
+    commands+=("migrate")
+    commands+=("promote")
     commands+=("remove")
     commands+=("secret")
     commands+=("set")
     commands+=("undeploy")
+    commands+=("validate")
     commands+=("vcs")
     commands+=("version")
 
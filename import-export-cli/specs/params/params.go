@@ -111,7 +111,11 @@ type ProjectParams struct {
 	NickName                   string          `yaml:"nickName,omitempty"`
 	FailedDuringPreviousDeploy bool            `yaml:"failedDuringPreviousDeploy,omitempty"`
 	Deleted                    bool            `yaml:"deleted,omitempty"`
+	Blocked                    bool            `yaml:"blocked,omitempty"`
+	MicrogatewayFailed         bool            `yaml:"microgatewayFailed,omitempty"`
+	RollbackRequested          bool            `yaml:"-"`
 	MetaData                   *utils.MetaData `yaml:"metaData,omitempty"`
+	Dependencies               []ProjectInfo   `yaml:"dependencies,omitempty"`
 }
 
 type ProjectInfo struct {
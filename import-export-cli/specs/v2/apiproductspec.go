@@ -27,6 +27,13 @@ type APIProductDefinitionFile struct {
 
 // APIProductDTODefinition represents an API Product artifact in APIM
 type APIProductDTODefinition struct {
-	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
-	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Name     string                    `json:"name,omitempty" yaml:"name,omitempty"`
+	Provider string                    `json:"provider,omitempty" yaml:"provider,omitempty"`
+	APIs     []ProductAPIDTODefinition `json:"apis,omitempty" yaml:"apis,omitempty"`
+}
+
+// ProductAPIDTODefinition represents an API which is a member of an API Product
+type ProductAPIDTODefinition struct {
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 }
@@ -83,6 +83,29 @@ type APIDTODefinition struct {
 	WebsubSubscriptionConfiguration interface{}   `json:"websubSubscriptionConfiguration" yaml:"websubSubscriptionConfiguration"`
 }
 
+// Operation represents an operation (a resource) of an API
+type Operation struct {
+	Target           string   `json:"target" yaml:"target"`
+	Verb             string   `json:"verb" yaml:"verb"`
+	AuthType         string   `json:"authType,omitempty" yaml:"authType,omitempty"`
+	ThrottlingPolicy string   `json:"throttlingPolicy,omitempty" yaml:"throttlingPolicy,omitempty"`
+	Scopes           []string `json:"scopes" yaml:"scopes"`
+}
+
+// APIScope represents a scope of an API
+type APIScope struct {
+	Scope  Scope `json:"scope" yaml:"scope"`
+	Shared bool  `json:"shared" yaml:"shared"`
+}
+
+// Scope represents the details of a scope with the roles bound to it
+type Scope struct {
+	Name        string   `json:"name" yaml:"name"`
+	DisplayName string   `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Bindings    []string `json:"bindings" yaml:"bindings"`
+}
+
 type CorsConfiguration struct {
 	CorsConfigurationEnabled      bool     `json:"corsConfigurationEnabled,omitempty" yaml:"corsConfigurationEnabled,omitempty"`
 	AccessControlAllowOrigins     []string `json:"accessControlAllowOrigins,omitempty" yaml:"accessControlAllowOrigins,omitempty"`
@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// API types of the streaming APIs, which are defined using AsyncAPI definitions
+const (
+	APITypeWS     = "WS"
+	APITypeWebSub = "WEBSUB"
+	APITypeSSE    = "SSE"
+	epWS          = "ws"
+)
+
+// Throttling policies of the streaming APIs
+const (
+	asyncDefaultPolicy       = "AsyncUnlimited"
+	asyncWebSubDefaultPolicy = "AsyncWHUnlimited"
+)
+
+// asyncAPIDocument represents the parts of an AsyncAPI 2.x definition used to populate an API
+type asyncAPIDocument struct {
+	AsyncAPI string `json:"asyncapi"`
+	Info     struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	} `json:"info"`
+	Servers map[string]struct {
+		URL       string `json:"url"`
+		Protocol  string `json:"protocol"`
+		Variables map[string]struct {
+			Default interface{} `json:"default"`
+		} `json:"variables"`
+	} `json:"servers"`
+	Channels map[string]map[string]interface{} `json:"channels"`
+}
+
+// AsyncAPIPopulate populates the API using the given AsyncAPI definition (in JSON): the info, the type of the API
+// from the protocol of the servers (WS for ws/wss, WEBSUB for websub and SSE for sse, defaulting to WS), the
+// endpoints from the URL of the servers and an operation (a topic) for each publish and subscribe of the channels
+func AsyncAPIPopulate(def *APIDTODefinition, jsonContent []byte) error {
+	var document asyncAPIDocument
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(document.AsyncAPI, "2.") {
+		return errors.New("the definition should be an AsyncAPI 2.x definition")
+	}
+
+	def.Name = document.Info.Title
+	def.Version = document.Info.Version
+	def.Provider = "admin"
+	def.Description = document.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	trimNameVersionAndContext(def)
+
+	var serverNames []string
+	for serverName := range document.Servers {
+		serverNames = append(serverNames, serverName)
+	}
+	sort.Strings(serverNames)
+	def.Type = APITypeWS
+	var serverUrl string
+	for _, serverName := range serverNames {
+		server := document.Servers[serverName]
+		apiType := ""
+		switch strings.ToLower(server.Protocol) {
+		case "ws", "wss":
+			apiType = APITypeWS
+		case "websub":
+			apiType = APITypeWebSub
+		case "sse":
+			apiType = APITypeSSE
+		}
+		if apiType == "" {
+			continue
+		}
+		def.Type = apiType
+		serverUrl = server.URL
+		for name, variable := range server.Variables {
+			if variable.Default != nil {
+				serverUrl = strings.ReplaceAll(serverUrl, "{"+name+"}", fmt.Sprint(variable.Default))
+			}
+		}
+		break
+	}
+
+	switch def.Type {
+	case APITypeWebSub:
+		// the WebSub APIs do not have endpoints, as the subscribers are called back by the hub
+		def.Policies = []string{asyncWebSubDefaultPolicy}
+		def.EndpointConfig = nil
+	case APITypeWS:
+		def.Policies = []string{asyncDefaultPolicy}
+		if strings.HasPrefix(serverUrl, "ws://") || strings.HasPrefix(serverUrl, "wss://") {
+			def.EndpointConfig = &map[string]interface{}{
+				"endpoint_type":        epWS,
+				"production_endpoints": map[string]interface{}{"url": serverUrl},
+				"sandbox_endpoints":    map[string]interface{}{"url": serverUrl},
+			}
+		}
+	case APITypeSSE:
+		def.Policies = []string{asyncDefaultPolicy}
+		if strings.HasPrefix(serverUrl, "http://") || strings.HasPrefix(serverUrl, "https://") {
+			if err := setEndpointConfig(def, &Endpoints{Urls: []string{serverUrl}}, &Endpoints{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	var channelNames []string
+	for channelName := range document.Channels {
+		channelNames = append(channelNames, channelName)
+	}
+	sort.Strings(channelNames)
+	var operations []interface{}
+	for _, channelName := range channelNames {
+		for _, verb := range []string{"subscribe", "publish"} {
+			if _, ok := document.Channels[channelName][verb]; !ok {
+				continue
+			}
+			operations = append(operations, Operation{
+				Target:           channelName,
+				Verb:             strings.ToUpper(verb),
+				AuthType:         DefaultAuthType,
+				ThrottlingPolicy: DefaultThrottlingPolicy,
+				Scopes:           []string{},
+			})
+		}
+	}
+	if len(operations) > 0 {
+		def.Operations = operations
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestAsyncAPIPopulate(t *testing.T) {
+	var def APIDTODefinition
+	jsonContent, err := utils.LoadYamlAsJson("testdata/streetlights_ws.yaml")
+	assert.Nil(t, err, "err should be nil")
+	err = AsyncAPIPopulate(&def, jsonContent)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "StreetlightsAPI", def.Name, "should return correct api name")
+	assert.Equal(t, "/StreetlightsAPI", def.Context, "should return correct context")
+	assert.Equal(t, APITypeWS, def.Type, "should return correct api type")
+	assert.Equal(t, []string{"AsyncUnlimited"}, def.Policies, "should return correct policies")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, "ws", endpointConfig["endpoint_type"], "should return correct endpoint type")
+	assert.Equal(t, map[string]interface{}{"url": "ws://streetlights.example.com:8080/lights"},
+		endpointConfig["production_endpoints"], "should substitute the server variables")
+	var operations []string
+	for _, operation := range def.Operations {
+		operations = append(operations, operation.(Operation).Verb+" "+operation.(Operation).Target)
+	}
+	assert.Equal(t, []string{"SUBSCRIBE /lights/dim", "PUBLISH /lights/dim", "SUBSCRIBE /lights/measured"},
+		operations, "should have an operation per topic")
+}
+
+func TestAsyncAPIPopulateWithWebSub(t *testing.T) {
+	def := APIDTODefinition{EndpointConfig: map[string]interface{}{"endpoint_type": "http"}}
+	err := AsyncAPIPopulate(&def, []byte(`{"asyncapi": "2.0.0", "info": {"title": "Hub", "version": "1.0.0"},
+		"servers": {"hub": {"url": "https://hub.example.com", "protocol": "websub"}},
+		"channels": {"news": {"subscribe": {}}}}`))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, APITypeWebSub, def.Type, "should return correct api type")
+	assert.Equal(t, []string{"AsyncWHUnlimited"}, def.Policies, "should return correct policies")
+	assert.Nil(t, def.EndpointConfig, "a WebSub API should not have endpoints")
+}
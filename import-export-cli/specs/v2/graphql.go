@@ -0,0 +1,326 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"errors"
+	"strings"
+)
+
+// API type and the operation verbs of a GraphQL API
+const (
+	APITypeGraphQL      = "GRAPHQL"
+	graphQLQuery        = "QUERY"
+	graphQLMutation     = "MUTATION"
+	graphQLSubscription = "SUBSCRIPTION"
+)
+
+// Kinds of the tokens of a GraphQL schema
+const (
+	graphQLTokenName = iota
+	graphQLTokenPunctuator
+	graphQLTokenValue
+)
+
+// Keywords starting the definitions of a GraphQL schema, which end the list of interfaces of a type
+var graphQLDefinitionKeywords = map[string]bool{
+	"schema": true, "scalar": true, "type": true, "interface": true, "union": true, "enum": true, "input": true,
+	"directive": true, "extend": true,
+}
+
+// graphQLToken is a token of a GraphQL schema. The descriptions and the other strings are value tokens, so that the
+// content of those is never read as a part of the schema.
+type graphQLToken struct {
+	kind  int
+	value string
+}
+
+// graphQLSchemaParser reads the root operation types and the fields of the object types of a GraphQL schema. The
+// other definitions and the arguments, the default values and the directives are skipped.
+type graphQLSchemaParser struct {
+	tokens   []graphQLToken
+	position int
+}
+
+// GraphQLPopulate populates the API using the given GraphQL schema: the type of the API, and an operation for each
+// field of the query, the mutation and the subscription types. The schema does not name the API, hence the name and
+// the context are not populated.
+func GraphQLPopulate(def *APIDTODefinition, schema []byte) error {
+	tokens, err := tokenizeGraphQLSchema(string(schema))
+	if err != nil {
+		return err
+	}
+	parser := &graphQLSchemaParser{tokens: tokens}
+	schemaRootTypes, types, typeFields, err := parser.parse()
+	if err != nil {
+		return err
+	}
+	rootTypes := map[string]string{
+		"Query":        graphQLQuery,
+		"Mutation":     graphQLMutation,
+		"Subscription": graphQLSubscription,
+	}
+	// the root operation types can be renamed in the schema definition
+	if schemaRootTypes != nil {
+		rootTypes = schemaRootTypes
+	}
+
+	var operations []interface{}
+	for i, typeName := range types {
+		verb, isRootType := rootTypes[typeName]
+		if !isRootType {
+			continue
+		}
+		for _, field := range typeFields[i] {
+			operations = append(operations, Operation{
+				Target:           field,
+				Verb:             verb,
+				AuthType:         DefaultAuthType,
+				ThrottlingPolicy: DefaultThrottlingPolicy,
+				Scopes:           []string{},
+			})
+		}
+	}
+	if len(operations) == 0 {
+		return errors.New("the GraphQL schema does not have any query, mutation or subscription fields")
+	}
+	def.Type = APITypeGraphQL
+	def.Operations = operations
+	return nil
+}
+
+// tokenizeGraphQLSchema splits a GraphQL schema into the names, the punctuators and the values (strings and numbers),
+// leaving out the comments, the commas and the white spaces
+func tokenizeGraphQLSchema(schema string) ([]graphQLToken, error) {
+	var tokens []graphQLToken
+	for i := 0; i < len(schema); {
+		c := schema[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(schema) && schema[i] != '\n' && schema[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(schema[i:], `"""`):
+			end := i + 3
+			for end < len(schema) && !strings.HasPrefix(schema[end:], `"""`) {
+				if strings.HasPrefix(schema[end:], `\"""`) {
+					end += 4
+				} else {
+					end++
+				}
+			}
+			if end >= len(schema) {
+				return nil, errors.New("the GraphQL schema has an unterminated block string")
+			}
+			tokens = append(tokens, graphQLToken{graphQLTokenValue, schema[i : end+3]})
+			i = end + 3
+		case c == '"':
+			end := i + 1
+			for end < len(schema) && schema[end] != '"' && schema[end] != '\n' {
+				if schema[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(schema) || schema[end] != '"' {
+				return nil, errors.New("the GraphQL schema has an unterminated string")
+			}
+			tokens = append(tokens, graphQLToken{graphQLTokenValue, schema[i : end+1]})
+			i = end + 1
+		case isGraphQLNameStart(c):
+			end := i + 1
+			for end < len(schema) && (isGraphQLNameStart(schema[end]) || (schema[end] >= '0' && schema[end] <= '9')) {
+				end++
+			}
+			tokens = append(tokens, graphQLToken{graphQLTokenName, schema[i:end]})
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(schema) && strings.IndexByte("0123456789.eE+-", schema[end]) >= 0 {
+				end++
+			}
+			tokens = append(tokens, graphQLToken{graphQLTokenValue, schema[i:end]})
+			i = end
+		default:
+			tokens = append(tokens, graphQLToken{graphQLTokenPunctuator, string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// Returns whether the character can start a name of a GraphQL schema
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parse reads the definitions of the schema
+// Returns map[string]string, the root operation types of the schema definition (or nil if there is no schema
+// definition) against the type names
+// Returns []string and [][]string, the names of the object types (and the extensions of those) in the order of the
+// definitions, along with the names of the fields of each
+func (parser *graphQLSchemaParser) parse() (map[string]string, []string, [][]string, error) {
+	var schemaRootTypes map[string]string
+	var types []string
+	var typeFields [][]string
+	for parser.position < len(parser.tokens) {
+		token := parser.next()
+		switch {
+		case token.kind == graphQLTokenName && token.value == "schema":
+			parser.skipDirectives()
+			if !parser.accept(graphQLTokenPunctuator, "{") {
+				continue
+			}
+			if schemaRootTypes == nil {
+				schemaRootTypes = make(map[string]string)
+			}
+			for !parser.accept(graphQLTokenPunctuator, "}") {
+				operation := parser.next()
+				if operation.kind != graphQLTokenName || !parser.accept(graphQLTokenPunctuator, ":") {
+					return nil, nil, nil, errors.New("the schema definition of the GraphQL schema is invalid")
+				}
+				rootType := parser.next()
+				schemaRootTypes[rootType.value] = strings.ToUpper(operation.value)
+			}
+		case token.kind == graphQLTokenName && token.value == "type":
+			typeName := parser.next()
+			if typeName.kind != graphQLTokenName {
+				return nil, nil, nil, errors.New("a type of the GraphQL schema does not have a name")
+			}
+			parser.skipInterfaces()
+			parser.skipDirectives()
+			if !parser.accept(graphQLTokenPunctuator, "{") {
+				continue
+			}
+			fields, err := parser.parseFields(typeName.value)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			types = append(types, typeName.value)
+			typeFields = append(typeFields, fields)
+		case token.kind == graphQLTokenPunctuator && token.value == "{":
+			// the bodies of the other definitions, such as the interfaces, the input types and the enums
+			parser.skipNested("{", "}")
+		case token.kind == graphQLTokenPunctuator && token.value == "(":
+			// the arguments of the directive definitions
+			parser.skipNested("(", ")")
+		}
+	}
+	return schemaRootTypes, types, typeFields, nil
+}
+
+// parseFields reads the names of the fields of an object type, after the opening brace of the type
+func (parser *graphQLSchemaParser) parseFields(typeName string) ([]string, error) {
+	var fields []string
+	for !parser.accept(graphQLTokenPunctuator, "}") {
+		// the description of the field
+		if parser.accept(graphQLTokenValue, "") {
+			continue
+		}
+		field := parser.next()
+		if field.kind != graphQLTokenName {
+			return nil, errors.New("the type " + typeName + " of the GraphQL schema has an invalid field")
+		}
+		if parser.accept(graphQLTokenPunctuator, "(") {
+			parser.skipNested("(", ")")
+		}
+		if !parser.accept(graphQLTokenPunctuator, ":") {
+			return nil, errors.New("the field " + field.value + " of the type " + typeName +
+				" of the GraphQL schema does not have a type")
+		}
+		// the type of the field, such as [String!]!
+		for parser.accept(graphQLTokenPunctuator, "[") {
+		}
+		if parser.next().kind != graphQLTokenName {
+			return nil, errors.New("the field " + field.value + " of the type " + typeName +
+				" of the GraphQL schema has an invalid type")
+		}
+		for parser.accept(graphQLTokenPunctuator, "]") || parser.accept(graphQLTokenPunctuator, "!") {
+		}
+		parser.skipDirectives()
+		fields = append(fields, field.value)
+	}
+	return fields, nil
+}
+
+// skipInterfaces skips the interfaces implemented by a type (ie: implements Node & Character)
+func (parser *graphQLSchemaParser) skipInterfaces() {
+	if !parser.accept(graphQLTokenName, "implements") {
+		return
+	}
+	parser.accept(graphQLTokenPunctuator, "&")
+	for parser.position < len(parser.tokens) {
+		token := parser.tokens[parser.position]
+		if token.kind != graphQLTokenName || graphQLDefinitionKeywords[token.value] {
+			return
+		}
+		parser.position++
+		parser.accept(graphQLTokenPunctuator, "&")
+	}
+}
+
+// skipDirectives skips the directives (ie: @deprecated(reason: "Use hero")) along with the arguments of those
+func (parser *graphQLSchemaParser) skipDirectives() {
+	for parser.accept(graphQLTokenPunctuator, "@") {
+		parser.next()
+		if parser.accept(graphQLTokenPunctuator, "(") {
+			parser.skipNested("(", ")")
+		}
+	}
+}
+
+// skipNested skips the tokens up to the closing punctuator, after the opening punctuator
+func (parser *graphQLSchemaParser) skipNested(open, close string) {
+	depth := 1
+	for depth > 0 && parser.position < len(parser.tokens) {
+		token := parser.next()
+		if token.kind != graphQLTokenPunctuator {
+			continue
+		}
+		if token.value == open {
+			depth++
+		} else if token.value == close {
+			depth--
+		}
+	}
+}
+
+// next returns the next token, or an empty token at the end of the schema
+func (parser *graphQLSchemaParser) next() graphQLToken {
+	if parser.position >= len(parser.tokens) {
+		return graphQLToken{kind: graphQLTokenPunctuator}
+	}
+	parser.position++
+	return parser.tokens[parser.position-1]
+}
+
+// accept moves to the next token if it is of the given kind and value (or any value if the value is empty)
+// Returns bool, whether the next token is accepted
+func (parser *graphQLSchemaParser) accept(kind int, value string) bool {
+	if parser.position >= len(parser.tokens) {
+		return false
+	}
+	token := parser.tokens[parser.position]
+	if token.kind != kind || (value != "" && token.value != value) {
+		return false
+	}
+	parser.position++
+	return true
+}
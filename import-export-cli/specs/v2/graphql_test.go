@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLPopulate(t *testing.T) {
+	var def APIDTODefinition
+	schema, err := ioutil.ReadFile("testdata/starwars.graphql")
+	assert.Nil(t, err, "err should be nil")
+	err = GraphQLPopulate(&def, schema)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, APITypeGraphQL, def.Type, "should return correct api type")
+	var operations []string
+	for _, operation := range def.Operations {
+		operations = append(operations, operation.(Operation).Verb+" "+operation.(Operation).Target)
+	}
+	assert.Equal(t, []string{"QUERY hero", "QUERY droid", "QUERY characters", "MUTATION createReview",
+		"QUERY starship"}, operations, "should have an operation per field of the root types")
+}
+
+func TestGraphQLPopulateWithoutRootTypes(t *testing.T) {
+	var def APIDTODefinition
+	err := GraphQLPopulate(&def, []byte("type Droid {\n  id: ID!\n}\n"))
+	assert.NotNil(t, err, "err should not be nil for a schema without root types")
+}
+
+func TestGraphQLPopulateWithNestedArgumentsAndKeywordFields(t *testing.T) {
+	var def APIDTODefinition
+	err := GraphQLPopulate(&def, []byte(`
+directive @auth(requires: Role = { name: "admin" }) on FIELD_DEFINITION
+
+interface Node { id: ID! }
+
+type Query implements Node & Entity @key(fields: "{ id }") {
+  id: ID!
+  search(filter: SearchFilter = { type: "droid", range: { from: 1, to: 10 } }, first: Int = 10): [Result!]!
+  type: String @auth(requires: { name: "admin" })
+  """
+  The schema of the API. type Mutation { notAField: String }
+  """
+  schema(input: [String] = ["}", ")"]): String
+}
+
+input SearchFilter {
+  type: String
+  range: Range = { from: 0 }
+}
+`))
+	assert.Nil(t, err, "err should be nil")
+	var operations []string
+	for _, operation := range def.Operations {
+		operations = append(operations, operation.(Operation).Verb+" "+operation.(Operation).Target)
+	}
+	assert.Equal(t, []string{"QUERY id", "QUERY search", "QUERY type", "QUERY schema"}, operations,
+		"should have an operation per field, skipping the arguments, the directives and the descriptions")
+}
+
+func TestGraphQLPopulateWithInvalidSchema(t *testing.T) {
+	var def APIDTODefinition
+	err := GraphQLPopulate(&def, []byte("type Query {\n  hero(episode: Episode): \n}\n"))
+	assert.NotNil(t, err, "err should not be nil for a field without a type")
+	err = GraphQLPopulate(&def, []byte("type Query {\n  \"\"\"unterminated\n  hero: Character\n}\n"))
+	assert.NotNil(t, err, "err should not be nil for an unterminated description")
+}
@@ -0,0 +1,147 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matches the path segments which are identifiers (ie: numbers and UUIDs), which are taken as path parameters
+var harIdSegmentRegex = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// Extensions of the static resources of web pages, which are not added as resources of the API
+var harStaticExtensions = map[string]bool{
+	".css": true, ".js": true, ".map": true, ".html": true, ".htm": true, ".png": true, ".jpg": true, ".jpeg": true,
+	".gif": true, ".svg": true, ".ico": true, ".woff": true, ".woff2": true, ".ttf": true, ".eot": true,
+}
+
+type harArchive struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		PostData    *struct {
+			MimeType string         `json:"mimeType"`
+			Text     string         `json:"text"`
+			Params   []harNameValue `json:"params"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Status     int    `json:"status"`
+		StatusText string `json:"statusText"`
+		Content    struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+			Encoding string `json:"encoding"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARToOAI3 infers an OpenAPI 3.0 definition (in JSON) with the given title from the given HAR (HTTP Archive) file:
+// the paths and the verbs from the recorded requests (the numeric and UUID path segments as the path parameters), the
+// query and the header parameters, the schemas of the request and the response bodies from the recorded bodies, and
+// the servers from the hosts of the requests. The requests to static resources (ie: scripts and images) are skipped.
+func HARToOAI3(harContent []byte, title string) ([]byte, error) {
+	var archive harArchive
+	if err := json.Unmarshal(harContent, &archive); err != nil {
+		return nil, err
+	}
+	builder := newOAI3Builder()
+	for _, entry := range archive.Log.Entries {
+		server, resourcePath, _ := splitRequestURL(entry.Request.URL)
+		if harStaticExtensions[strings.ToLower(path.Ext(resourcePath))] {
+			continue
+		}
+		request := recordedRequest{
+			method:     entry.Request.Method,
+			pathValues: make(map[string]string),
+		}
+		request.url = server + templateHARPath(resourcePath, request.pathValues)
+		for _, param := range entry.Request.QueryString {
+			request.query = append(request.query, nameValue{name: param.Name, value: param.Value})
+		}
+		for _, header := range entry.Request.Headers {
+			// HTTP/2 pseudo headers (ie: :authority) are not added
+			if !strings.HasPrefix(header.Name, ":") {
+				request.headers = append(request.headers, nameValue{name: header.Name, value: header.Value})
+			}
+		}
+		if postData := entry.Request.PostData; postData != nil {
+			request.mimeType = postData.MimeType
+			request.body = postData.Text
+			if postData.Text == "" {
+				for _, param := range postData.Params {
+					request.formParams = append(request.formParams, nameValue{name: param.Name, value: param.Value})
+				}
+			}
+		}
+		response := recordedResponse{
+			status:      entry.Response.Status,
+			description: entry.Response.StatusText,
+			mimeType:    entry.Response.Content.MimeType,
+		}
+		// base64 encoded (ie: binary) contents are not inferred
+		if entry.Response.Content.Encoding == "" {
+			response.body = entry.Response.Content.Text
+		}
+		request.responses = []recordedResponse{response}
+		builder.addRequest(request)
+	}
+	if len(builder.paths) == 0 {
+		return nil, errors.New("no API requests found in the HAR file")
+	}
+	return builder.build(title, "")
+}
+
+// templateHARPath replaces the identifier segments of the given path with path parameters named after the preceding
+// segment (ie: /pets/12 as /pets/{petsId}), and records the values of those
+func templateHARPath(resourcePath string, pathValues map[string]string) string {
+	segments := strings.Split(resourcePath, "/")
+	for i, segment := range segments {
+		if i == 0 || !harIdSegmentRegex.MatchString(segment) {
+			continue
+		}
+		name := "id"
+		if previous := segments[i-1]; previous != "" && !strings.HasPrefix(previous, "{") {
+			name = previous + "Id"
+		}
+		for j, baseName := 2, name; pathValues[name] != ""; j++ {
+			name = baseName + strconv.Itoa(j)
+		}
+		pathValues[name] = segment
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/")
+}
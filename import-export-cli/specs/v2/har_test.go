@@ -0,0 +1,67 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHARToOAI3(t *testing.T) {
+	har, err := ioutil.ReadFile("testdata/orders.har")
+	assert.Nil(t, err, "err should be nil")
+	content, err := HARToOAI3(har, "OrdersAPI")
+	assert.Nil(t, err, "err should be nil")
+
+	var definition map[string]interface{}
+	assert.Nil(t, json.Unmarshal(content, &definition), "err should be nil")
+	assert.Equal(t, "OrdersAPI", definition["info"].(map[string]interface{})["title"], "should use the given title")
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://orders.example.com/api/v1"}},
+		definition["servers"], "should use the host and the common base path as the server")
+
+	paths := definition["paths"].(map[string]interface{})
+	assert.Len(t, paths, 2, "should skip the static resources")
+	assert.Contains(t, paths["/orders"], "get", "should add the recorded verbs")
+	assert.Contains(t, paths["/orders"], "post", "should add the recorded verbs")
+	getOrder := paths["/orders/{ordersId}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "ordersId", "in": "path", "required": true,
+		"example": "42", "schema": map[string]interface{}{"type": "integer"}}}, getOrder["parameters"],
+		"should take the identifier segments as path parameters")
+	assert.Contains(t, getOrder["responses"], "404", "should add the recorded response status")
+
+	listOrders := paths["/orders"].(map[string]interface{})["get"].(map[string]interface{})
+	for _, parameter := range listOrders["parameters"].([]interface{}) {
+		assert.NotEqual(t, ":authority", parameter.(map[string]interface{})["name"],
+			"should not add the pseudo headers")
+	}
+}
+
+func TestHARToOAI3WithoutRequests(t *testing.T) {
+	_, err := HARToOAI3([]byte(`{"log": {"entries": []}}`), "OrdersAPI")
+	assert.NotNil(t, err, "err should not be nil for a HAR file without requests")
+}
+
+func TestTemplateHARPath(t *testing.T) {
+	pathValues := make(map[string]string)
+	resourcePath := templateHARPath("/users/7/orders/3f2b8c1e-4d5a-4b6c-8d7e-9f0a1b2c3d4e", pathValues)
+	assert.Equal(t, "/users/{usersId}/orders/{ordersId}", resourcePath, "should template the identifier segments")
+	assert.Equal(t, "7", pathValues["usersId"], "should record the value of the path parameter")
+}
@@ -20,10 +20,32 @@ package v2
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// oai3Document represents the parts of an OpenAPI 3.0/3.1 definition used to populate an API. The definition is not
+// loaded using the openapi3 loader, as it does not support the OpenAPI 3.1 schemas.
+type oai3Document struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	} `json:"info"`
+	Servers    []openapi3.Server `json:"servers"`
+	Components struct {
+		SecuritySchemes map[string]struct {
+			Type   string `json:"type"`
+			Scheme string `json:"scheme"`
+		} `json:"securitySchemes"`
+	} `json:"components"`
+}
+
 func oai3XWSO2Cors(exts map[string]interface{}) (*CorsConfiguration, bool, error) {
 	if v, ok := exts["x-wso2-cors"]; ok {
 		ep, ok := v.(json.RawMessage)
@@ -37,7 +59,7 @@ func oai3XWSO2Cors(exts map[string]interface{}) (*CorsConfiguration, bool, error
 			return &cors, true, nil
 		}
 	}
-	return nil, true, nil
+	return nil, false, nil
 }
 
 type Tag struct {
@@ -77,7 +99,7 @@ func oai3XWSO2ProductionEndpoints(exts map[string]interface{}) (*Endpoints, bool
 			return &prodEp, true, nil
 		}
 	}
-	return nil, true, nil
+	return &Endpoints{}, false, nil
 }
 
 func oai3XWso2SandboxEndpoints(exts map[string]interface{}) (*Endpoints, bool, error) {
@@ -92,7 +114,7 @@ func oai3XWso2SandboxEndpoints(exts map[string]interface{}) (*Endpoints, bool, e
 			return &sandboxEp, true, nil
 		}
 	}
-	return nil, true, nil
+	return &Endpoints{}, false, nil
 }
 
 func oai3WSO2Basepath(exts map[string]interface{}) (string, bool, error) {
@@ -110,6 +132,214 @@ func oai3WSO2Basepath(exts map[string]interface{}) (string, bool, error) {
 	return "", false, nil
 }
 
+func oai3XWSO2AuthHeader(exts map[string]interface{}) (string, bool, error) {
+	if v, ok := exts["x-wso2-auth-header"]; ok {
+		data, ok := v.(json.RawMessage)
+		if ok {
+			var authHeader string
+			err := json.Unmarshal(data, &authHeader)
+			if err != nil {
+				return "", true, err
+			}
+			return authHeader, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func oai3XThrottlingTier(exts map[string]interface{}) (string, bool, error) {
+	if v, ok := exts["x-throttling-tier"]; ok {
+		data, ok := v.(json.RawMessage)
+		if ok {
+			var throttlingTier string
+			err := json.Unmarshal(data, &throttlingTier)
+			if err != nil {
+				return "", true, err
+			}
+			return throttlingTier, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func oai3XWSO2Transports(exts map[string]interface{}) ([]string, bool, error) {
+	if v, ok := exts["x-wso2-transports"]; ok {
+		data, ok := v.(json.RawMessage)
+		if ok {
+			var transports []string
+			err := json.Unmarshal(data, &transports)
+			if err != nil {
+				return nil, true, err
+			}
+			return transports, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// oai3ServerEndpoints returns the first absolute URL of the servers as the production endpoint, substituting the
+// server variables with their defaults
+func oai3ServerEndpoints(servers []openapi3.Server) *Endpoints {
+	for _, server := range servers {
+		serverUrl := server.URL
+		for name, variable := range server.Variables {
+			if variable == nil || variable.Default == nil {
+				continue
+			}
+			serverUrl = strings.ReplaceAll(serverUrl, "{"+name+"}", fmt.Sprint(variable.Default))
+		}
+		if u, err := url.Parse(serverUrl); err == nil && u.IsAbs() && !strings.Contains(serverUrl, "{") {
+			return &Endpoints{Urls: []string{strings.TrimSuffix(serverUrl, "/")}}
+		}
+	}
+	return nil
+}
+
+// oai3SecuritySchemes maps the security schemes of the components of the definition to the security schemes of APIM
+func oai3SecuritySchemes(document *oai3Document) []string {
+	var names []string
+	for name := range document.Components.SecuritySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var schemes []string
+	added := make(map[string]bool)
+	addScheme := func(scheme string) {
+		if !added[scheme] {
+			added[scheme] = true
+			schemes = append(schemes, scheme)
+		}
+	}
+	for _, name := range names {
+		securityScheme := document.Components.SecuritySchemes[name]
+		switch securityScheme.Type {
+		case "oauth2", "openIdConnect":
+			addScheme("oauth2")
+		case "apiKey":
+			addScheme("api_key")
+		case "http":
+			if strings.EqualFold(securityScheme.Scheme, "basic") {
+				addScheme("basic_auth")
+			} else if strings.EqualFold(securityScheme.Scheme, "bearer") {
+				addScheme("oauth2")
+			}
+		case "mutualTLS":
+			addScheme("mutualssl")
+		}
+	}
+	if len(schemes) == 0 {
+		return nil
+	}
+	if len(schemes) == 1 && schemes[0] == "mutualssl" {
+		return append(schemes, "mutualssl_mandatory")
+	}
+	return append(schemes, "oauth_basic_auth_api_key_mandatory")
+}
+
+// IsOAI3Definition returns whether the given definition (in JSON) is an OpenAPI 3.x definition
+func IsOAI3Definition(jsonContent []byte) bool {
+	var document oai3Document
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return false
+	}
+	return strings.HasPrefix(document.OpenAPI, "3.")
+}
+
+// OAI3Populate populates the API using the given OpenAPI 3.0/3.1 definition (in JSON): the info, the x-wso2-basePath
+// for the context, the x-wso2 endpoints (or else the servers) for the endpoints, the x-wso2 security extensions (or
+// else the security schemes of the components), the operations with the scopes and the other x-wso2 extensions
+func OAI3Populate(def *APIDTODefinition, jsonContent []byte) error {
+	var document oai3Document
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return err
+	}
+	var rawDocument map[string]json.RawMessage
+	if err := json.Unmarshal(jsonContent, &rawDocument); err != nil {
+		return err
+	}
+	// the helpers read the x-wso2 extensions and the tags from the top level fields of the definition
+	exts := make(map[string]interface{}, len(rawDocument))
+	for key, value := range rawDocument {
+		exts[key] = value
+	}
+
+	def.Name = document.Info.Title
+	def.Version = document.Info.Version
+	def.Provider = "admin"
+	def.Description = document.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	def.Tags = oai3Tags(exts)
+
+	basepath, ok, err := oai3WSO2Basepath(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		populateContextFromWSO2BasePath(def, basepath)
+	}
+	trimNameVersionAndContext(def)
+
+	cors, ok, err := oai3XWSO2Cors(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.CorsConfiguration = cors
+	}
+	authHeader, ok, err := oai3XWSO2AuthHeader(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.AuthorizationHeader = authHeader
+	}
+	transports, ok, err := oai3XWSO2Transports(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.Transport = transports
+	}
+	throttlingTier, ok, err := oai3XThrottlingTier(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.APIThrottlingPolicy = throttlingTier
+	}
+	// the security schemes of the API given as the x-wso2 extensions take precedence over the security schemes of the
+	// components, which have the default OAuth2 security scheme of the scopes as well
+	securitySchemes, ok, err := wso2SecuritySchemes(jsonContent)
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.SecurityScheme = securitySchemes
+	} else if securitySchemes := oai3SecuritySchemes(&document); len(securitySchemes) > 0 {
+		def.SecurityScheme = securitySchemes
+	}
+	if err := populateOperationsAndScopes(def, jsonContent); err != nil {
+		return err
+	}
+
+	prodEp, foundProdEp, err := oai3XWSO2ProductionEndpoints(exts)
+	if err != nil {
+		return err
+	}
+	sandboxEp, foundSandboxEp, err := oai3XWso2SandboxEndpoints(exts)
+	if err != nil {
+		return err
+	}
+	if foundProdEp || foundSandboxEp {
+		return setEndpointConfig(def, prodEp, sandboxEp)
+	}
+	if serverEp := oai3ServerEndpoints(document.Servers); serverEp != nil {
+		return setEndpointConfig(def, serverEp, &Endpoints{})
+	}
+	return nil
+}
+
 // oai3GetHttpVerbs generates verbs for api definition
 func oai3GetHttpVerbs(item *openapi3.PathItem) (verbs []string) {
 	if item.Get != nil {
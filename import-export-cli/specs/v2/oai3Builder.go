@@ -0,0 +1,459 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Headers which are not added as the parameters of the operations, as those are handled by the gateway or the client
+var ignoredRequestHeaders = map[string]bool{
+	"accept": true, "accept-encoding": true, "accept-language": true, "authorization": true, "cache-control": true,
+	"connection": true, "content-length": true, "content-type": true, "cookie": true, "host": true, "origin": true,
+	"pragma": true, "referer": true, "user-agent": true, "postman-token": true,
+}
+
+// Matches the path parameters of a resource path (ie: {petId} of /pets/{petId})
+var pathParamRegex = regexp.MustCompile(`{([^{}]+)}`)
+
+// nameValue represents a parameter, a header or a form field of a recorded request
+type nameValue struct {
+	name  string
+	value string
+}
+
+// recordedRequest represents a request (along with its responses) of a Postman collection or a HAR file, from which
+// an operation of the OpenAPI definition is inferred
+type recordedRequest struct {
+	name       string
+	method     string
+	url        string
+	query      []nameValue
+	headers    []nameValue
+	pathValues map[string]string
+	mimeType   string
+	body       string
+	formParams []nameValue
+	responses  []recordedResponse
+}
+
+// recordedResponse represents a response of a recorded request
+type recordedResponse struct {
+	status      int
+	description string
+	mimeType    string
+	body        string
+}
+
+// oai3Builder builds an OpenAPI 3.0 definition from the recorded requests, merging the requests to the same resource
+type oai3Builder struct {
+	servers      []string
+	paths        map[string]map[string]map[string]interface{}
+	operationIds map[string]bool
+}
+
+func newOAI3Builder() *oai3Builder {
+	return &oai3Builder{
+		paths:        make(map[string]map[string]map[string]interface{}),
+		operationIds: make(map[string]bool),
+	}
+}
+
+// addRequest adds the operation of the given request, or merges the request into the operation if the resource is
+// already added. The server of the URL of the request is added to the servers of the definition.
+func (builder *oai3Builder) addRequest(request recordedRequest) {
+	method := strings.ToLower(request.method)
+	if method == "" {
+		method = "get"
+	}
+	if !isOperationVerb(method) {
+		return
+	}
+	server, resourcePath, query := splitRequestURL(request.url)
+	if server != "" {
+		builder.addServer(server)
+	}
+	request.query = append(query, request.query...)
+
+	pathItem, ok := builder.paths[resourcePath]
+	if !ok {
+		pathItem = make(map[string]map[string]interface{})
+		builder.paths[resourcePath] = pathItem
+	}
+	operation, exists := pathItem[method]
+	if !exists {
+		operation = map[string]interface{}{
+			"operationId": builder.uniqueOperationId(request.name, method, resourcePath),
+			"parameters":  []interface{}{},
+			"responses":   map[string]interface{}{},
+		}
+		if request.name != "" {
+			operation["summary"] = request.name
+		}
+		pathItem[method] = operation
+	}
+
+	var parameters []interface{}
+	for _, match := range pathParamRegex.FindAllStringSubmatch(resourcePath, -1) {
+		parameters = append(parameters, buildParameter(match[1], "path", request.pathValues[match[1]], true))
+	}
+	for _, param := range request.query {
+		parameters = append(parameters, buildParameter(param.name, "query", param.value, false))
+	}
+	for _, header := range request.headers {
+		if !ignoredRequestHeaders[strings.ToLower(header.name)] {
+			parameters = append(parameters, buildParameter(header.name, "header", header.value, false))
+		}
+	}
+	operation["parameters"] = mergeParameters(operation["parameters"].([]interface{}), parameters)
+
+	if _, hasBody := operation["requestBody"]; !hasBody {
+		if requestBody := buildRequestBody(request); requestBody != nil {
+			operation["requestBody"] = requestBody
+		}
+	}
+	responses := operation["responses"].(map[string]interface{})
+	for _, response := range request.responses {
+		status := strconv.Itoa(response.status)
+		if response.status == 0 {
+			status = "default"
+		}
+		if _, exists := responses[status]; !exists {
+			responses[status] = buildResponse(response)
+		}
+	}
+}
+
+// build returns the OpenAPI 3.0 definition (in JSON) with the given title and description. The base path common to
+// all the resources (ie: /api/v1) is moved to the servers.
+func (builder *oai3Builder) build(title, description string) ([]byte, error) {
+	var resourcePaths []string
+	for resourcePath := range builder.paths {
+		resourcePaths = append(resourcePaths, resourcePath)
+	}
+	basePath := getCommonBasePath(resourcePaths)
+
+	paths := make(map[string]interface{}, len(builder.paths))
+	for resourcePath, pathItem := range builder.paths {
+		operations := make(map[string]interface{}, len(pathItem))
+		for method, operation := range pathItem {
+			if responses := operation["responses"].(map[string]interface{}); len(responses) == 0 {
+				responses["200"] = map[string]interface{}{"description": "OK"}
+			}
+			if len(operation["parameters"].([]interface{})) == 0 {
+				delete(operation, "parameters")
+			}
+			operations[method] = operation
+		}
+		paths[strings.TrimPrefix(resourcePath, basePath)] = operations
+	}
+	info := map[string]interface{}{
+		"title":   title,
+		"version": "1.0.0",
+	}
+	if description != "" {
+		info["description"] = description
+	}
+	definition := map[string]interface{}{
+		"openapi": "3.0.1",
+		"info":    info,
+		"paths":   paths,
+	}
+	if len(builder.servers) > 0 {
+		servers := make([]interface{}, len(builder.servers))
+		for i, server := range builder.servers {
+			servers[i] = map[string]interface{}{"url": server + basePath}
+		}
+		definition["servers"] = servers
+	}
+	return json.Marshal(definition)
+}
+
+// getCommonBasePath returns the leading segments common to all the given paths, leaving at least a segment of each
+// path, and without any path parameters
+func getCommonBasePath(resourcePaths []string) string {
+	var common []string
+	for i, resourcePath := range resourcePaths {
+		segments := strings.Split(strings.TrimPrefix(resourcePath, "/"), "/")
+		segments = segments[:len(segments)-1]
+		if i == 0 {
+			common = segments
+		}
+		j := 0
+		for j < len(common) && j < len(segments) && common[j] == segments[j] && !strings.HasPrefix(common[j], "{") {
+			j++
+		}
+		common = common[:j]
+	}
+	if len(common) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(common, "/")
+}
+
+func (builder *oai3Builder) addServer(server string) {
+	for _, existingServer := range builder.servers {
+		if existingServer == server {
+			return
+		}
+	}
+	builder.servers = append(builder.servers, server)
+}
+
+// uniqueOperationId returns an operation id in camel case from the name of the request (or else the method and the
+// path), which is unique in the definition
+func (builder *oai3Builder) uniqueOperationId(name, method, resourcePath string) string {
+	if strings.TrimSpace(name) == "" {
+		name = method + " " + pathParamRegex.ReplaceAllString(resourcePath, "by $1")
+	}
+	var operationId strings.Builder
+	upperNext := false
+	for _, c := range name {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upperNext = operationId.Len() > 0
+			continue
+		}
+		if upperNext {
+			c = unicode.ToUpper(c)
+			upperNext = false
+		} else if operationId.Len() == 0 {
+			c = unicode.ToLower(c)
+		}
+		operationId.WriteRune(c)
+	}
+	id := operationId.String()
+	if id == "" {
+		id = method
+	}
+	uniqueId := id
+	for i := 2; builder.operationIds[uniqueId]; i++ {
+		uniqueId = id + strconv.Itoa(i)
+	}
+	builder.operationIds[uniqueId] = true
+	return uniqueId
+}
+
+// splitRequestURL splits the URL of a request into the server, the resource path with the path parameters as
+// templates (ie: /pets/{petId} of /pets/:petId or /pets/{{petId}}), and the query parameters
+func splitRequestURL(requestURL string) (string, string, []nameValue) {
+	var query []nameValue
+	if i := strings.Index(requestURL, "#"); i >= 0 {
+		requestURL = requestURL[:i]
+	}
+	if i := strings.Index(requestURL, "?"); i >= 0 {
+		values, _ := url.ParseQuery(requestURL[i+1:])
+		var names []string
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			query = append(query, nameValue{name: name, value: values.Get(name)})
+		}
+		requestURL = requestURL[:i]
+	}
+
+	var server string
+	if strings.HasPrefix(requestURL, "{{") {
+		// the server is given by an unresolved variable (ie: {{baseUrl}}/pets)
+		if i := strings.Index(requestURL, "}}"); i >= 0 {
+			requestURL = requestURL[i+2:]
+		}
+	} else {
+		if !strings.Contains(requestURL, "://") && !strings.HasPrefix(requestURL, "/") {
+			requestURL = "http://" + requestURL
+		}
+		if i := strings.Index(requestURL, "://"); i >= 0 {
+			hostEnd := strings.Index(requestURL[i+3:], "/")
+			if hostEnd < 0 {
+				server, requestURL = requestURL, ""
+			} else {
+				server, requestURL = requestURL[:i+3+hostEnd], requestURL[i+3+hostEnd:]
+			}
+		}
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(requestURL, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") {
+			segment = "{" + segment[1:] + "}"
+		} else if strings.HasPrefix(segment, "{{") && strings.HasSuffix(segment, "}}") {
+			segment = segment[1 : len(segment)-1]
+		}
+		segments = append(segments, segment)
+	}
+	return server, "/" + strings.Join(segments, "/"), query
+}
+
+func isOperationVerb(method string) bool {
+	for _, verb := range operationVerbs {
+		if verb == method {
+			return true
+		}
+	}
+	return false
+}
+
+// buildParameter returns a parameter with the schema inferred from the value of it, which is given as the example
+func buildParameter(name, in, value string, required bool) map[string]interface{} {
+	parameter := map[string]interface{}{
+		"name":   name,
+		"in":     in,
+		"schema": map[string]interface{}{"type": inferValueType(value)},
+	}
+	if required {
+		parameter["required"] = true
+	}
+	if value != "" && !strings.Contains(value, "{{") {
+		parameter["example"] = value
+	}
+	return parameter
+}
+
+// mergeParameters adds the given parameters which are not already in the parameters (by the name and the location)
+func mergeParameters(parameters, newParameters []interface{}) []interface{} {
+	for _, newParameter := range newParameters {
+		newParameterMap := newParameter.(map[string]interface{})
+		exists := false
+		for _, parameter := range parameters {
+			parameterMap := parameter.(map[string]interface{})
+			if parameterMap["name"] == newParameterMap["name"] && parameterMap["in"] == newParameterMap["in"] {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			parameters = append(parameters, newParameter)
+		}
+	}
+	return parameters
+}
+
+// buildRequestBody returns the request body of the operation with the schema inferred from the body of the request,
+// or nil if the request does not have a body
+func buildRequestBody(request recordedRequest) map[string]interface{} {
+	if len(request.formParams) > 0 {
+		mimeType := request.mimeType
+		if mimeType == "" {
+			mimeType = "application/x-www-form-urlencoded"
+		}
+		properties := make(map[string]interface{}, len(request.formParams))
+		for _, param := range request.formParams {
+			properties[param.name] = map[string]interface{}{"type": inferValueType(param.value)}
+		}
+		return map[string]interface{}{
+			"content": map[string]interface{}{
+				mimeType: map[string]interface{}{
+					"schema": map[string]interface{}{"type": "object", "properties": properties},
+				},
+			},
+		}
+	}
+	if strings.TrimSpace(request.body) == "" {
+		return nil
+	}
+	return map[string]interface{}{"content": buildContent(request.mimeType, request.body)}
+}
+
+// buildResponse returns the response of the operation with the schema inferred from the body of the response
+func buildResponse(response recordedResponse) map[string]interface{} {
+	description := response.description
+	if description == "" {
+		description = "Response " + strconv.Itoa(response.status)
+	}
+	oai3Response := map[string]interface{}{"description": description}
+	if strings.TrimSpace(response.body) != "" {
+		oai3Response["content"] = buildContent(response.mimeType, response.body)
+	}
+	return oai3Response
+}
+
+// buildContent returns the content of a body with the given mime type, inferring the schema of it if it is JSON
+func buildContent(mimeType, body string) map[string]interface{} {
+	if i := strings.Index(mimeType, ";"); i >= 0 {
+		mimeType = strings.TrimSpace(mimeType[:i])
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err == nil && (mimeType == "" ||
+		strings.Contains(mimeType, "json")) {
+		if mimeType == "" {
+			mimeType = "application/json"
+		}
+		return map[string]interface{}{
+			mimeType: map[string]interface{}{"schema": inferSchema(value), "example": value},
+		}
+	}
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	return map[string]interface{}{
+		mimeType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}, "example": body},
+	}
+}
+
+// inferSchema returns the schema of the given JSON value
+func inferSchema(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties := make(map[string]interface{}, len(v))
+		for name, property := range v {
+			properties[name] = inferSchema(property)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case []interface{}:
+		items := map[string]interface{}{}
+		if len(v) > 0 {
+			items = inferSchema(v[0])
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	case float64:
+		if v == float64(int64(v)) {
+			return map[string]interface{}{"type": "integer"}
+		}
+		return map[string]interface{}{"type": "number"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	case nil:
+		return map[string]interface{}{"nullable": true}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// inferValueType returns the type of a parameter from its value
+func inferValueType(value string) string {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return "integer"
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return "number"
+	}
+	if value == "true" || value == "false" {
+		return "boolean"
+	}
+	return "string"
+}
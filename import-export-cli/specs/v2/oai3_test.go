@@ -23,6 +23,7 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var petstoreProdUrls = []string{"https://petstore.swagger.io/v2", "https://petstore.swagger.io/v2/1", "https://petstore.swagger.io/v2/2"}
@@ -71,3 +72,46 @@ func Test_oai3WSO2Cors(t *testing.T) {
 	assert.ElementsMatch(t, []string{"GET", "PUT", "POST"}, cors.AccessControlAllowMethods, "should have same elements for access control")
 	assert.ElementsMatch(t, []string{"test.com", "example.com"}, cors.AccessControlAllowOrigins, "should have same elements for origins")
 }
+
+func TestOAI3Populate(t *testing.T) {
+	var def APIDTODefinition
+	jsonContent, err := utils.LoadYamlAsJson("testdata/petstore_basic.yaml")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, IsOAI3Definition(jsonContent), "should be an OpenAPI 3 definition")
+	err = OAI3Populate(&def, jsonContent)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "SwaggerPetstoreNew", def.Name, "should return correct api name")
+	assert.Equal(t, "1.0.0", def.Version, "should return correct api version")
+	assert.Equal(t, "/petstore/v1", def.Context, "should return correct context")
+	assert.ElementsMatch(t, []string{"pet", "user", "store"}, def.Tags, "should have same elements")
+	assert.True(t, def.CorsConfiguration.(*CorsConfiguration).CorsConfigurationEnabled, "should enable cors")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpLoadbalance, endpointConfig["endpoint_type"], "should use the x-wso2 endpoints")
+}
+
+func TestOAI3PopulateWithOAS31(t *testing.T) {
+	var def APIDTODefinition
+	jsonContent, err := utils.LoadYamlAsJson("testdata/petstore_oas31.yaml")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, IsOAI3Definition(jsonContent), "should be an OpenAPI 3 definition")
+	err = OAI3Populate(&def, jsonContent)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "SwaggerPetstore", def.Name, "should return correct api name")
+	assert.Equal(t, "/petstore", def.Context, "should return correct context")
+	assert.Equal(t, "X-Petstore-Auth", def.AuthorizationHeader, "should return correct auth header")
+	assert.Equal(t, []string{"https"}, def.Transport, "should return correct transports")
+	assert.Equal(t, []string{"api_key", "oauth2", "oauth_basic_auth_api_key_mandatory"}, def.SecurityScheme,
+		"should map the security schemes")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpHttp, endpointConfig["endpoint_type"], "should use the servers as the endpoints")
+	assert.Equal(t, map[string]interface{}{"url": "https://api.petstore.swagger.io/v3"},
+		endpointConfig["production_endpoints"], "should substitute the server variables")
+}
+
+func TestIsOAI3Definition(t *testing.T) {
+	jsonContent, err := utils.LoadYamlAsJson("testdata/petstore_swagger2.yaml")
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, IsOAI3Definition(jsonContent), "should not be an OpenAPI 3 definition")
+}
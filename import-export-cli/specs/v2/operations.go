@@ -0,0 +1,238 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Default auth type and throttling policy of the operations, when those are not given in the definition
+const (
+	DefaultAuthType         = "Application & Application User"
+	DefaultThrottlingPolicy = "Unlimited"
+	authTypeNone            = "None"
+)
+
+// HTTP verbs of the operations in a Swagger/OpenAPI definition, in the order of the operations of an API
+var operationVerbs = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+// populateOperationsAndScopes populates the operations of the API with an operation per path and verb of the given
+// Swagger 2.0/OpenAPI 3.x definition (in JSON), along with the scopes of the API. The auth type, the throttling
+// policy and the scopes of each operation are read from the x-auth-type, the x-throttling-tier, the
+// x-wso2-disable-security, the x-scope/x-wso2-scopes and the security of the operation.
+func populateOperationsAndScopes(def *APIDTODefinition, jsonContent []byte) error {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &definition); err != nil {
+		return err
+	}
+	paths, _ := definition["paths"].(map[string]interface{})
+	var targets []string
+	for target := range paths {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	scopes := getDefinitionScopes(definition)
+	var operations []interface{}
+	for _, target := range targets {
+		pathItem, _ := paths[target].(map[string]interface{})
+		for _, verb := range operationVerbs {
+			operationMap, ok := pathItem[verb].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			operation := Operation{
+				Target:           target,
+				Verb:             strings.ToUpper(verb),
+				AuthType:         DefaultAuthType,
+				ThrottlingPolicy: DefaultThrottlingPolicy,
+				Scopes:           getOperationScopes(operationMap),
+			}
+			if authType, _ := operationMap["x-auth-type"].(string); authType != "" {
+				operation.AuthType = authType
+			}
+			if disableSecurity, _ := operationMap["x-wso2-disable-security"].(bool); disableSecurity {
+				operation.AuthType = authTypeNone
+			}
+			if throttlingTier, _ := operationMap["x-throttling-tier"].(string); throttlingTier != "" {
+				operation.ThrottlingPolicy = throttlingTier
+			}
+			for _, scope := range operation.Scopes {
+				scopes = addScope(scopes, Scope{Name: scope, DisplayName: scope})
+			}
+			operations = append(operations, operation)
+		}
+	}
+	if len(operations) > 0 {
+		def.Operations = operations
+	}
+
+	if len(scopes) > 0 {
+		apiScopes := make([]interface{}, len(scopes))
+		for i, scope := range scopes {
+			apiScopes[i] = APIScope{Scope: scope}
+		}
+		def.Scopes = apiScopes
+	}
+	return nil
+}
+
+// getOperationScopes returns the scopes of an operation given as the x-scope, the x-wso2-scopes or the scopes of the
+// security requirements of the operation
+func getOperationScopes(operation map[string]interface{}) []string {
+	scopes := []string{}
+	addOperationScope := func(scope string) {
+		if scope == "" {
+			return
+		}
+		for _, existingScope := range scopes {
+			if existingScope == scope {
+				return
+			}
+		}
+		scopes = append(scopes, scope)
+	}
+
+	if scope, ok := operation["x-scope"].(string); ok {
+		addOperationScope(scope)
+	}
+	wso2Scopes, _ := operation["x-wso2-scopes"].([]interface{})
+	for _, wso2Scope := range wso2Scopes {
+		switch scope := wso2Scope.(type) {
+		case string:
+			addOperationScope(scope)
+		case map[string]interface{}:
+			addOperationScope(getScopeKey(scope))
+		}
+	}
+	securityRequirements, _ := operation["security"].([]interface{})
+	for _, securityRequirement := range securityRequirements {
+		requirement, _ := securityRequirement.(map[string]interface{})
+		var schemeNames []string
+		for schemeName := range requirement {
+			schemeNames = append(schemeNames, schemeName)
+		}
+		sort.Strings(schemeNames)
+		for _, schemeName := range schemeNames {
+			schemeScopes, _ := requirement[schemeName].([]interface{})
+			for _, scope := range schemeScopes {
+				scopeName, _ := scope.(string)
+				addOperationScope(scopeName)
+			}
+		}
+	}
+	return scopes
+}
+
+// getDefinitionScopes returns the scopes declared in the definition, either in the x-wso2-security extension or in
+// the OAuth2 flows of the security schemes, with the roles bound to those in the x-scopes-bindings
+func getDefinitionScopes(definition map[string]interface{}) []Scope {
+	var scopes []Scope
+	wso2Security, _ := definition["x-wso2-security"].(map[string]interface{})
+	apimSecurity, _ := wso2Security["apim"].(map[string]interface{})
+	wso2Scopes, _ := apimSecurity["x-wso2-scopes"].([]interface{})
+	for _, wso2Scope := range wso2Scopes {
+		scopeMap, _ := wso2Scope.(map[string]interface{})
+		name := getScopeKey(scopeMap)
+		if name == "" {
+			continue
+		}
+		displayName, _ := scopeMap["name"].(string)
+		description, _ := scopeMap["description"].(string)
+		roles, _ := scopeMap["roles"].(string)
+		scopes = addScope(scopes, Scope{Name: name, DisplayName: displayName, Description: description,
+			Bindings: splitRoles(roles)})
+	}
+
+	// the security schemes are in the securityDefinitions of a Swagger 2.0 definition, and in the components of an
+	// OpenAPI 3.x definition
+	securitySchemes, ok := definition["securityDefinitions"].(map[string]interface{})
+	if !ok {
+		components, _ := definition["components"].(map[string]interface{})
+		securitySchemes, _ = components["securitySchemes"].(map[string]interface{})
+	}
+	var schemeNames []string
+	for schemeName := range securitySchemes {
+		schemeNames = append(schemeNames, schemeName)
+	}
+	sort.Strings(schemeNames)
+	for _, schemeName := range schemeNames {
+		securityScheme, _ := securitySchemes[schemeName].(map[string]interface{})
+		bindings, _ := securityScheme["x-scopes-bindings"].(map[string]interface{})
+		var flowScopes []map[string]interface{}
+		if scopesMap, ok := securityScheme["scopes"].(map[string]interface{}); ok {
+			flowScopes = append(flowScopes, scopesMap)
+		}
+		flows, _ := securityScheme["flows"].(map[string]interface{})
+		for _, flow := range flows {
+			flowMap, _ := flow.(map[string]interface{})
+			if scopesMap, ok := flowMap["scopes"].(map[string]interface{}); ok {
+				flowScopes = append(flowScopes, scopesMap)
+			}
+		}
+		for _, scopesMap := range flowScopes {
+			var names []string
+			for name := range scopesMap {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				description, _ := scopesMap[name].(string)
+				roles, _ := bindings[name].(string)
+				scopes = addScope(scopes, Scope{Name: name, DisplayName: name, Description: description,
+					Bindings: splitRoles(roles)})
+			}
+		}
+	}
+	return scopes
+}
+
+// getScopeKey returns the name of a scope given in the x-wso2-scopes, which is the key of it
+func getScopeKey(scope map[string]interface{}) string {
+	if key, _ := scope["key"].(string); key != "" {
+		return key
+	}
+	name, _ := scope["name"].(string)
+	return name
+}
+
+// addScope adds the given scope to the scopes if a scope with the same name is not already there
+func addScope(scopes []Scope, scope Scope) []Scope {
+	for _, existingScope := range scopes {
+		if existingScope.Name == scope.Name {
+			return scopes
+		}
+	}
+	if scope.Bindings == nil {
+		scope.Bindings = []string{}
+	}
+	return append(scopes, scope)
+}
+
+// splitRoles returns the roles in the given comma separated list of roles
+func splitRoles(roles string) []string {
+	var roleList []string
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roleList = append(roleList, role)
+		}
+	}
+	return roleList
+}
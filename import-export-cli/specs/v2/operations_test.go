@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPopulateOperationsAndScopes(t *testing.T) {
+	var def APIDTODefinition
+	jsonContent, err := utils.LoadYamlAsJson("testdata/petstore_operations.yaml")
+	assert.Nil(t, err, "err should be nil")
+	err = populateOperationsAndScopes(&def, jsonContent)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, []interface{}{
+		Operation{Target: "/health", Verb: "GET", AuthType: "None", ThrottlingPolicy: DefaultThrottlingPolicy,
+			Scopes: []string{}},
+		Operation{Target: "/pets", Verb: "GET", AuthType: "Application", ThrottlingPolicy: "10KPerMin",
+			Scopes: []string{"read:pets"}},
+		Operation{Target: "/pets", Verb: "POST", AuthType: DefaultAuthType, ThrottlingPolicy: DefaultThrottlingPolicy,
+			Scopes: []string{"write:pets"}},
+	}, def.Operations, "should have an operation per path and verb")
+	assert.Equal(t, []interface{}{
+		APIScope{Scope: Scope{Name: "read:pets", DisplayName: "Read Pets", Description: "Read the pets",
+			Bindings: []string{"admin", "Internal/subscriber"}}},
+		APIScope{Scope: Scope{Name: "write:pets", DisplayName: "write:pets", Bindings: []string{}}},
+	}, def.Scopes, "should have the declared and the used scopes")
+}
+
+func TestPopulateOperationsAndScopesWithOAS3(t *testing.T) {
+	var def APIDTODefinition
+	jsonContent, err := utils.LoadYamlAsJson("testdata/petstore_oas31.yaml")
+	assert.Nil(t, err, "err should be nil")
+	err = OAI3Populate(&def, jsonContent)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, []interface{}{
+		Operation{Target: "/pets", Verb: "GET", AuthType: DefaultAuthType, ThrottlingPolicy: "Gold",
+			Scopes: []string{"write:pets"}},
+	}, def.Operations, "should have an operation per path and verb")
+	assert.Equal(t, []interface{}{
+		APIScope{Scope: Scope{Name: "write:pets", DisplayName: "write:pets", Description: "modify pets in your account",
+			Bindings: []string{"admin"}}},
+	}, def.Scopes, "should have the scopes of the OAuth2 flows")
+}
@@ -0,0 +1,237 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Matches the variables of a Postman collection (ie: {{baseUrl}})
+var postmanVariableRegex = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+type postmanCollection struct {
+	Info struct {
+		Name        string          `json:"name"`
+		Description json.RawMessage `json:"description"`
+		Schema      string          `json:"schema"`
+	} `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+// postmanItem is either a folder of items or a request, along with the saved responses of it
+type postmanItem struct {
+	Name     string            `json:"name"`
+	Item     []postmanItem     `json:"item"`
+	Request  *postmanRequest   `json:"request"`
+	Response []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	URL    json.RawMessage   `json:"url"`
+	Header []postmanKeyValue `json:"header"`
+	Body   *struct {
+		Mode       string            `json:"mode"`
+		Raw        string            `json:"raw"`
+		URLEncoded []postmanKeyValue `json:"urlencoded"`
+		FormData   []postmanKeyValue `json:"formdata"`
+		Options    struct {
+			Raw struct {
+				Language string `json:"language"`
+			} `json:"raw"`
+		} `json:"options"`
+	} `json:"body"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Query    []postmanKeyValue `json:"query"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+type postmanResponse struct {
+	Name   string            `json:"name"`
+	Status string            `json:"status"`
+	Code   int               `json:"code"`
+	Header []postmanKeyValue `json:"header"`
+	Body   string            `json:"body"`
+}
+
+type postmanKeyValue struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Disabled bool        `json:"disabled"`
+}
+
+// Languages of the raw bodies of a Postman collection and the respective mime types
+var postmanRawLanguages = map[string]string{
+	"json":       "application/json",
+	"xml":        "application/xml",
+	"html":       "text/html",
+	"text":       "text/plain",
+	"javascript": "application/javascript",
+}
+
+// PostmanToOAI3 infers an OpenAPI 3.0 definition (in JSON) from the given Postman collection (v2.0/v2.1): the paths
+// and the verbs from the requests (the path variables as the path parameters), the query and the header parameters,
+// the schemas of the request and the response bodies from the examples, and the servers from the hosts of the
+// requests. The title is taken from the name of the collection, or else the given default title is used.
+func PostmanToOAI3(collectionContent []byte, defaultTitle string) ([]byte, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(collectionContent, &collection); err != nil {
+		return nil, err
+	}
+	if collection.Item == nil {
+		return nil, errors.New("not a valid Postman collection, no items found")
+	}
+
+	variables := make(map[string]string)
+	for _, variable := range collection.Variable {
+		variables[variable.Key] = postmanValueToString(variable.Value)
+	}
+	builder := newOAI3Builder()
+	addPostmanItems(builder, collection.Item, variables)
+	if len(builder.paths) == 0 {
+		return nil, errors.New("no requests found in the Postman collection")
+	}
+
+	title := strings.TrimSpace(collection.Info.Name)
+	if title == "" {
+		title = defaultTitle
+	}
+	var description string
+	if err := json.Unmarshal(collection.Info.Description, &description); err != nil {
+		var descriptionObj struct {
+			Content string `json:"content"`
+		}
+		_ = json.Unmarshal(collection.Info.Description, &descriptionObj)
+		description = descriptionObj.Content
+	}
+	return builder.build(title, description)
+}
+
+// addPostmanItems adds the requests of the given items to the builder, recursively traversing the folders
+func addPostmanItems(builder *oai3Builder, items []postmanItem, variables map[string]string) {
+	for _, item := range items {
+		if item.Request == nil {
+			addPostmanItems(builder, item.Item, variables)
+			continue
+		}
+		request := recordedRequest{
+			name:       item.Name,
+			method:     item.Request.Method,
+			pathValues: make(map[string]string),
+		}
+		var rawURL string
+		if err := json.Unmarshal(item.Request.URL, &rawURL); err != nil {
+			var url postmanURL
+			_ = json.Unmarshal(item.Request.URL, &url)
+			rawURL = url.Raw
+			if i := strings.Index(rawURL, "?"); i >= 0 && url.Query != nil {
+				// the query parameters are taken from the query list, which has the disabled ones as well
+				rawURL = rawURL[:i]
+			}
+			request.query = enabledPostmanKeyValues(url.Query, variables)
+			for _, variable := range url.Variable {
+				request.pathValues[variable.Key] = resolvePostmanVariables(postmanValueToString(variable.Value),
+					variables)
+			}
+		}
+		request.url = resolvePostmanVariables(rawURL, variables)
+		request.headers = enabledPostmanKeyValues(item.Request.Header, variables)
+		for _, header := range request.headers {
+			if strings.EqualFold(header.name, "Content-Type") {
+				request.mimeType = header.value
+			}
+		}
+
+		if body := item.Request.Body; body != nil {
+			switch body.Mode {
+			case "raw":
+				request.body = resolvePostmanVariables(body.Raw, variables)
+				if mimeType, ok := postmanRawLanguages[body.Options.Raw.Language]; ok && request.mimeType == "" {
+					request.mimeType = mimeType
+				}
+			case "urlencoded":
+				request.formParams = enabledPostmanKeyValues(body.URLEncoded, variables)
+				request.mimeType = "application/x-www-form-urlencoded"
+			case "formdata":
+				request.formParams = enabledPostmanKeyValues(body.FormData, variables)
+				request.mimeType = "multipart/form-data"
+			}
+		}
+
+		for _, response := range item.Response {
+			recorded := recordedResponse{
+				status:      response.Code,
+				description: response.Name,
+				body:        response.Body,
+			}
+			for _, header := range response.Header {
+				if strings.EqualFold(header.Key, "Content-Type") {
+					recorded.mimeType = postmanValueToString(header.Value)
+				}
+			}
+			request.responses = append(request.responses, recorded)
+		}
+		builder.addRequest(request)
+	}
+}
+
+// enabledPostmanKeyValues returns the key-values which are not disabled, resolving the variables of the values
+func enabledPostmanKeyValues(keyValues []postmanKeyValue, variables map[string]string) []nameValue {
+	var enabled []nameValue
+	for _, keyValue := range keyValues {
+		if keyValue.Disabled || keyValue.Key == "" {
+			continue
+		}
+		enabled = append(enabled, nameValue{
+			name:  keyValue.Key,
+			value: resolvePostmanVariables(postmanValueToString(keyValue.Value), variables),
+		})
+	}
+	return enabled
+}
+
+// resolvePostmanVariables replaces the variables of the collection in the given value. The variables which are not
+// defined in the collection (ie: environment variables) are kept as they are.
+func resolvePostmanVariables(value string, variables map[string]string) string {
+	return postmanVariableRegex.ReplaceAllStringFunc(value, func(variable string) string {
+		name := postmanVariableRegex.FindStringSubmatch(variable)[1]
+		if resolved, ok := variables[name]; ok && resolved != "" {
+			return resolved
+		}
+		return "{{" + name + "}}"
+	})
+}
+
+func postmanValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		content, _ := json.Marshal(v)
+		return string(content)
+	}
+}
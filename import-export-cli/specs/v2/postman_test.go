@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostmanToOAI3(t *testing.T) {
+	collection, err := ioutil.ReadFile("testdata/orders.postman_collection.json")
+	assert.Nil(t, err, "err should be nil")
+	content, err := PostmanToOAI3(collection, "DefaultAPI")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, IsOAI3Definition(content), "should infer an OpenAPI 3 definition")
+
+	var definition map[string]interface{}
+	assert.Nil(t, json.Unmarshal(content, &definition), "err should be nil")
+	assert.Equal(t, "OrdersAPI", definition["info"].(map[string]interface{})["title"],
+		"should use the name of the collection as the title")
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://orders.example.com/api/v1"}},
+		definition["servers"], "should use the resolved base URL as the server")
+
+	paths := definition["paths"].(map[string]interface{})
+	assert.Len(t, paths, 2, "should merge the requests to the same resources")
+	listOrders := paths["/orders"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, "listOrders", listOrders["operationId"], "should use the name of the request as the operation id")
+	assert.Len(t, listOrders["parameters"], 3, "should skip the disabled query parameters and the standard headers")
+	response := listOrders["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	responseContent := response["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	responseSchema := responseContent["schema"].(map[string]interface{})
+	assert.Equal(t, "array", responseSchema["type"], "should infer the schema of the response")
+	assert.Equal(t, map[string]interface{}{"type": "number"},
+		responseSchema["items"].(map[string]interface{})["properties"].(map[string]interface{})["price"],
+		"should infer the types of the properties")
+
+	createOrder := paths["/orders"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Contains(t, createOrder["requestBody"].(map[string]interface{})["content"], "application/json",
+		"should infer the request body")
+	order := paths["/orders/{orderId}"].(map[string]interface{})
+	assert.Contains(t, order, "get", "should convert the path variables to path parameters")
+	assert.Contains(t, order, "delete", "should convert the variables in the path to path parameters")
+
+	def := APIDTODefinition{Version: "1.0.0"}
+	err = OAI3Populate(&def, content)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "OrdersAPI", def.Name, "should return correct name")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"url": "https://orders.example.com/api/v1"},
+		endpointConfig["production_endpoints"], "should use the server as the production endpoint")
+	assert.Len(t, def.Operations, 4, "should populate the operations of the requests")
+}
+
+func TestPostmanToOAI3WithInvalidCollection(t *testing.T) {
+	_, err := PostmanToOAI3([]byte(`{"info": {"name": "Empty"}}`), "DefaultAPI")
+	assert.NotNil(t, err, "err should not be nil for a collection without items")
+	_, err = PostmanToOAI3([]byte(`{"info": {"name": "Empty"}, "item": []}`), "DefaultAPI")
+	assert.NotNil(t, err, "err should not be nil for a collection without requests")
+}
+
+func TestSplitRequestURL(t *testing.T) {
+	server, resourcePath, query := splitRequestURL("http://localhost:8080/pets/:petId/{{tag}}?limit=5#top")
+	assert.Equal(t, "http://localhost:8080", server, "should return the server")
+	assert.Equal(t, "/pets/{petId}/{tag}", resourcePath, "should return the path with the path parameters")
+	assert.Equal(t, []nameValue{{name: "limit", value: "5"}}, query, "should return the query parameters")
+
+	server, resourcePath, _ = splitRequestURL("{{baseUrl}}/pets")
+	assert.Equal(t, "", server, "should not return an unresolved server")
+	assert.Equal(t, "/pets", resourcePath, "should return the path")
+}
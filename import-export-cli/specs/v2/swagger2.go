@@ -86,6 +86,34 @@ func swagger2XWO2BasePath(document *loads.Document) (string, bool) {
 	return "", false
 }
 
+func swagger2XWSO2AuthHeader(document *loads.Document) (string, bool) {
+	if v, ok := document.Spec().Extensions["x-wso2-auth-header"]; ok {
+		str, ok := v.(string)
+		return str, ok
+	}
+	return "", false
+}
+
+func swagger2XThrottlingTier(document *loads.Document) (string, bool) {
+	if v, ok := document.Spec().Extensions["x-throttling-tier"]; ok {
+		str, ok := v.(string)
+		return str, ok
+	}
+	return "", false
+}
+
+func swagger2XWSO2Transports(document *loads.Document) ([]string, bool, error) {
+	if v, ok := document.Spec().Extensions["x-wso2-transports"]; ok {
+		var transports []string
+		err := mapstructure.Decode(v, &transports)
+		if err != nil {
+			return nil, true, err
+		}
+		return transports, true, nil
+	}
+	return nil, false, nil
+}
+
 func swagger2XWSO2Cors(document *loads.Document) (*CorsConfiguration, bool, error) {
 	if v, ok := document.Spec().Extensions["x-wso2-cors"]; ok {
 		var cors CorsConfiguration
@@ -261,24 +289,11 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 
 	// override basepath if wso2 extension provided
 	if basepath, ok := swagger2XWO2BasePath(document); ok {
-		def.Context = path.Clean(basepath)
-		if !strings.Contains(basepath, "{version}") {
-			if strings.Contains(basepath, def.Version) {
-				def.Context = path.Clean(strings.Replace(basepath, def.Version, "",
-					strings.LastIndex(basepath, def.Version)))
-			} else {
-				def.Context = path.Clean(basepath)
-			}
-			def.IsDefaultVersion = true
-		} else {
-			def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
-		}
+		populateContextFromWSO2BasePath(def, basepath)
 	}
 
 	// trim spaces if available
-	def.Name = strings.ReplaceAll(def.Name, " ", "")
-	def.Version = strings.ReplaceAll(def.Version, " ", "")
-	def.Context = strings.ReplaceAll(def.Context, " ", "")
+	trimNameVersionAndContext(def)
 
 	cors, ok, err := swagger2XWSO2Cors(document)
 	if err != nil && ok {
@@ -287,6 +302,29 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 	if ok {
 		def.CorsConfiguration = cors
 	}
+	if authHeader, ok := swagger2XWSO2AuthHeader(document); ok {
+		def.AuthorizationHeader = authHeader
+	}
+	transports, ok, err := swagger2XWSO2Transports(document)
+	if err != nil && ok {
+		return err
+	}
+	if ok {
+		def.Transport = transports
+	}
+	if throttlingTier, ok := swagger2XThrottlingTier(document); ok {
+		def.APIThrottlingPolicy = throttlingTier
+	}
+	securitySchemes, ok, err := wso2SecuritySchemes(document.Raw())
+	if err != nil && ok {
+		return err
+	}
+	if ok {
+		def.SecurityScheme = securitySchemes
+	}
+	if err := populateOperationsAndScopes(def, document.Raw()); err != nil {
+		return err
+	}
 	prodEp, foundProdEp, err := swagger2XWSO2ProductionEndpoints(document)
 	if err != nil && foundProdEp {
 		return err
@@ -296,17 +334,48 @@ func Swagger2Populate(def *APIDTODefinition, document *loads.Document) error {
 		return err
 	}
 	if foundProdEp || foundSandboxEp {
-		ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
-		if err != nil {
-			return err
-		}
-		var endpointConfig map[string]interface{}
-		err = json.Unmarshal([]byte(ep), &endpointConfig)
-		if err != nil {
-			return err
+		return setEndpointConfig(def, prodEp, sandboxEp)
+	}
+	return nil
+}
+
+// populateContextFromWSO2BasePath sets the context of the API using the x-wso2-basePath extension of the definition
+func populateContextFromWSO2BasePath(def *APIDTODefinition, basepath string) {
+	def.Context = path.Clean(basepath)
+	if !strings.Contains(basepath, "{version}") {
+		if strings.Contains(basepath, def.Version) {
+			def.Context = path.Clean(strings.Replace(basepath, def.Version, "",
+				strings.LastIndex(basepath, def.Version)))
+		} else {
+			def.Context = path.Clean(basepath)
 		}
-		def.EndpointConfig = &endpointConfig
+		def.IsDefaultVersion = true
+	} else {
+		// API Manager appends the version to the context, hence the {version} at the end is not kept in the context
+		def.Context = path.Clean(strings.ReplaceAll(strings.TrimSuffix(basepath, "/{version}"), "{version}",
+			def.Version))
 	}
+}
+
+// trimNameVersionAndContext removes the spaces in the name, the version and the context of the API
+func trimNameVersionAndContext(def *APIDTODefinition) {
+	def.Name = strings.ReplaceAll(def.Name, " ", "")
+	def.Version = strings.ReplaceAll(def.Version, " ", "")
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+}
+
+// setEndpointConfig builds the endpointConfig of the API from the given production and sandbox endpoints
+func setEndpointConfig(def *APIDTODefinition, production, sandbox *Endpoints) error {
+	ep, err := BuildAPIMEndpoints(production, sandbox)
+	if err != nil {
+		return err
+	}
+	var endpointConfig map[string]interface{}
+	err = json.Unmarshal([]byte(ep), &endpointConfig)
+	if err != nil {
+		return err
+	}
+	def.EndpointConfig = &endpointConfig
 	return nil
 }
 
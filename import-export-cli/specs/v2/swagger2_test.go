@@ -89,6 +89,6 @@ func TestSwagger2PopulateWithBasePath(t *testing.T) {
 	err1 = Swagger2Populate(&def2, doc2)
 	assert.Nil(t, err2, "err should be nil")
 
-	assert.Equal(t, "/petstore/v1/1.0.0", def2.Context)
+	assert.Equal(t, "/petstore/v1", def2.Context)
 	assert.Equal(t, false, def2.IsDefaultVersion)
 }
@@ -0,0 +1,142 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// API type of a SOAP (pass-through) API, which is defined using a WSDL
+const APITypeSOAP = "SOAP"
+
+// Resource of a SOAP API, to which the SOAP requests are posted
+const (
+	soapResourceTarget = "/*"
+	soapResourceVerb   = "POST"
+)
+
+// WSDLPopulate populates the API using the given WSDL 1.1/2.0 document: the name from the service (or the
+// definitions), the endpoints from the address of the first port of the service, and the resource to which the SOAP
+// requests are passed through
+func WSDLPopulate(def *APIDTODefinition, wsdl []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(wsdl))
+	var definitionsName, serviceName, address string
+	isWSDL := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch element.Name.Local {
+		case "definitions", "description":
+			isWSDL = true
+			definitionsName = getXMLAttr(element, "name")
+		case "service":
+			if serviceName == "" {
+				serviceName = getXMLAttr(element, "name")
+			}
+		case "address":
+			// the address of a port of WSDL 1.1 (ie: soap:address)
+			if address == "" {
+				address = getXMLAttr(element, "location")
+			}
+		case "endpoint":
+			// the endpoint of a service of WSDL 2.0
+			if address == "" {
+				address = getXMLAttr(element, "address")
+			}
+		}
+	}
+	if !isWSDL {
+		return errors.New("the document is not a WSDL")
+	}
+
+	def.Name = serviceName
+	if def.Name == "" {
+		def.Name = definitionsName
+	}
+	def.Provider = "admin"
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	trimNameVersionAndContext(def)
+	def.Type = APITypeSOAP
+	if address != "" {
+		endpoints := &Endpoints{Urls: []string{address}}
+		if err := setEndpointConfig(def, endpoints, endpoints); err != nil {
+			return err
+		}
+	}
+	def.Operations = []interface{}{Operation{
+		Target:           soapResourceTarget,
+		Verb:             soapResourceVerb,
+		AuthType:         DefaultAuthType,
+		ThrottlingPolicy: DefaultThrottlingPolicy,
+		Scopes:           []string{},
+	}}
+	return nil
+}
+
+// WSDLSwagger returns the Swagger definition of a SOAP API, which has the resource to which the SOAP requests are
+// passed through
+func WSDLSwagger(def *APIDTODefinition) map[string]interface{} {
+	return map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   def.Name,
+			"version": def.Version,
+		},
+		"paths": map[string]interface{}{
+			soapResourceTarget: map[string]interface{}{
+				strings.ToLower(soapResourceVerb): map[string]interface{}{
+					"parameters": []interface{}{map[string]interface{}{
+						"in":       "body",
+						"name":     "SOAP Request",
+						"required": true,
+						"schema":   map[string]interface{}{"type": "string"},
+					}},
+					"responses": map[string]interface{}{
+						"default": map[string]interface{}{"description": ""},
+					},
+					"x-auth-type":       DefaultAuthType,
+					"x-throttling-tier": DefaultThrottlingPolicy,
+				},
+			},
+		},
+	}
+}
+
+// getXMLAttr returns the value of the attribute of the element with the given (local) name
+func getXMLAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
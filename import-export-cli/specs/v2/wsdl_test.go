@@ -0,0 +1,52 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWSDLPopulate(t *testing.T) {
+	def := APIDTODefinition{Version: "1.0.0"}
+	wsdl, err := ioutil.ReadFile("testdata/phoneverify.wsdl")
+	assert.Nil(t, err, "err should be nil")
+	err = WSDLPopulate(&def, wsdl)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "PhoneVerify", def.Name, "should return the name of the service")
+	assert.Equal(t, "/PhoneVerify", def.Context, "should return correct context")
+	assert.Equal(t, APITypeSOAP, def.Type, "should return correct api type")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"url": "http://ws.cdyne.com/phoneverify/phoneverify.asmx"},
+		endpointConfig["production_endpoints"], "should use the address of the service")
+	assert.Equal(t, []interface{}{Operation{Target: "/*", Verb: "POST", AuthType: DefaultAuthType,
+		ThrottlingPolicy: DefaultThrottlingPolicy, Scopes: []string{}}}, def.Operations,
+		"should have the pass-through resource")
+
+	swagger := WSDLSwagger(&def)
+	assert.Contains(t, swagger["paths"], "/*", "the swagger should have the pass-through resource")
+}
+
+func TestWSDLPopulateWithInvalidWSDL(t *testing.T) {
+	var def APIDTODefinition
+	err := WSDLPopulate(&def, []byte("<note><to>Tove</to></note>"))
+	assert.NotNil(t, err, "err should not be nil for a document which is not a WSDL")
+}
@@ -0,0 +1,305 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Name of the security scheme of the definition to which the scopes of the API are added, as in the definitions
+// exported from API Manager
+const defaultSecuritySchemeName = "default"
+
+// Authorization URL of the OAuth2 implicit flow of the default security scheme, which is not used by API Manager but
+// is required by the specification
+const defaultAuthorizationURL = "https://test.com"
+
+// Security schemes of API Manager which make the application security or the mutual SSL of an API mandatory
+const (
+	securitySchemeApplicationMandatory = "oauth_basic_auth_api_key_mandatory"
+	securitySchemeMutualSSL            = "mutualssl"
+	securitySchemeMutualSSLMandatory   = "mutualssl_mandatory"
+)
+
+// wso2ApplicationSecurity is the x-wso2-application-security extension of the definitions exported from API Manager,
+// having the application security schemes of the API
+type wso2ApplicationSecurity struct {
+	SecurityTypes []string `json:"security-types"`
+	Optional      bool     `json:"optional"`
+}
+
+// AddWSO2Extensions adds the details of the given API to the given Swagger 2.0/OpenAPI 3.x definition as the x-wso2
+// extensions read by init: the context as the x-wso2-basePath, the endpoints, the CORS configuration, the transports,
+// the authorization header, the security schemes and the throttling policy of the API, and the auth type, the
+// throttling policy and the scopes of each operation. The scopes of the API are added to the default OAuth2 security scheme, with the roles
+// bound to those as the x-scopes-bindings. The operations which are not in the definition are added to it.
+func AddWSO2Extensions(definition map[string]interface{}, def *APIDTODefinition) error {
+	definition["x-wso2-basePath"] = getWSO2BasePath(def)
+	if def.AuthorizationHeader != "" {
+		definition["x-wso2-auth-header"] = def.AuthorizationHeader
+	}
+	if len(def.Transport) > 0 {
+		definition["x-wso2-transports"] = def.Transport
+	}
+	addSecuritySchemeExtensions(definition, def.SecurityScheme)
+	if def.APIThrottlingPolicy != "" {
+		definition["x-throttling-tier"] = def.APIThrottlingPolicy
+	}
+	if def.CorsConfiguration != nil {
+		definition["x-wso2-cors"] = def.CorsConfiguration
+	}
+
+	var endpointConfig map[string]interface{}
+	if err := convertAPIValue(def.EndpointConfig, &endpointConfig); err != nil {
+		return err
+	}
+	for endpointType, endpoints := range getWSO2Endpoints(endpointConfig) {
+		definition["x-wso2-"+endpointType+"-endpoints"] = endpoints
+	}
+
+	var operations []Operation
+	if err := convertAPIValue(def.Operations, &operations); err != nil {
+		return err
+	}
+	paths, _ := definition["paths"].(map[string]interface{})
+	if paths == nil {
+		paths = make(map[string]interface{})
+		definition["paths"] = paths
+	}
+	for _, operation := range operations {
+		pathItem, _ := paths[operation.Target].(map[string]interface{})
+		if pathItem == nil {
+			pathItem = make(map[string]interface{})
+			paths[operation.Target] = pathItem
+		}
+		verb := strings.ToLower(operation.Verb)
+		operationMap, _ := pathItem[verb].(map[string]interface{})
+		if operationMap == nil {
+			operationMap = map[string]interface{}{
+				"responses": map[string]interface{}{"200": map[string]interface{}{"description": "OK"}},
+			}
+			pathItem[verb] = operationMap
+		}
+		addOperationExtensions(operationMap, operation)
+	}
+
+	var scopes []APIScope
+	if err := convertAPIValue(def.Scopes, &scopes); err != nil {
+		return err
+	}
+	addDefaultSecurityScheme(definition, scopes)
+	return nil
+}
+
+// getWSO2BasePath returns the context of the API as the x-wso2-basePath. The base path of the default version of an
+// API has the version as in the definitions exported from API Manager, while the base path of the other versions has
+// the {version} template, as init reads a base path without the template as of the default version.
+func getWSO2BasePath(def *APIDTODefinition) string {
+	if !def.IsDefaultVersion {
+		if strings.Contains(def.Context, "{version}") {
+			return def.Context
+		}
+		return strings.TrimSuffix(def.Context, "/") + "/{version}"
+	}
+	if strings.Contains(def.Context, "{version}") {
+		return strings.ReplaceAll(def.Context, "{version}", def.Version)
+	}
+	return strings.TrimSuffix(def.Context, "/") + "/" + def.Version
+}
+
+// addSecuritySchemeExtensions adds the security schemes of the API as the x-wso2-application-security and the
+// x-wso2-mutual-ssl extensions, as the default OAuth2 security scheme of the scopes does not tell those
+func addSecuritySchemeExtensions(definition map[string]interface{}, securitySchemes []string) {
+	applicationSecurity := wso2ApplicationSecurity{SecurityTypes: []string{}, Optional: true}
+	mutualSSL := ""
+	for _, securityScheme := range securitySchemes {
+		switch securityScheme {
+		case securitySchemeApplicationMandatory:
+			applicationSecurity.Optional = false
+		case securitySchemeMutualSSL:
+			if mutualSSL == "" {
+				mutualSSL = "optional"
+			}
+		case securitySchemeMutualSSLMandatory:
+			mutualSSL = "mandatory"
+		default:
+			applicationSecurity.SecurityTypes = append(applicationSecurity.SecurityTypes, securityScheme)
+		}
+	}
+	definition["x-wso2-application-security"] = applicationSecurity
+	if mutualSSL != "" {
+		definition["x-wso2-mutual-ssl"] = mutualSSL
+	} else {
+		delete(definition, "x-wso2-mutual-ssl")
+	}
+}
+
+// wso2SecuritySchemes returns the security schemes of the API given as the x-wso2-application-security and the
+// x-wso2-mutual-ssl extensions of the given definition (in JSON)
+// Returns bool, whether the definition has the extensions
+func wso2SecuritySchemes(jsonContent []byte) ([]string, bool, error) {
+	var extensions struct {
+		ApplicationSecurity *wso2ApplicationSecurity `json:"x-wso2-application-security"`
+		MutualSSL           string                   `json:"x-wso2-mutual-ssl"`
+	}
+	if err := json.Unmarshal(jsonContent, &extensions); err != nil {
+		return nil, true, err
+	}
+	if extensions.ApplicationSecurity == nil && extensions.MutualSSL == "" {
+		return nil, false, nil
+	}
+	securitySchemes := []string{}
+	if extensions.ApplicationSecurity != nil {
+		securitySchemes = append(securitySchemes, extensions.ApplicationSecurity.SecurityTypes...)
+		if !extensions.ApplicationSecurity.Optional && len(extensions.ApplicationSecurity.SecurityTypes) > 0 {
+			securitySchemes = append(securitySchemes, securitySchemeApplicationMandatory)
+		}
+	}
+	switch strings.ToLower(extensions.MutualSSL) {
+	case "optional":
+		securitySchemes = append(securitySchemes, securitySchemeMutualSSL)
+	case "mandatory":
+		securitySchemes = append(securitySchemes, securitySchemeMutualSSL, securitySchemeMutualSSLMandatory)
+	}
+	return securitySchemes, true, nil
+}
+
+// getWSO2Endpoints returns the production and the sandbox endpoints (keyed by "production" and "sandbox") of the
+// given HTTP, load balanced or failover endpoint configuration, as the x-wso2 endpoints extensions
+func getWSO2Endpoints(endpointConfig map[string]interface{}) map[string]map[string]interface{} {
+	endpointType, _ := endpointConfig["endpoint_type"].(string)
+	if endpointType == "address" {
+		endpointType = EpHttp
+	}
+	if endpointType != EpHttp && endpointType != EpLoadbalance && endpointType != EpFailover {
+		return nil
+	}
+	wso2Endpoints := make(map[string]map[string]interface{})
+	for _, environment := range []string{"production", "sandbox"} {
+		urls := getEndpointURLs(endpointConfig[environment+"_endpoints"])
+		if endpointType == EpFailover {
+			urls = append(urls, getEndpointURLs(endpointConfig[environment+"_failovers"])...)
+		}
+		if len(urls) > 0 {
+			wso2Endpoints[environment] = map[string]interface{}{"urls": urls, "type": endpointType}
+		}
+	}
+	return wso2Endpoints
+}
+
+// getEndpointURLs returns the URLs of the given endpoint or the list of endpoints of an endpoint configuration
+func getEndpointURLs(endpoints interface{}) []string {
+	var urls []string
+	switch endpoint := endpoints.(type) {
+	case map[string]interface{}:
+		if url, _ := endpoint["url"].(string); url != "" {
+			urls = append(urls, url)
+		}
+	case []interface{}:
+		for _, item := range endpoint {
+			urls = append(urls, getEndpointURLs(item)...)
+		}
+	}
+	return urls
+}
+
+// addOperationExtensions adds the auth type, the throttling policy and the scopes of the given operation to the
+// operation of the definition
+func addOperationExtensions(operationMap map[string]interface{}, operation Operation) {
+	if operation.AuthType != "" {
+		operationMap["x-auth-type"] = operation.AuthType
+	}
+	if operation.ThrottlingPolicy != "" {
+		operationMap["x-throttling-tier"] = operation.ThrottlingPolicy
+	}
+	scopes := operation.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	operationMap["security"] = []interface{}{map[string]interface{}{defaultSecuritySchemeName: scopes}}
+	if len(scopes) > 0 {
+		operationMap["x-scope"] = scopes[0]
+	} else {
+		delete(operationMap, "x-scope")
+	}
+}
+
+// addDefaultSecurityScheme adds the default OAuth2 security scheme, which is referred by the security of the
+// operations, with the given scopes to the securityDefinitions of a Swagger 2.0 definition or to the components of an
+// OpenAPI 3.x definition
+func addDefaultSecurityScheme(definition map[string]interface{}, apiScopes []APIScope) {
+	scopes := make(map[string]interface{}, len(apiScopes))
+	bindings := make(map[string]interface{}, len(apiScopes))
+	for _, apiScope := range apiScopes {
+		scopes[apiScope.Scope.Name] = apiScope.Scope.Description
+		if len(apiScope.Scope.Bindings) > 0 {
+			bindings[apiScope.Scope.Name] = strings.Join(apiScope.Scope.Bindings, ",")
+		}
+	}
+
+	var securityScheme map[string]interface{}
+	if _, isSwagger2 := definition["swagger"]; isSwagger2 {
+		securityScheme = map[string]interface{}{
+			"type":             "oauth2",
+			"authorizationUrl": defaultAuthorizationURL,
+			"flow":             "implicit",
+			"scopes":           scopes,
+		}
+		securityDefinitions, _ := definition["securityDefinitions"].(map[string]interface{})
+		if securityDefinitions == nil {
+			securityDefinitions = make(map[string]interface{})
+			definition["securityDefinitions"] = securityDefinitions
+		}
+		securityDefinitions[defaultSecuritySchemeName] = securityScheme
+	} else {
+		securityScheme = map[string]interface{}{
+			"type": "oauth2",
+			"flows": map[string]interface{}{
+				"implicit": map[string]interface{}{"authorizationUrl": defaultAuthorizationURL, "scopes": scopes},
+			},
+		}
+		components, _ := definition["components"].(map[string]interface{})
+		if components == nil {
+			components = make(map[string]interface{})
+			definition["components"] = components
+		}
+		securitySchemes, _ := components["securitySchemes"].(map[string]interface{})
+		if securitySchemes == nil {
+			securitySchemes = make(map[string]interface{})
+			components["securitySchemes"] = securitySchemes
+		}
+		securitySchemes[defaultSecuritySchemeName] = securityScheme
+	}
+	if len(bindings) > 0 {
+		securityScheme["x-scopes-bindings"] = bindings
+	}
+}
+
+// convertAPIValue converts the given value of the api.yaml (ie: a map of the endpoint configuration or a list of
+// operations) into the given target through JSON
+func convertAPIValue(value interface{}, target interface{}) error {
+	if value == nil {
+		return nil
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, target)
+}
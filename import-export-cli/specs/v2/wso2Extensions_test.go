@@ -0,0 +1,169 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/stretchr/testify/assert"
+)
+
+func getAPIToAnnotate() *APIDTODefinition {
+	return &APIDTODefinition{
+		Name:                "PizzaShackAPI",
+		Version:             "1.0.0",
+		Context:             "/pizzashack",
+		IsDefaultVersion:    true,
+		Transport:           []string{"https"},
+		SecurityScheme:      []string{"oauth2", "api_key", "oauth_basic_auth_api_key_mandatory"},
+		AuthorizationHeader: "X-Authorization",
+		APIThrottlingPolicy: "Gold",
+		CorsConfiguration: map[string]interface{}{
+			"corsConfigurationEnabled":  true,
+			"accessControlAllowOrigins": []interface{}{"https://pizzashack.com"},
+		},
+		EndpointConfig: map[string]interface{}{
+			"endpoint_type":        "failover",
+			"production_endpoints": map[string]interface{}{"url": "https://prod1.pizzashack.com"},
+			"production_failovers": []interface{}{map[string]interface{}{"url": "https://prod2.pizzashack.com"}},
+		},
+		Operations: []interface{}{
+			map[string]interface{}{"target": "/menu", "verb": "GET", "authType": "None",
+				"throttlingPolicy": "Unlimited", "scopes": []interface{}{}},
+			map[string]interface{}{"target": "/order", "verb": "POST", "authType": DefaultAuthType,
+				"throttlingPolicy": "10KPerMin", "scopes": []interface{}{"order:write"}},
+		},
+		Scopes: []interface{}{
+			map[string]interface{}{"scope": map[string]interface{}{"name": "order:write", "description": "Place orders",
+				"bindings": []interface{}{"admin", "customer"}}, "shared": false},
+		},
+	}
+}
+
+func TestAddWSO2ExtensionsRoundTrip(t *testing.T) {
+	definition := map[string]interface{}{
+		"openapi": "3.0.1",
+		"info":    map[string]interface{}{"title": "PizzaShackAPI", "version": "1.0.0"},
+		"paths": map[string]interface{}{
+			"/menu": map[string]interface{}{
+				"get": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{
+					"description": "OK"}}},
+			},
+		},
+	}
+	err := AddWSO2Extensions(definition, getAPIToAnnotate())
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "/pizzashack/1.0.0", definition["x-wso2-basePath"], "should add the context with the version")
+	assert.Equal(t, "Gold", definition["x-throttling-tier"], "should add the throttling policy of the API")
+	assert.Contains(t, definition["paths"], "/order", "should add the operations missing in the definition")
+
+	content, err := json.Marshal(definition)
+	assert.Nil(t, err, "err should be nil")
+	def := APIDTODefinition{}
+	err = OAI3Populate(&def, content)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "/pizzashack", def.Context, "should return the same context")
+	assert.True(t, def.IsDefaultVersion, "should return the same default version")
+	assert.Equal(t, "Gold", def.APIThrottlingPolicy, "should return the same throttling policy")
+	assert.Equal(t, []string{"oauth2", "api_key", "oauth_basic_auth_api_key_mandatory"}, def.SecurityScheme,
+		"should return the same security schemes")
+	assert.Equal(t, []string{"https"}, def.Transport, "should return the same transports")
+	assert.Equal(t, "X-Authorization", def.AuthorizationHeader, "should return the same authorization header")
+	assert.Equal(t, []string{"https://pizzashack.com"},
+		def.CorsConfiguration.(*CorsConfiguration).AccessControlAllowOrigins, "should return the same CORS origins")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpFailover, endpointConfig["endpoint_type"], "should return the same endpoint type")
+	assert.Equal(t, map[string]interface{}{"url": "https://prod1.pizzashack.com"},
+		endpointConfig["production_endpoints"], "should return the same production endpoint")
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://prod2.pizzashack.com"}},
+		endpointConfig["production_failovers"], "should return the same failover endpoints")
+	assert.Equal(t, []interface{}{
+		Operation{Target: "/menu", Verb: "GET", AuthType: "None", ThrottlingPolicy: "Unlimited", Scopes: []string{}},
+		Operation{Target: "/order", Verb: "POST", AuthType: DefaultAuthType, ThrottlingPolicy: "10KPerMin",
+			Scopes: []string{"order:write"}},
+	}, def.Operations, "should return the same operations")
+	assert.Equal(t, []interface{}{APIScope{Scope: Scope{Name: "order:write", DisplayName: "order:write",
+		Description: "Place orders", Bindings: []string{"admin", "customer"}}}}, def.Scopes,
+		"should return the same scopes")
+}
+
+func TestAddWSO2ExtensionsToSwagger2(t *testing.T) {
+	definition := map[string]interface{}{
+		"swagger": "2.0",
+		"info":    map[string]interface{}{"title": "PizzaShackAPI", "version": "1.0.0"},
+	}
+	api := getAPIToAnnotate()
+	api.Context = "/pizzashack/{version}"
+	api.EndpointConfig = map[string]interface{}{"endpoint_type": "awslambda"}
+	err := AddWSO2Extensions(definition, api)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "/pizzashack/1.0.0", definition["x-wso2-basePath"], "should substitute the version")
+	assert.NotContains(t, definition, "x-wso2-production-endpoints",
+		"should not add the endpoints of an unsupported endpoint type")
+	securityScheme := definition["securityDefinitions"].(map[string]interface{})["default"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"order:write": "Place orders"}, securityScheme["scopes"],
+		"should add the scopes to the security definitions")
+	assert.Equal(t, map[string]interface{}{"order:write": "admin,customer"}, securityScheme["x-scopes-bindings"],
+		"should add the role bindings of the scopes")
+}
+
+func TestAddWSO2ExtensionsRoundTripWithSwagger2(t *testing.T) {
+	definition := map[string]interface{}{
+		"swagger": "2.0",
+		"info":    map[string]interface{}{"title": "PizzaShackAPI", "version": "1.0.0"},
+	}
+	api := getAPIToAnnotate()
+	api.IsDefaultVersion = false
+	api.SecurityScheme = []string{}
+	err := AddWSO2Extensions(definition, api)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "/pizzashack/{version}", definition["x-wso2-basePath"],
+		"should add the version template to the context of a version which is not the default")
+
+	content, err := json.Marshal(definition)
+	assert.Nil(t, err, "err should be nil")
+	document, err := loads.Analyzed(content, "")
+	assert.Nil(t, err, "err should be nil")
+	def := APIDTODefinition{}
+	err = Swagger2Populate(&def, document)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "/pizzashack", def.Context, "should return the same context")
+	assert.False(t, def.IsDefaultVersion, "should return the same default version")
+	assert.Equal(t, []string{"https"}, def.Transport, "should return the same transports")
+	assert.Equal(t, "X-Authorization", def.AuthorizationHeader, "should return the same authorization header")
+	assert.Equal(t, "Gold", def.APIThrottlingPolicy, "should return the same throttling policy")
+	assert.Empty(t, def.SecurityScheme, "should return the same security schemes")
+}
+
+func TestWSO2SecuritySchemesOfMutualSSL(t *testing.T) {
+	definition := map[string]interface{}{}
+	addSecuritySchemeExtensions(definition, []string{"mutualssl", "mutualssl_mandatory", "oauth2"})
+	content, err := json.Marshal(definition)
+	assert.Nil(t, err, "err should be nil")
+
+	securitySchemes, ok, err := wso2SecuritySchemes(content)
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, ok, "should have the security extensions")
+	assert.Equal(t, []string{"oauth2", "mutualssl", "mutualssl_mandatory"}, securitySchemes,
+		"should return the same security schemes")
+}
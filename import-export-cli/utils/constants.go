@@ -68,6 +68,7 @@ const ExportedApisDirName = "apis"
 const ExportedApiProductsDirName = "api-products"
 const ExportedAppsDirName = "apps"
 const ExportedMigrationArtifactsDirName = "migration"
+const ImportedMigrationArtifactsDirName = "migration-import"
 const CertificatesDirName = "certs"
 
 const (
@@ -164,11 +165,37 @@ const TLSRenegotiationOnce = "once"
 // TLSRenegotiationFreely : negotiate freely
 const TLSRenegotiationFreely = "freely"
 
+// VCSStateBackendFile : keep the VCS deployment state in the local VCS config file
+const VCSStateBackendFile = "file"
+
+// VCSStateBackendGit : keep the VCS deployment state in a dedicated git ref of the repository
+const VCSStateBackendGit = "git"
+
+// DefaultVCSLockExpiry : number of minutes after which the deployment lock of an environment expires
+const DefaultVCSLockExpiry = 60
+
 // Migration export
 const MaxAPIsToExportOnce = 20
 const MigrationAPIsExportMetadataFileName = "migration-apis-export-metadata.yaml"
 const LastSucceededApiFileName = "last-succeeded-api.log"
 const LastSuceededContentDelimiter = " " // space
+const MigrationAPIProductsExportMetadataFileName = "migration-api-products-export-metadata.yaml"
+const LastSucceededApiProductFileName = "last-succeeded-api-product.log"
+const MigrationAppsExportMetadataFileName = "migration-apps-export-metadata.yaml"
+const LastSucceededAppFileName = "last-succeeded-app.log"
+
+// Migration import
+const MigrationAPIsImportMetadataFileName = "migration-apis-import-metadata.yaml"
+const MigrationAPIProductsImportMetadataFileName = "migration-api-products-import-metadata.yaml"
+const MigrationAppsImportMetadataFileName = "migration-apps-import-metadata.yaml"
+const LastImportedApiFileName = "last-imported-api.log"
+const LastImportedApiProductFileName = "last-imported-api-product.log"
+const LastImportedAppFileName = "last-imported-app.log"
+const MaxArchivesToImportOnce = 20
+
+// Tenant migration
+const MigratedTenantsDirName = "migration-tenant"
+const MigrationTenantMetadataFileName = "migration-tenant-metadata.yaml"
 const DefaultResourceTenantDomain = "tenant-default"
 const ApplicationId = "applicationId"
 const ApiId = "apiId"
@@ -190,6 +217,7 @@ const (
 	ProjectTypeApi         = "API"
 	ProjectTypeApiProduct  = "API Product"
 	ProjectTypeApplication = "Application"
+	ProjectTypeMICApp      = "MI CApp"
 )
 
 // project param files
@@ -210,8 +238,12 @@ const (
 	MetaFileAPI         = "api_meta.yaml"
 	MetaFileAPIProduct  = "api_product_meta.yaml"
 	MetaFileApplication = "application_meta.yaml"
+	MetaFileMICApp      = "mi_meta.yaml"
 )
 
+// extension of the Carbon Application (CApp) files deployed to the Micro Integrator
+const CAppFileExtension = ".car"
+
 const DeploymentEnvFile = "deployment_environments.yaml"
 const PrivateJetModeConst = "privateJet"
 const SidecarModeConst = "sidecar"
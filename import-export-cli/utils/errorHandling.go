@@ -67,3 +67,25 @@ func GetHttpErrorResponse(err error) error {
 	json.Unmarshal([]byte(err.Error()), &errorResponse)
 	return errors.New(cast.ToString(errorResponse.Code) + "-" + errorResponse.Status + " : " + errorResponse.Description)
 }
+
+// HttpResponseError is returned when an HTTP request is responded with an error status, so that the status code and
+//  the response body can be reported along with the error message
+type HttpResponseError struct {
+	StatusCode int
+	Body       string
+	message    string
+}
+
+func (e *HttpResponseError) Error() string {
+	return e.message
+}
+
+// Creates an error for an erroneous http response
+// message is the error message to be returned by Error()
+func NewHttpResponseError(response *resty.Response, message string) error {
+	return &HttpResponseError{
+		StatusCode: response.StatusCode(),
+		Body:       string(response.Body()),
+		message:    message,
+	}
+}
@@ -95,3 +95,136 @@ func WriteMigrationApisExportMetadataFile(apis []API, cmdResourceTenantDomain st
 
 	WriteConfigFile(exportMetaData, filepath.Join(exportRelatedFilesPath, MigrationAPIsExportMetadataFileName))
 }
+
+// Read the ID of finally and successfully exported API Product from the last-succeeded-api-product.log file
+func ReadLastSucceededAPIProductFileData(exportRelatedFilesPath string) string {
+	return readLastSucceededId(filepath.Join(exportRelatedFilesPath, LastSucceededApiProductFileName))
+}
+
+// Write the last-succeeded-api-product.log file. It includes the ID, name and provider of the API Product, which was
+// successfully exported finally
+func WriteLastSucceededAPIProductFileData(exportRelatedFilesPath string, apiProduct APIProduct) {
+	writeLastSucceededData(filepath.Join(exportRelatedFilesPath, LastSucceededApiProductFileName),
+		apiProduct.ID, apiProduct.Name, apiProduct.Provider)
+}
+
+// Read the ID of finally and successfully exported Application from the last-succeeded-app.log file
+func ReadLastSucceededAppFileData(exportRelatedFilesPath string) string {
+	return readLastSucceededId(filepath.Join(exportRelatedFilesPath, LastSucceededAppFileName))
+}
+
+// Write the last-succeeded-app.log file. It includes the ID, owner and name of the Application, which was
+// successfully exported finally. The name is written last since the name of an Application may have spaces.
+func WriteLastSucceededAppFileData(exportRelatedFilesPath string, app Application) {
+	writeLastSucceededData(filepath.Join(exportRelatedFilesPath, LastSucceededAppFileName),
+		app.ID, app.Owner, app.Name)
+}
+
+// Read the ID, which is the first field, of a last succeeded file
+func readLastSucceededId(lastSucceededFilePath string) string {
+	data, err := ioutil.ReadFile(lastSucceededFilePath)
+	if err != nil {
+		HandleErrorAndExit("Error in reading file "+lastSucceededFilePath, err)
+	}
+	return strings.TrimSpace(strings.SplitN(string(data), LastSuceededContentDelimiter, 2)[0])
+}
+
+func writeLastSucceededData(lastSucceededFilePath string, fields ...string) {
+	content := []byte(strings.Join(fields, LastSuceededContentDelimiter))
+	if err := ioutil.WriteFile(lastSucceededFilePath, content, 0644); err != nil {
+		HandleErrorAndExit("Error in writing file "+lastSucceededFilePath, err)
+	}
+}
+
+// Read the migration-api-products-export-metadata.yaml file
+func (migrationAPIProductsExportMetadata *MigrationAPIProductsExportMetadata) ReadMigrationAPIProductsExportMetadataFile(
+	filePath string) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, migrationAPIProductsExportMetadata)
+}
+
+// Write the migration-api-products-export-metadata.yaml file. This includes the list of API Products exported at the
+// iteration along with the offset of the list, the user and the tenant, similar to migration-apis-export-metadata.yaml
+func WriteMigrationAPIProductsExportMetadataFile(apiProducts []APIProduct, cmdResourceTenantDomain, cmdUsername,
+	exportRelatedFilesPath string, apiProductListOffset int) {
+	exportMetaData := &MigrationAPIProductsExportMetadata{
+		ApiProductListOffset:   apiProductListOffset,
+		User:                   cmdUsername,
+		OnTenant:               cmdResourceTenantDomain,
+		ApiProductListToExport: apiProducts,
+	}
+	WriteConfigFile(exportMetaData, filepath.Join(exportRelatedFilesPath, MigrationAPIProductsExportMetadataFileName))
+}
+
+// Read the migration-apps-export-metadata.yaml file
+func (migrationAppsExportMetadata *MigrationAppsExportMetadata) ReadMigrationAppsExportMetadataFile(
+	filePath string) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, migrationAppsExportMetadata)
+}
+
+// Write the migration-apps-export-metadata.yaml file. This includes the list of Applications exported at the
+// iteration along with the offset of the list, the user and the tenant, similar to migration-apis-export-metadata.yaml
+func WriteMigrationAppsExportMetadataFile(apps []Application, cmdResourceTenantDomain, cmdUsername,
+	exportRelatedFilesPath string, appListOffset int) {
+	exportMetaData := &MigrationAppsExportMetadata{
+		AppListOffset:   appListOffset,
+		User:            cmdUsername,
+		OnTenant:        cmdResourceTenantDomain,
+		AppListToExport: apps,
+	}
+	WriteConfigFile(exportMetaData, filepath.Join(exportRelatedFilesPath, MigrationAppsExportMetadataFileName))
+}
+
+// Read the import metadata file (e.g. migration-apis-import-metadata.yaml)
+func (migrationImportMetadata *MigrationImportMetadata) ReadMigrationImportMetadataFile(filePath string) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, migrationImportMetadata)
+}
+
+// Write the import metadata file (e.g. migration-apis-import-metadata.yaml). This includes the list of archives to
+// import and the results of the archives imported so far
+func (migrationImportMetadata *MigrationImportMetadata) WriteMigrationImportMetadataFile(filePath string) {
+	WriteConfigFile(migrationImportMetadata, filePath)
+}
+
+// Read the name of the archive which was processed last from the given file (e.g. last-imported-api.log)
+func ReadLastImportedFileData(lastImportedFilePath string) string {
+	data, err := ioutil.ReadFile(lastImportedFilePath)
+	if err != nil {
+		HandleErrorAndExit("Error in reading file "+lastImportedFilePath, err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// Write the name of the archive which was processed last into the given file (e.g. last-imported-api.log), regardless
+// of whether the import was successful or not
+func WriteLastImportedFileData(lastImportedFilePath, archiveName string) {
+	if err := ioutil.WriteFile(lastImportedFilePath, []byte(archiveName), 0644); err != nil {
+		HandleErrorAndExit("Error in writing file "+lastImportedFilePath, err)
+	}
+}
+
+// Read the migration-tenant-metadata.yaml file
+func (migrationTenantMetadata *MigrationTenantMetadata) ReadMigrationTenantMetadataFile(filePath string) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, migrationTenantMetadata)
+}
+
+// Write the migration-tenant-metadata.yaml file. This includes the environments and the tenant of the migration along
+// with the steps of the migration completed so far
+func (migrationTenantMetadata *MigrationTenantMetadata) WriteMigrationTenantMetadataFile(filePath string) {
+	WriteConfigFile(migrationTenantMetadata, filePath)
+}
@@ -43,6 +43,9 @@ type Config struct {
 	VCSConfigFilePath     string `yaml:"vcs_config_file_path"`
 	VCSSourceRepoPath     string `yaml:"vcs_source_repo_path"`
 	VCSDeploymentRepoPath string `yaml:"vcs_deployment_repo_path"`
+	VCSStateBackend       string `yaml:"vcs_state_backend"`
+	VCSStateRemote        string `yaml:"vcs_state_remote"`
+	VCSLockExpiry         int    `yaml:"vcs_lock_expiry"`
 	TLSRenegotiationMode  string `yaml:"tls-renegotiation-mode"`
 }
 
@@ -60,6 +63,10 @@ type EnvEndpoints struct {
 	AdminEndpoint        string `yaml:"admin"`
 	TokenEndpoint        string `yaml:"token"`
 	MiManagementEndpoint string `yaml:"mi"`
+	// Whether the destructive vcs deployments to the environment need an approval
+	Protected bool `yaml:"protected,omitempty"`
+	// Maximum number of projects deployed to a protected environment at once without an approval (0 for no limit)
+	MaxChanges int `yaml:"maxChanges,omitempty"`
 }
 
 type MgwEndpoints struct {
@@ -130,6 +137,54 @@ type MigrationApisExportMetadata struct {
 	ApiListToExport []API  `yaml:"apis_to_export"`
 }
 
+type MigrationAPIProductsExportMetadata struct {
+	ApiProductListOffset   int          `yaml:"api_product_list_offset"`
+	User                   string       `yaml:"user"`
+	OnTenant               string       `yaml:"on_tenant"`
+	ApiProductListToExport []APIProduct `yaml:"api_products_to_export"`
+}
+
+type MigrationAppsExportMetadata struct {
+	AppListOffset   int           `yaml:"app_list_offset"`
+	User            string        `yaml:"user"`
+	OnTenant        string        `yaml:"on_tenant"`
+	AppListToExport []Application `yaml:"apps_to_export"`
+}
+
+// MigrationImportMetadata is the state of an "import apis", "import api-products" or "import apps" operation, which is
+// used to resume the operation
+type MigrationImportMetadata struct {
+	Source           string            `yaml:"source"`
+	Environment      string            `yaml:"environment"`
+	User             string            `yaml:"user"`
+	ArchivesToImport []string          `yaml:"archives_to_import"`
+	ImportedArchives []string          `yaml:"imported_archives"`
+	FailedArchives   map[string]string `yaml:"failed_archives"`
+}
+
+// MigrationTenantMetadata is the state of a "migrate tenant" operation, which is used to resume the operation
+type MigrationTenantMetadata struct {
+	From           string         `yaml:"from"`
+	To             string         `yaml:"to"`
+	Tenant         string         `yaml:"tenant"`
+	User           string         `yaml:"user"`
+	CurrentStep    string         `yaml:"current_step"`
+	CompletedSteps []string       `yaml:"completed_steps"`
+	FailedImports  map[string]int `yaml:"failed_imports"`
+	// Revisions of the APIs created in the import-apis step, in the order to be deployed in the deploy-revisions step
+	APIRevisions []MigratedAPIRevision `yaml:"api_revisions,omitempty"`
+}
+
+// MigratedAPIRevision is a revision of an API created from a revision archive in the import-apis step of a tenant
+// migration, which is deployed in the deploy-revisions step
+type MigratedAPIRevision struct {
+	Archive     string       `yaml:"archive"`
+	APIId       string       `yaml:"api_id"`
+	RevisionId  string       `yaml:"revision_id"`
+	Deployments []Deployment `yaml:"deployments,omitempty"`
+	Deployed    bool         `yaml:"deployed"`
+}
+
 type HttpErrorResponse struct {
 	Code        int     `json:"code"`
 	Status      string  `json:"message"`
@@ -363,6 +418,32 @@ type MetaData struct {
 
 type DeployConfig struct {
 	Import ImportConfig `json:"import,omitempty" yaml:"import,omitempty"`
+	// Microgateway adapter environments (mgw-clusters) which the API is deployed to, per environment
+	Microgateway map[string][]string `json:"microgateway,omitempty" yaml:"microgateway,omitempty"`
+	Hooks        DeployHooks         `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+}
+
+// DeployHooks are the hooks run by vcs deploy around importing an API or an API Product project
+type DeployHooks struct {
+	PreDeploy  []DeployHook `json:"preDeploy,omitempty" yaml:"preDeploy,omitempty"`
+	PostDeploy []DeployHook `json:"postDeploy,omitempty" yaml:"postDeploy,omitempty"`
+	// Whether the environment is rolled back if a post-deploy hook fails, even when deploying with --skip-rollback
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty" yaml:"rollbackOnFailure,omitempty"`
+}
+
+// DeployHook is either a local command or a built-in check
+type DeployHook struct {
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	Check   string `json:"check,omitempty" yaml:"check,omitempty"`
+	// Time in seconds to wait for the hook to complete
+	Timeout int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Base URL of the gateway, resource path (relative to the API context), headers and the expected status code of
+	//  the smoke request
+	Gateway        string            `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	Path           string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	ExpectedStatus int               `json:"expectedStatus,omitempty" yaml:"expectedStatus,omitempty"`
 }
 
 type ImportConfig struct {
@@ -390,6 +471,7 @@ type Revisions struct {
 }
 
 type Deployment struct {
-	Name               string `json:"name"`
-	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+	Name               string `json:"name" yaml:"name"`
+	Vhost              string `json:"vhost,omitempty" yaml:"vhost,omitempty"`
+	DisplayOnDevportal bool   `json:"displayOnDevportal" yaml:"displayOnDevportal"`
 }